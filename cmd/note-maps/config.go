@@ -19,10 +19,13 @@ import (
 	"flag"
 	"io"
 	"os"
+	"os/user"
 	"path/filepath"
+	"strings"
 
 	"github.com/99designs/keyring"
 	"github.com/google/note-maps/note"
+	"github.com/google/note-maps/note/history"
 	"github.com/google/note-maps/note/textile"
 	"github.com/google/subcommands"
 )
@@ -34,6 +37,9 @@ type Config struct {
 	output     io.Writer
 	dataHome   string
 	thread     string
+	locale     string
+	validate   bool
+	history    string
 }
 
 type addCloser struct {
@@ -50,7 +56,28 @@ func (c addCloser) Close() error {
 	return e1
 }
 
-func (c *Config) open() (note.Database, error) {
+// open opens the configured database, using ctx to abort connecting to it.
+//
+// If a history file is configured, every change written through the result
+// is recorded in it.
+func (c *Config) open(ctx context.Context) (note.Database, error) {
+	db, err := c.openDB(ctx)
+	if err != nil || c.history == "" {
+		return db, err
+	}
+	s, err := history.OpenFileStore(c.history)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	hdb := &history.Database{DB: db, Store: s}
+	if u, err := user.Current(); err == nil {
+		hdb.Author = u.Username
+	}
+	return hdb, nil
+}
+
+func (c *Config) openDB(ctx context.Context) (note.Database, error) {
 	if c.overrideDb != nil {
 		return c.overrideDb, nil
 	}
@@ -89,13 +116,43 @@ func (c *Config) open() (note.Database, error) {
 	if c.thread != "" {
 		opts = append(opts, textile.WithThread(c.thread))
 	}
-	nm, err := textile.Open(context.Background(), n, opts...)
+	if c.validate {
+		opts = append(opts, textile.WithValidation())
+	}
+	nm, err := textile.Open(ctx, n, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return addCloser{nm, n.Close}, nil
 }
 
+// locales returns the preferred locales for display names, most preferred
+// first.
+func (c *Config) locales() []string {
+	var ls []string
+	for _, l := range strings.Split(c.locale, ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			ls = append(ls, l)
+		}
+	}
+	return ls
+}
+
+// localeFromEnv returns the locale of messages given by the environment as a
+// BCP 47 tag, as in "en-US" for "en_US.UTF-8", or "" if there is none.
+func localeFromEnv() string {
+	for _, n := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(n)
+		if i := strings.IndexAny(v, ".@"); i >= 0 {
+			v = v[:i]
+		}
+		if v != "" && v != "C" && v != "POSIX" {
+			return strings.ReplaceAll(v, "_", "-")
+		}
+	}
+	return ""
+}
+
 var (
 	globalConfig = Config{
 		input:  os.Stdin,
@@ -122,6 +179,12 @@ func init() {
 	os.MkdirAll(globalConfig.dataHome, 0700) // ignore error, it might not matter.
 	flag.StringVar(&globalConfig.Db, "db", "", "location for data files")
 	flag.StringVar(&globalConfig.thread, "thread_id", "", "ThreadsDB thread id")
+	flag.StringVar(&globalConfig.locale, "locale", localeFromEnv(),
+		"comma-separated preferred locales for display names")
+	flag.BoolVar(&globalConfig.validate, "validate", false,
+		"refuse changes that violate the constraints of note types")
+	flag.StringVar(&globalConfig.history, "history", "",
+		"file in which to record the history of changes, if any")
 }
 
 type configCmd struct {
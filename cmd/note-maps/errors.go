@@ -0,0 +1,61 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/note-maps/note"
+	"github.com/google/subcommands"
+)
+
+// Exit statuses for failures that scripts may want to tell apart, in addition
+// to those defined by package subcommands:
+//
+//	0  success
+//	1  any other failure
+//	2  usage error
+//	3  the change conflicts with the current state of a note
+//	4  an ID or value is not valid
+//	5  the operation is not supported
+//	6  the database is closed
+//	7  the command was interrupted or timed out
+const (
+	exitConflict subcommands.ExitStatus = iota + 3
+	exitInvalid
+	exitUnsupported
+	exitClosed
+	exitInterrupted
+)
+
+// exitStatus returns the exit status that describes err.
+func exitStatus(err error) subcommands.ExitStatus {
+	switch {
+	case err == nil:
+		return subcommands.ExitSuccess
+	case errors.Is(err, note.ConflictingDelta):
+		return exitConflict
+	case errors.Is(err, note.InvalidID), errors.Is(err, note.ValidationFailed):
+		return exitInvalid
+	case errors.Is(err, note.UnsupportedOperation):
+		return exitUnsupported
+	case errors.Is(err, note.ClosedDatabase):
+		return exitClosed
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return exitInterrupted
+	}
+	return subcommands.ExitFailure
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/note-maps/note"
+	"github.com/google/note-maps/note/json"
+	"github.com/google/subcommands"
+)
+
+// formats are the supported values of the -format flag of export and import.
+var formats = []string{"json"}
+
+func supportedFormat(format string) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+type exportCmd struct {
+	cfg    *Config
+	format string
+}
+
+func (*exportCmd) Name() string     { return "export" }
+func (*exportCmd) Synopsis() string { return "Write the whole note map to stdout." }
+func (*exportCmd) Usage() string {
+	return `export [-format=json]:
+  Print every note in the note map, in a form that can be read by import.
+`
+}
+func (c *exportCmd) SetConfig(cfg *Config) { c.cfg = cfg }
+func (c *exportCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&c.format, "format", "json", fmt.Sprintf("output format, one of %v", formats))
+}
+func (c *exportCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if len(f.Args()) > 0 {
+		return subcommands.ExitUsageError
+	}
+	if !supportedFormat(c.format) {
+		fmt.Fprintln(os.Stderr, "export: unsupported format", c.format)
+		return subcommands.ExitUsageError
+	}
+
+	db, err := c.cfg.open(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export: while opening db:", err)
+		return exitStatus(err)
+	}
+	defer db.Close()
+
+	if err = note.IsolatedReadContext(ctx, db, func(r note.FindLoader) error {
+		return json.Export(c.cfg.output, r)
+	}); err != nil {
+		fmt.Fprintln(os.Stderr, "export:", err)
+		return exitStatus(err)
+	}
+	return subcommands.ExitSuccess
+}
+
+func init() {
+	subcommands.Register(&exportCmd{cfg: &globalConfig}, "notes")
+}
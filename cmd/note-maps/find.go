@@ -19,6 +19,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/google/note-maps/note"
 	"github.com/google/note-maps/note/yaml"
@@ -34,41 +35,59 @@ func (*findCmd) Synopsis() string { return "Find notes matching a query." }
 func (*findCmd) Usage() string {
 	return `find <query>:
   Print matching notes to stdout.
+
+  A query is a sequence of words and key:value terms. Words must appear in
+  the value of a matching note. Supported keys are prefix, vtype, type,
+  anytype, si, in, offset, and limit.
+
+  Notes with names are annotated with comments showing the names, in the
+  locales given by -locale where possible.
 `
 }
 func (c *findCmd) SetConfig(cfg *Config) { c.cfg = cfg }
 func (c *findCmd) SetFlags(f *flag.FlagSet) {
 	//f.BoolVar(&c.capitalize, "capitalize", false, "capitalize output")
 }
-func (c *findCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
-	db, err := c.cfg.open()
+func (c *findCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	q, err := note.ParseQuery(strings.Join(f.Args(), " "))
 	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		return subcommands.ExitFailure
+		fmt.Fprintln(os.Stderr, "find:", err)
+		return subcommands.ExitUsageError
 	}
-	defer db.Close()
-	var ns []note.GraphNote
-	err = db.IsolatedRead(func(r note.FindLoader) (e error) {
-		ns, e = r.Find(&note.Query{})
-		return e
-	})
+	db, err := c.cfg.open(ctx)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		return subcommands.ExitFailure
+		return exitStatus(err)
 	}
-	for _, n := range ns {
-		bs, err := yaml.MarshalNote(n)
+	defer db.Close()
+	var (
+		m     = yaml.Marshaler{Locales: c.cfg.locales()}
+		found int
+	)
+	err = note.IsolatedReadContext(ctx, db, func(r note.FindLoader) error {
+		ns, err := r.Find(q)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			return subcommands.ExitFailure
+			return err
 		}
-		c.cfg.output.Write([]byte("---\n"))
-		c.cfg.output.Write(bs)
+		found = len(ns)
+		for _, n := range ns {
+			bs, err := m.MarshalNote(n)
+			if err != nil {
+				return err
+			}
+			c.cfg.output.Write([]byte("---\n"))
+			c.cfg.output.Write(bs)
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitStatus(err)
 	}
-	if len(ns) > 0 {
+	if found > 0 {
 		c.cfg.output.Write([]byte("---\n"))
 	}
-	if len(ns) == 0 {
+	if found == 0 {
 		fmt.Fprintln(os.Stderr, "no matching notes found")
 		return subcommands.ExitFailure
 	}
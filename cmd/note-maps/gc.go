@@ -0,0 +1,84 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/note-maps/note"
+	"github.com/google/note-maps/note/gc"
+	"github.com/google/subcommands"
+)
+
+type gcCmd struct {
+	cfg    *Config
+	dryRun bool
+}
+
+func (*gcCmd) Name() string     { return "gc" }
+func (*gcCmd) Synopsis() string { return "Delete notes that cannot be reached from root notes." }
+func (*gcCmd) Usage() string {
+	return `gc [-n] <root-id>...:
+  Clear every note that cannot be reached from any of the identified root
+  notes through content, types, value types, or roles, and print its id.
+`
+}
+func (c *gcCmd) SetConfig(cfg *Config) { c.cfg = cfg }
+func (c *gcCmd) SetFlags(f *flag.FlagSet) {
+	f.BoolVar(&c.dryRun, "n", false, "only print the ids of orphaned notes, without deleting them")
+}
+func (c *gcCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if len(f.Args()) == 0 {
+		fmt.Fprintln(os.Stderr, "gc: at least one root id is required")
+		return subcommands.ExitUsageError
+	}
+	roots := make([]note.ID, len(f.Args()))
+	for i, arg := range f.Args() {
+		if roots[i] = note.ID(arg); roots[i].Empty() {
+			fmt.Fprintln(os.Stderr, "gc: a non-zero id is required")
+			return subcommands.ExitUsageError
+		}
+	}
+
+	db, err := c.cfg.open(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gc: while opening db:", err)
+		return exitStatus(err)
+	}
+	defer db.Close()
+
+	var orphans []note.ID
+	if c.dryRun {
+		orphans, err = gc.Report(ctx, db, roots)
+	} else {
+		orphans, err = gc.Collect(ctx, db, roots)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gc:", err)
+		return exitStatus(err)
+	}
+
+	for _, id := range orphans {
+		fmt.Fprintln(c.cfg.output, id)
+	}
+	return subcommands.ExitSuccess
+}
+
+func init() {
+	subcommands.Register(&gcCmd{cfg: &globalConfig}, "notes")
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/note-maps/note"
+	"github.com/google/note-maps/note/json"
+	"github.com/google/subcommands"
+)
+
+type importCmd struct {
+	cfg    *Config
+	format string
+}
+
+func (*importCmd) Name() string     { return "import" }
+func (*importCmd) Synopsis() string { return "Read notes into the note map." }
+func (*importCmd) Usage() string {
+	return `import [-format=json] [<file>]:
+  Read notes from file, or from stdin if no file is given, and change each
+  note in the note map to match, all in a single change. Inline notes
+  without ids are created with new ids.
+`
+}
+func (c *importCmd) SetConfig(cfg *Config) { c.cfg = cfg }
+func (c *importCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&c.format, "format", "json", fmt.Sprintf("input format, one of %v", formats))
+}
+func (c *importCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if len(f.Args()) > 1 {
+		return subcommands.ExitUsageError
+	}
+	if !supportedFormat(c.format) {
+		fmt.Fprintln(os.Stderr, "import: unsupported format", c.format)
+		return subcommands.ExitUsageError
+	}
+	r := c.cfg.input
+	if len(f.Args()) == 1 {
+		file, err := os.Open(f.Args()[0])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "import: while opening", f.Args()[0], ":", err)
+			return subcommands.ExitFailure
+		}
+		defer file.Close()
+		r = file
+	}
+
+	db, err := c.cfg.open(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "import: while opening db:", err)
+		return exitStatus(err)
+	}
+	defer db.Close()
+
+	if err = note.IsolatedWriteContext(ctx, db, func(w note.FindLoadPatcher) error {
+		ops, err := json.Import(r, w)
+		if err != nil {
+			return err
+		}
+		return w.Patch(ops)
+	}); err != nil {
+		fmt.Fprintln(os.Stderr, "import:", err)
+		return exitStatus(err)
+	}
+	return subcommands.ExitSuccess
+}
+
+func init() {
+	subcommands.Register(&importCmd{cfg: &globalConfig}, "notes")
+}
@@ -17,7 +17,9 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"os"
+	"os/signal"
 
 	"github.com/google/subcommands"
 )
@@ -28,6 +30,20 @@ func main() {
 	subcommands.Register(subcommands.CommandsCommand(), "")
 
 	flag.Parse()
-	ctx := context.Background()
-	os.Exit(int(subcommands.Execute(ctx)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		// The first interrupt aborts whatever the command is waiting for, so
+		// that it can clean up. A second interrupt exits immediately.
+		<-interrupt
+		fmt.Fprintln(os.Stderr, "interrupted")
+		cancel()
+		<-interrupt
+		os.Exit(1)
+	}()
+	status := subcommands.Execute(ctx)
+	cancel()
+	os.Exit(int(status))
 }
@@ -0,0 +1,79 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/note-maps/note"
+	"github.com/google/subcommands"
+)
+
+type rmCmd struct {
+	cfg *Config
+}
+
+func (*rmCmd) Name() string     { return "rm" }
+func (*rmCmd) Synopsis() string { return "Delete notes." }
+func (*rmCmd) Usage() string {
+	return `rm <id>...:
+  Clear every field of each identified note, effectively deleting it.
+`
+}
+func (c *rmCmd) SetConfig(cfg *Config)    { c.cfg = cfg }
+func (c *rmCmd) SetFlags(f *flag.FlagSet) {}
+func (c *rmCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if len(f.Args()) == 0 {
+		return subcommands.ExitUsageError
+	}
+	for _, arg := range f.Args() {
+		if note.ID(arg).Empty() {
+			fmt.Fprintln(os.Stderr, "rm: a non-zero id is required")
+			return subcommands.ExitUsageError
+		}
+	}
+
+	db, err := c.cfg.open(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "rm: while opening db:", err)
+		return exitStatus(err)
+	}
+	defer db.Close()
+
+	if err = note.IsolatedWriteContext(ctx, db, func(w note.FindLoadPatcher) error {
+		stage := note.Stage{Base: w}
+		for _, arg := range f.Args() {
+			if err := stage.Note(note.ID(arg)).Clear(); err != nil {
+				return err
+			}
+		}
+		return w.Patch(stage.Ops)
+	}); err != nil {
+		fmt.Fprintln(os.Stderr, "rm: while applying change:", err)
+		return exitStatus(err)
+	}
+
+	for _, arg := range f.Args() {
+		fmt.Fprintln(c.cfg.output, arg)
+	}
+	return subcommands.ExitSuccess
+}
+
+func init() {
+	subcommands.Register(&rmCmd{&globalConfig}, "note")
+}
@@ -19,7 +19,6 @@ import (
 	"flag"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
 
 	"github.com/google/note-maps/note"
@@ -34,64 +33,83 @@ type setCmd struct {
 func (*setCmd) Name() string     { return "set" }
 func (*setCmd) Synopsis() string { return "Set the info about a subject." }
 func (*setCmd) Usage() string {
-	return `set:
-  Import a note in YAML format.
+	return `set [<file>]:
+  Read notes in YAML format from file, or from stdin if no file is given, and
+  change each note in the note map to match, all in a single change.
+
+  The input may hold several YAML documents separated by "---", such as the
+  output of find. Notes are identified by their anchors, and notes without
+  anchors are created with new ids. Within a document, an alias such as *42
+  refers to the note with the anchor &42. An item such as "- ref: 42" refers
+  to the note with id 42 wherever it is described, even in another document
+  or only in the note map.
+
+  The id of the note described by each document is printed to stdout.
 `
 }
 func (c *setCmd) SetConfig(cfg *Config) { c.cfg = cfg }
 func (c *setCmd) SetFlags(f *flag.FlagSet) {
 	//f.BoolVar(&c.capitalize, "capitalize", false, "capitalize output")
 }
-func (c *setCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
-	var (
-		r   io.Reader
-		err error
-	)
+func (c *setCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	r := c.cfg.input
 	if len(f.Args()) > 1 {
 		return subcommands.ExitUsageError
 	} else if len(f.Args()) == 1 {
-		r, err = os.Open(f.Args()[0])
+		file, err := os.Open(f.Args()[0])
 		if err != nil {
 			fmt.Fprintln(os.Stderr, "set: while opening", f.Args()[0], ":", err)
 			return subcommands.ExitFailure
 		}
-	} else {
-		r = c.cfg.input
-	}
-	input, err := ioutil.ReadAll(r)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "set: while reading input:", err)
-		return subcommands.ExitFailure
+		defer file.Close()
+		r = file
 	}
 	var (
-		stage note.Stage
-		n     note.Plain
+		d   = yaml.NewDecoder(r)
+		top []note.ID
 	)
-	err = yaml.UnmarshalNote(input, &n)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "set: while parsing input", err)
-		return subcommands.ExitFailure
+	for {
+		n, err := d.Decode()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			fmt.Fprintln(os.Stderr, "set: while parsing input:", err)
+			return subcommands.ExitFailure
+		}
+		top = append(top, n.ID)
 	}
-	if n.ID == note.EmptyID {
-		fmt.Fprintln(os.Stderr, "set: a non-zero id is required")
+	if len(top) == 0 {
+		fmt.Fprintln(os.Stderr, "set: no notes in input")
 		return subcommands.ExitFailure
 	}
 
-	db, err := c.cfg.open()
+	db, err := c.cfg.open(ctx)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "set: while opening db:", err)
-		return subcommands.ExitFailure
+		return exitStatus(err)
 	}
 	defer db.Close()
 
-	if err = db.IsolatedWrite(func(w note.FindLoadPatcher) error {
+	if err = note.IsolatedWriteContext(ctx, db, func(w note.FindLoadPatcher) error {
+		stage := note.Stage{Base: w}
+		for _, n := range d.Defined() {
+			tn, err := note.TruncateNote(n.GraphNote())
+			if err != nil {
+				return err
+			}
+			if err := stage.Note(n.ID).Replace(tn); err != nil {
+				return err
+			}
+		}
 		return w.Patch(stage.Ops)
 	}); err != nil {
 		fmt.Fprintln(os.Stderr, "set: while applying change:", err)
-		return subcommands.ExitFailure
+		return exitStatus(err)
 	}
 
-	fmt.Fprintln(c.cfg.output, n.ID)
+	for _, id := range top {
+		fmt.Fprintln(c.cfg.output, id)
+	}
 	return subcommands.ExitSuccess
 }
 
@@ -0,0 +1,95 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/note-maps/note"
+	"github.com/google/subcommands"
+)
+
+type validateCmd struct {
+	cfg *Config
+}
+
+func (*validateCmd) Name() string { return "validate" }
+func (*validateCmd) Synopsis() string {
+	return "Check notes against the constraints of their types."
+}
+func (*validateCmd) Usage() string {
+	return `validate [<id>...]:
+  Print each violation of the constraints declared by the types of the
+  identified notes, or of every note if no ids are given.
+`
+}
+func (c *validateCmd) SetConfig(cfg *Config)    { c.cfg = cfg }
+func (c *validateCmd) SetFlags(f *flag.FlagSet) {}
+func (c *validateCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	ids := make([]note.ID, len(f.Args()))
+	for i, arg := range f.Args() {
+		if ids[i] = note.ID(arg); ids[i].Empty() {
+			fmt.Fprintln(os.Stderr, "validate: a non-zero id is required")
+			return subcommands.ExitUsageError
+		}
+	}
+
+	db, err := c.cfg.open(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "validate: while opening db:", err)
+		return exitStatus(err)
+	}
+	defer db.Close()
+
+	var vs []note.Violation
+	err = note.IsolatedReadContext(ctx, db, func(r note.FindLoader) error {
+		var v note.Validator
+		if len(ids) == 0 {
+			var err error
+			vs, err = v.ValidateAll(r)
+			return err
+		}
+		ns, err := r.Load(ids)
+		if err != nil {
+			return err
+		}
+		for _, n := range ns {
+			nvs, err := v.Validate(n)
+			if err != nil {
+				return err
+			}
+			vs = append(vs, nvs...)
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "validate:", err)
+		return exitStatus(err)
+	}
+	for _, v := range vs {
+		fmt.Fprintln(c.cfg.output, v)
+	}
+	if len(vs) > 0 {
+		return exitStatus(note.ValidationFailed)
+	}
+	return subcommands.ExitSuccess
+}
+
+func init() {
+	subcommands.Register(&validateCmd{&globalConfig}, "notes")
+}
@@ -17,12 +17,19 @@ package nmgql
 import (
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/google/note-maps/note"
 	"github.com/google/note-maps/note/graph"
 	"github.com/google/note-maps/note/graph/generated"
+	"github.com/google/note-maps/note/textile"
+	"github.com/textileio/go-threads/core/app"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
@@ -30,34 +37,129 @@ func GetGoVersion() string {
 	return runtime.Version()
 }
 
-func WarmUp()                           { defaultServer.WarmUp(context.Background()) }
+func WarmUp() error                     { return defaultServer.WarmUp(context.Background()) }
 func Request(bs []byte) ([]byte, error) { return defaultServer.Request(context.Background(), bs) }
-func CoolDown()                         { defaultServer.CoolDown(context.Background()) }
+func CoolDown() error                   { return defaultServer.CoolDown(context.Background()) }
+
+// SetDataDirectory sets the directory in which the note map is stored. It has
+// no effect on a note map that is already open.
+func SetDataDirectory(dir string) { defaultServer.dir = dir }
 
 var (
 	defaultServer server
 )
 
 type server struct {
+	// open opens the note map to serve. If it is nil, the note map is opened
+	// with openTextile.
+	open func(ctx context.Context) (note.Database, error)
+
+	// dir is the directory used by openTextile.
+	dir string
+
+	db   note.Database
 	exec *executor.Executor
 }
 
-func (s *server) WarmUp(ctx context.Context) {
+func (s *server) WarmUp(ctx context.Context) error {
 	if s.exec == nil {
-		// TODO: initialize database/network/etc.
+		open := s.open
+		if open == nil {
+			open = s.openTextile
+		}
+		db, err := open(ctx)
+		if err != nil {
+			return wrap(err, "note map could not be opened")
+		}
+		s.db = db
 		s.exec = executor.New(generated.NewExecutableSchema(generated.Config{
-			Resolvers: &graph.Resolver{},
+			Resolvers: &graph.Resolver{DB: db},
 		}))
+		s.exec.SetErrorPresenter(graph.ErrorPresenter)
 	}
+	return nil
 }
 
-func (s *server) CoolDown(ctx context.Context) {
-	// TODO: shut down database/network/etc.
+func (s *server) CoolDown(ctx context.Context) error {
+	if s.db == nil {
+		return nil
+	}
+	err := s.db.Close()
+	s.db, s.exec = nil, nil
+	return err
+}
+
+// openTextile opens the note map stored in s.dir, creating it if it does not
+// exist yet.
+//
+// The thread ID and the thread key are kept in files alongside the data,
+// since there is no system keyring on every platform that Flutter supports.
+func (s *server) openTextile(ctx context.Context) (note.Database, error) {
+	dir := s.dir
+	if dir == "" {
+		config, err := os.UserConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(config, "note-maps")
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "keys"), 0700); err != nil {
+		return nil, err
+	}
+	n, err := textile.DefaultNetwork(filepath.Join(dir, "textile"))
+	if err != nil {
+		return nil, err
+	}
+	opts := []textile.Option{
+		textile.WithBaseDirectory(dir),
+		textile.WithGetSecret(func(key string) ([]byte, error) {
+			return ioutil.ReadFile(filepath.Join(dir, "keys", key))
+		}),
+		textile.WithSetSecret(func(key string, secret []byte) error {
+			return ioutil.WriteFile(filepath.Join(dir, "keys", key), secret, 0600)
+		}),
+	}
+	threadFile := filepath.Join(dir, "thread")
+	if bs, err := ioutil.ReadFile(threadFile); err == nil {
+		opts = append(opts, textile.WithThread(strings.TrimSpace(string(bs))))
+	} else if !os.IsNotExist(err) {
+		n.Close()
+		return nil, err
+	}
+	nm, err := textile.Open(ctx, n, opts...)
+	if err != nil {
+		n.Close()
+		return nil, err
+	}
+	if err := ioutil.WriteFile(threadFile, []byte(nm.GetThreadID().String()+"\n"), 0600); err != nil {
+		nm.Close()
+		n.Close()
+		return nil, err
+	}
+	return networkDatabase{nm, n}, nil
+}
+
+// networkDatabase is a note.Database that also closes the network it
+// replicates through.
+type networkDatabase struct {
+	*textile.Database
+	n app.Net
+}
+
+func (c networkDatabase) Close() error {
+	e0 := c.Database.Close()
+	e1 := c.n.Close()
+	if e0 != nil {
+		return e0
+	}
+	return e1
 }
 
 func (s *server) Request(ctx context.Context, in []byte) (out []byte, err error) {
 	ctx = graphql.StartOperationTrace(ctx)
-	s.WarmUp(ctx)
+	if err := s.WarmUp(ctx); err != nil {
+		return nil, err
+	}
 
 	defer func() {
 		if p := recover(); p != nil {
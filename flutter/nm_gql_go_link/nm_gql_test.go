@@ -0,0 +1,58 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nmgql
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/note-maps/note"
+	"github.com/google/note-maps/note/notetest"
+)
+
+func TestServer(t *testing.T) {
+	db := notetest.MapDB{
+		"n0":     {ID: "n0", Contents: []note.ID{"n0name"}},
+		"n0name": {ID: "n0name", ValueString: "Hello", Types: []note.ID{note.Name}},
+	}
+	s := server{open: func(context.Context) (note.Database, error) { return db, nil }}
+	ctx := context.Background()
+	if err := s.WarmUp(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer s.CoolDown(ctx)
+	out, err := s.Request(ctx, []byte(`{"query": "{ note(id: \"n0\") { displayName } }"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var response struct {
+		Data struct {
+			Note struct {
+				DisplayName string `json:"displayName"`
+			} `json:"note"`
+		} `json:"data"`
+		Errors []interface{} `json:"errors"`
+	}
+	if err := json.Unmarshal(out, &response); err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Errors) > 0 {
+		t.Fatalf("got errors %v", response.Errors)
+	}
+	if got := response.Data.Note.DisplayName; got != "Hello" {
+		t.Errorf("got display name %#v, expected %#v", got, "Hello")
+	}
+}
@@ -0,0 +1,428 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Type notes declare constraints on the notes that have them as a type by
+// including notes of the following types in their content.
+const (
+	// TypeDatatype is the type of a constraint whose value is the ID of a
+	// datatype allowed for the values of instances. If a type declares any
+	// datatypes, instances that have a value must have one of them.
+	TypeDatatype ID = "org.note-maps.type.datatype"
+
+	// TypeContent is the type of a constraint whose value is the ID of a type
+	// of content allowed in instances. Its own content may include a TypeMin
+	// and a TypeMax to limit how many such notes an instance may include.
+	//
+	// A TypeContent constraint for Name also requires the value of each name
+	// to be a valid name, as described by ValidName.
+	TypeContent ID = "org.note-maps.type.content"
+
+	// TypeMin is the type of the value of a TypeContent constraint that gives
+	// the least number of matching notes that an instance must include.
+	TypeMin ID = "org.note-maps.type.min"
+
+	// TypeMax is the type of the value of a TypeContent constraint that gives
+	// the greatest number of matching notes that an instance may include.
+	TypeMax ID = "org.note-maps.type.max"
+
+	// TypeClosed is the type of a constraint that, if present, allows only
+	// content that matches one of the TypeContent constraints of the same
+	// type.
+	TypeClosed ID = "org.note-maps.type.closed"
+)
+
+// TypeConstraints are the constraints that a type note declares for the notes
+// that have it as a type.
+type TypeConstraints struct {
+	// Type is the ID of the type note.
+	Type ID
+
+	// Datatypes are the allowed datatypes of values, or nil if any datatype
+	// is allowed.
+	Datatypes []ID
+
+	// Content are the declared types of content.
+	Content []ContentConstraint
+
+	// Closed is true if only the declared types of content are allowed.
+	Closed bool
+}
+
+// ContentConstraint limits the number of notes of a type in the content of
+// an instance.
+type ContentConstraint struct {
+	// Type is the type of the notes that this constraint applies to.
+	Type ID
+
+	// Min and Max limit the number of notes an instance may include, where
+	// a Max of zero means there is no limit.
+	Min, Max int
+}
+
+// Empty returns true if tc does not constrain instances at all.
+func (tc *TypeConstraints) Empty() bool {
+	return len(tc.Datatypes) == 0 && len(tc.Content) == 0 && !tc.Closed
+}
+
+// LoadTypeConstraints reads the constraints declared in the content of t.
+func LoadTypeConstraints(t GraphNote) (*TypeConstraints, error) {
+	tc := &TypeConstraints{Type: t.GetID()}
+	cs, err := t.GetContents()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cs {
+		kind, err := primaryTypeID(c)
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case TypeDatatype:
+			lex, _, err := c.GetValue()
+			if err != nil {
+				return nil, err
+			}
+			tc.Datatypes = append(tc.Datatypes, ID(strings.TrimSpace(lex)))
+		case TypeContent:
+			cc, err := loadContentConstraint(c)
+			if err != nil {
+				return nil, fmt.Errorf("type %v: %w", tc.Type, err)
+			}
+			tc.Content = append(tc.Content, cc)
+		case TypeClosed:
+			tc.Closed = true
+		}
+	}
+	return tc, nil
+}
+
+func loadContentConstraint(c GraphNote) (ContentConstraint, error) {
+	var cc ContentConstraint
+	lex, _, err := c.GetValue()
+	if err != nil {
+		return cc, err
+	}
+	cc.Type = ID(strings.TrimSpace(lex))
+	if cc.Type.Empty() {
+		return cc, noteError(c.GetID(), fmt.Errorf("content constraint has no type: %w", InvalidValue))
+	}
+	limits, err := c.GetContents()
+	if err != nil {
+		return cc, err
+	}
+	for _, l := range limits {
+		kind, err := primaryTypeID(l)
+		if err != nil {
+			return cc, err
+		}
+		var dst *int
+		switch kind {
+		case TypeMin:
+			dst = &cc.Min
+		case TypeMax:
+			dst = &cc.Max
+		default:
+			continue
+		}
+		lex, _, err := l.GetValue()
+		if err != nil {
+			return cc, err
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(lex))
+		if err != nil || n < 0 {
+			return cc, noteError(l.GetID(),
+				fmt.Errorf("%#v is not a valid limit: %w", lex, InvalidValue))
+		}
+		*dst = n
+	}
+	return cc, nil
+}
+
+// Violation describes a way in which a note does not satisfy the constraints
+// of one of its types.
+type Violation struct {
+	// ID is the ID of the note that violates a constraint.
+	ID ID
+
+	// Type is the ID of the type that declares the constraint.
+	Type ID
+
+	// Message describes the violation.
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("note %#v: type %#v: %v", string(v.ID), string(v.Type), v.Message)
+}
+
+// ValidationError reports the violations that caused a change to be refused.
+//
+// A ValidationError is a ValidationFailed error.
+type ValidationError []Violation
+
+func (e ValidationError) Error() string {
+	ss := make([]string, len(e))
+	for i, v := range e {
+		ss[i] = v.String()
+	}
+	return ValidationFailed.Error() + ": " + strings.Join(ss, "; ")
+}
+
+func (e ValidationError) Is(target error) bool { return target == ValidationFailed }
+
+// Validator checks notes against the constraints declared by their types.
+//
+// A Validator remembers the constraints of each type it loads, so it should
+// not be used across changes to type notes. The zero value is ready to use.
+type Validator struct {
+	types map[ID]*TypeConstraints
+}
+
+// Validate returns the violations of the constraints of the types of n.
+func (v *Validator) Validate(n GraphNote) ([]Violation, error) {
+	ts, err := n.GetTypes()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		vs       []Violation
+		cs       []GraphNote
+		ctypes   [][]ID
+		loadedCs bool
+		violate  = func(t ID, format string, args ...interface{}) {
+			vs = append(vs, Violation{n.GetID(), t, fmt.Sprintf(format, args...)})
+		}
+	)
+	for _, t := range ts {
+		tc, err := v.constraints(t)
+		if err != nil {
+			return nil, err
+		}
+		if tc.Empty() {
+			continue
+		}
+		if len(tc.Datatypes) > 0 {
+			lex, vt, err := n.GetValue()
+			if err != nil {
+				return nil, err
+			}
+			dt := idOf(vt)
+			if (lex != "" || !dt.Empty()) && !containsAny(tc.Datatypes, []ID{dt}) {
+				violate(tc.Type, "value has datatype %#v, expected one of %v",
+					string(dt), IDSlice(tc.Datatypes))
+			}
+		}
+		if len(tc.Content) == 0 && !tc.Closed {
+			continue
+		}
+		if !loadedCs {
+			if cs, err = n.GetContents(); err != nil {
+				return nil, err
+			}
+			ctypes = make([][]ID, len(cs))
+			for i, c := range cs {
+				if ctypes[i], err = typeIDsOf(c); err != nil {
+					return nil, err
+				}
+			}
+			loadedCs = true
+		}
+		for _, cc := range tc.Content {
+			count := 0
+			for i, c := range cs {
+				if !containsAny(ctypes[i], []ID{cc.Type}) {
+					continue
+				}
+				count++
+				if cc.Type == Name {
+					lex, _, err := c.GetValue()
+					if err != nil {
+						return nil, err
+					}
+					if !ValidName(lex) {
+						violate(tc.Type, "name %#v is not a valid name", lex)
+					}
+				}
+			}
+			if count < cc.Min {
+				violate(tc.Type, "has %v content of type %#v, expected at least %v",
+					count, string(cc.Type), cc.Min)
+			}
+			if cc.Max > 0 && count > cc.Max {
+				violate(tc.Type, "has %v content of type %#v, expected at most %v",
+					count, string(cc.Type), cc.Max)
+			}
+		}
+		if tc.Closed {
+			for i, c := range cs {
+				if !tc.allows(ctypes[i]) {
+					violate(tc.Type, "content %#v has no allowed type",
+						string(c.GetID()))
+				}
+			}
+		}
+	}
+	return vs, nil
+}
+
+// ValidateAll returns the violations of every note that f can find.
+func (v *Validator) ValidateAll(f Finder) ([]Violation, error) {
+	ns, err := f.Find(&Query{})
+	if err != nil {
+		return nil, err
+	}
+	var vs []Violation
+	for _, n := range ns {
+		nvs, err := v.Validate(n)
+		if err != nil {
+			return nil, err
+		}
+		vs = append(vs, nvs...)
+	}
+	return vs, nil
+}
+
+func (v *Validator) constraints(t GraphNote) (*TypeConstraints, error) {
+	if tc, ok := v.types[t.GetID()]; ok {
+		return tc, nil
+	}
+	tc, err := LoadTypeConstraints(t)
+	if err != nil {
+		return nil, err
+	}
+	if v.types == nil {
+		v.types = make(map[ID]*TypeConstraints)
+	}
+	v.types[t.GetID()] = tc
+	return tc, nil
+}
+
+func (tc *TypeConstraints) allows(types []ID) bool {
+	for _, cc := range tc.Content {
+		if containsAny(types, []ID{cc.Type}) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePatch returns a ValidationError if applying ops to base would leave
+// any of the notes they change, or any of the parents of those notes, in
+// violation of the constraints of its types.
+//
+// Parents are found only if base is also a ParentLoader. Changes to the
+// constraints declared by a type note are not checked against existing
+// instances of that type.
+func ValidatePatch(base Loader, ops []Operation) error {
+	stage := Stage{Ops: ops, Base: base}
+	var (
+		v       Validator
+		vs      []Violation
+		changed []ID
+		seen    = make(map[ID]bool)
+	)
+	validate := func(id ID) error {
+		nvs, err := v.Validate(stage.Note(id))
+		if err != nil {
+			return err
+		}
+		vs = append(vs, nvs...)
+		return nil
+	}
+	for _, op := range ops {
+		o, ok := op.(interface{ GetID() ID })
+		if !ok || seen[o.GetID()] {
+			continue
+		}
+		seen[o.GetID()] = true
+		changed = append(changed, o.GetID())
+		if err := validate(o.GetID()); err != nil {
+			return err
+		}
+	}
+	// A change to the value or types of a note can violate the content
+	// constraints of its parents. Any note that gained content was itself
+	// changed, so the parents in base are the only others to check.
+	if pl, ok := base.(ParentLoader); ok && len(changed) > 0 {
+		parents, err := pl.LoadParentIDs(changed)
+		if err != nil {
+			return err
+		}
+		for _, pids := range parents {
+			for _, pid := range pids {
+				if seen[pid] {
+					continue
+				}
+				seen[pid] = true
+				if err := validate(pid); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if len(vs) > 0 {
+		return ValidationError(vs)
+	}
+	return nil
+}
+
+func primaryTypeID(n GraphNote) (ID, error) {
+	ids, err := typeIDsOf(n)
+	if err != nil || len(ids) == 0 {
+		return EmptyID, err
+	}
+	return ids[0], nil
+}
+
+// contentIDsOf returns the IDs of the content of n without loading the
+// content if n makes that possible.
+func contentIDsOf(n GraphNote) ([]ID, error) {
+	if x, ok := n.(interface{ GetContentIDs() ([]ID, error) }); ok {
+		return x.GetContentIDs()
+	}
+	cs, err := n.GetContents()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]ID, len(cs))
+	for i, c := range cs {
+		ids[i] = c.GetID()
+	}
+	return ids, nil
+}
+
+// typeIDsOf returns the IDs of the types of n without loading the types if n
+// makes that possible.
+func typeIDsOf(n GraphNote) ([]ID, error) {
+	if x, ok := n.(interface{ GetTypeIDs() ([]ID, error) }); ok {
+		return x.GetTypeIDs()
+	}
+	ts, err := n.GetTypes()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]ID, len(ts))
+	for i, t := range ts {
+		ids[i] = t.GetID()
+	}
+	return ids, nil
+}
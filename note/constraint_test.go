@@ -0,0 +1,141 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// typedNotes has a "person" type that requires plain text values and one or two
+// valid names, and allows no other content. Tests must not modify it.
+var typedNotes = mapFindLoader{
+	"person": {ID: "person", Contents: []ID{"dt", "cn", "closed"}},
+	"dt":     {ID: "dt", ValueString: string(TextPlain), Types: []ID{TypeDatatype}},
+	"cn":     {ID: "cn", ValueString: string(Name), Types: []ID{TypeContent}, Contents: []ID{"min", "max"}},
+	"min":    {ID: "min", ValueString: "1", Types: []ID{TypeMin}},
+	"max":    {ID: "max", ValueString: "2", Types: []ID{TypeMax}},
+	"closed": {ID: "closed", Types: []ID{TypeClosed}},
+
+	"alice":  {ID: "alice", ValueString: "Alice", ValueType: TextPlain, Types: []ID{"person"}, Contents: []ID{"aname"}},
+	"aname":  {ID: "aname", ValueString: "Alice", Types: []ID{Name}},
+	"bob":    {ID: "bob", ValueString: "2020-01-01", ValueType: TextISO8601, Types: []ID{"person"}, Contents: []ID{"bother"}},
+	"bother": {ID: "bother", ValueString: "untyped"},
+	"carol":  {ID: "carol", Types: []ID{"person"}, Contents: []ID{"cname"}},
+	"cname":  {ID: "cname", ValueString: "two\nlines", Types: []ID{Name}},
+}
+
+func TestLoadTypeConstraints(t *testing.T) {
+	m := mapFindLoader{
+		"person": {ID: "person", Contents: []ID{"dt", "cn", "closed"}},
+		"dt":     {ID: "dt", ValueString: string(TextPlain), Types: []ID{TypeDatatype}},
+		"cn":     {ID: "cn", ValueString: string(Name), Types: []ID{TypeContent}, Contents: []ID{"min", "max"}},
+		"min":    {ID: "min", ValueString: "1", Types: []ID{TypeMin}},
+		"max":    {ID: "max", ValueString: "2", Types: []ID{TypeMax}},
+		"closed": {ID: "closed", Types: []ID{TypeClosed}},
+	}
+	person, err := LoadOne(m, "person")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc, err := LoadTypeConstraints(person)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := &TypeConstraints{
+		Type:      "person",
+		Datatypes: []ID{TextPlain},
+		Content:   []ContentConstraint{{Type: Name, Min: 1, Max: 2}},
+		Closed:    true,
+	}
+	if !reflect.DeepEqual(tc, expect) {
+		t.Errorf("got %#v, expected %#v", tc, expect)
+	}
+	m["max"] = TruncatedNote{ID: "max", ValueString: "many", Types: []ID{TypeMax}}
+	if _, err := LoadTypeConstraints(person); !errors.Is(err, InvalidValue) {
+		t.Errorf("got %v, expected %v", err, InvalidValue)
+	}
+}
+
+func TestValidator(t *testing.T) {
+	m := typedNotes
+	for _, test := range []struct {
+		ID     ID
+		Expect []Violation
+	}{
+		{"alice", nil},
+		{"person", nil},
+		{"bob", []Violation{
+			{"bob", "person", `value has datatype "org.note-maps.text.iso8601", expected one of [org.note-maps.text.plain]`},
+			{"bob", "person", `has 0 content of type "org.note-maps.name", expected at least 1`},
+			{"bob", "person", `content "bother" has no allowed type`},
+		}},
+		{"carol", []Violation{
+			{"carol", "person", `name "two\nlines" is not a valid name`},
+		}},
+	} {
+		n, err := LoadOne(m, test.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v Validator
+		vs, err := v.Validate(n)
+		if err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(vs, test.Expect) {
+			t.Errorf("%v: got %#v, expected %#v", test.ID, vs, test.Expect)
+		}
+	}
+	var v Validator
+	if vs, err := v.ValidateAll(m); err != nil {
+		t.Error(err)
+	} else if len(vs) != 4 {
+		t.Errorf("got %v violations, expected 4: %v", len(vs), vs)
+	}
+}
+
+func TestValidatePatch(t *testing.T) {
+	m := typedNotes
+	if err := ValidatePatch(m, OperationSlice{}.SetValueString("alice", "Alicia")); err != nil {
+		t.Error(err)
+	}
+	err := ValidatePatch(m, OperationSlice{}.
+		SetValueString("alice", "Alicia").
+		PatchContent("alice", IDSlice{"aname"}.Append("bother")))
+	var verr ValidationError
+	if !errors.Is(err, ValidationFailed) || !errors.As(err, &verr) {
+		t.Fatalf("got %v, expected a ValidationError", err)
+	}
+	if len(verr) != 1 || verr[0].ID != "alice" {
+		t.Errorf("got %v, expected one violation by alice", verr)
+	}
+	// Changing only the content of alice still violates its constraints.
+	for _, ops := range []OperationSlice{
+		OperationSlice{}.SetValueString("aname", "two\nlines"),
+		OperationSlice{}.PatchTypes("aname", IDSlice{Name}.Delete(0, 1)),
+	} {
+		err := ValidatePatch(m, ops)
+		if !errors.As(err, &verr) {
+			t.Errorf("%v: got %v, expected a ValidationError", ops, err)
+		} else {
+			for _, v := range verr {
+				if v.ID != "alice" {
+					t.Errorf("%v: got %v, expected only violations by alice", ops, verr)
+				}
+			}
+		}
+	}
+}
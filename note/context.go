@@ -0,0 +1,191 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import "context"
+
+// ContextFinder is a Finder that can also find notes under the control of a
+// context, so that a long query can be cancelled or given a deadline.
+type ContextFinder interface {
+	Finder
+	FindContext(ctx context.Context, q *Query) ([]GraphNote, error)
+}
+
+// ContextLoader is a Loader that can also load notes under the control of a
+// context.
+type ContextLoader interface {
+	Loader
+	LoadContext(ctx context.Context, ids []ID) ([]GraphNote, error)
+}
+
+// ContextDatabaseReader is a DatabaseReader that can also read under the
+// control of a context.
+type ContextDatabaseReader interface {
+	DatabaseReader
+
+	// IsolatedReadContext is like IsolatedRead, except that the FindLoader
+	// passed to f returns the error of ctx once ctx is done.
+	IsolatedReadContext(ctx context.Context, f func(r FindLoader) error) error
+}
+
+// ContextDatabaseWriter is a DatabaseWriter that can also write under the
+// control of a context.
+type ContextDatabaseWriter interface {
+	DatabaseWriter
+
+	// IsolatedWriteContext is like IsolatedWrite, except that the
+	// FindLoadPatcher passed to f returns the error of ctx once ctx is done,
+	// and no changes are saved if ctx is done before they are committed.
+	IsolatedWriteContext(ctx context.Context, f func(rw FindLoadPatcher) error) error
+}
+
+// ContextDatabase is a Database that can also read and write under the
+// control of a context.
+type ContextDatabase interface {
+	Database
+	ContextDatabaseReader
+	ContextDatabaseWriter
+}
+
+// FindContext finds notes in f that match q, stopping early if ctx is done
+// and f supports it.
+func FindContext(ctx context.Context, f Finder, q *Query) ([]GraphNote, error) {
+	if cf, ok := f.(ContextFinder); ok {
+		return cf.FindContext(ctx, q)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.Find(q)
+}
+
+// LoadContext loads notes from l, stopping early if ctx is done and l supports
+// it.
+func LoadContext(ctx context.Context, l Loader, ids []ID) ([]GraphNote, error) {
+	if cl, ok := l.(ContextLoader); ok {
+		return cl.LoadContext(ctx, ids)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return l.Load(ids)
+}
+
+// IsolatedReadContext invokes f through db.IsolatedReadContext if db is a
+// ContextDatabaseReader.
+//
+// Otherwise, f is invoked through db.IsolatedRead with a FindLoader that
+// checks ctx before each call.
+func IsolatedReadContext(ctx context.Context, db DatabaseReader, f func(r FindLoader) error) error {
+	if cdb, ok := db.(ContextDatabaseReader); ok {
+		return cdb.IsolatedReadContext(ctx, f)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.IsolatedRead(func(r FindLoader) error {
+		return f(withContext(ctx, r))
+	})
+}
+
+// IsolatedWriteContext invokes f through db.IsolatedWriteContext if db is a
+// ContextDatabaseWriter.
+//
+// Otherwise, f is invoked through db.IsolatedWrite with a FindLoadPatcher that
+// checks ctx before each call, and the write fails if ctx is done by the time
+// f returns.
+func IsolatedWriteContext(ctx context.Context, db DatabaseWriter, f func(rw FindLoadPatcher) error) error {
+	if cdb, ok := db.(ContextDatabaseWriter); ok {
+		return cdb.IsolatedWriteContext(ctx, f)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.IsolatedWrite(func(rw FindLoadPatcher) error {
+		if err := f(withContextPatcher(ctx, rw)); err != nil {
+			return err
+		}
+		return ctx.Err()
+	})
+}
+
+// withContext returns a FindLoader that checks ctx before each call to r,
+// and that is also a ParentLoader if r is one.
+func withContext(ctx context.Context, r FindLoader) FindLoader {
+	cr := contextFindLoader{ctx, r}
+	if pl, ok := r.(ParentLoader); ok {
+		return struct {
+			contextFindLoader
+			contextParentLoader
+		}{cr, contextParentLoader{ctx, pl}}
+	}
+	return cr
+}
+
+// withContextPatcher is like withContext, for a FindLoadPatcher.
+func withContextPatcher(ctx context.Context, rw FindLoadPatcher) FindLoadPatcher {
+	crw := contextFindLoadPatcher{contextFindLoader{ctx, rw}, rw}
+	if pl, ok := rw.(ParentLoader); ok {
+		return struct {
+			contextFindLoadPatcher
+			contextParentLoader
+		}{crw, contextParentLoader{ctx, pl}}
+	}
+	return crw
+}
+
+type contextFindLoader struct {
+	ctx context.Context
+	FindLoader
+}
+
+func (r contextFindLoader) Find(q *Query) ([]GraphNote, error) {
+	return FindContext(r.ctx, r.FindLoader, q)
+}
+
+func (r contextFindLoader) FindBySubjectIdentifier(si string) ([]GraphNote, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.FindLoader.FindBySubjectIdentifier(si)
+}
+
+func (r contextFindLoader) Load(ids []ID) ([]GraphNote, error) {
+	return LoadContext(r.ctx, r.FindLoader, ids)
+}
+
+type contextFindLoadPatcher struct {
+	contextFindLoader
+	p Patcher
+}
+
+func (rw contextFindLoadPatcher) Patch(ops []Operation) error {
+	if err := rw.ctx.Err(); err != nil {
+		return err
+	}
+	return rw.p.Patch(ops)
+}
+
+type contextParentLoader struct {
+	ctx context.Context
+	pl  ParentLoader
+}
+
+func (l contextParentLoader) LoadParentIDs(ids []ID) ([][]ID, error) {
+	if err := l.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return l.pl.LoadParentIDs(ids)
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func (m mapFindLoader) IsolatedRead(f func(r FindLoader) error) error {
+	return f(m)
+}
+
+func TestIsolatedReadContext(t *testing.T) {
+	m := testNoteMap()
+	ctx, cancel := context.WithCancel(context.Background())
+	err := IsolatedReadContext(ctx, m, func(r FindLoader) error {
+		if _, err := r.Load([]ID{"n0"}); err != nil {
+			return err
+		}
+		cancel()
+		if _, err := r.Find(&Query{}); !errors.Is(err, context.Canceled) {
+			t.Errorf("find: expected %v, got %v", context.Canceled, err)
+		}
+		_, err := r.Load([]ID{"n0"})
+		return err
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	if err = IsolatedReadContext(ctx, m, func(r FindLoader) error {
+		t.Error("expected f not to be called with a cancelled context")
+		return nil
+	}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestIsolatedReadContext_parents(t *testing.T) {
+	m := mapFindLoader{
+		"p": {ID: "p", Contents: []ID{"c"}},
+		"c": {ID: "c", ValueString: "child"},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	err := IsolatedReadContext(ctx, m, func(r FindLoader) error {
+		pl, ok := r.(ParentLoader)
+		if !ok {
+			t.Fatal("expected the FindLoader to be a ParentLoader")
+		}
+		parents, err := pl.LoadParentIDs([]ID{"c"})
+		if err != nil {
+			return err
+		}
+		if len(parents) != 1 || len(parents[0]) != 1 || parents[0][0] != "p" {
+			t.Errorf("got parents %v, expected [[p]]", parents)
+		}
+		cancel()
+		_, err = pl.LoadParentIDs([]ID{"c"})
+		return err
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	if err := IsolatedWriteContext(context.Background(), m, func(rw FindLoadPatcher) error {
+		if _, ok := rw.(ParentLoader); !ok {
+			t.Error("expected the FindLoadPatcher to be a ParentLoader")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsolatedWriteContext(t *testing.T) {
+	m := testNoteMap()
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := IsolatedWriteContext(ctx, m, func(rw FindLoadPatcher) error {
+		return rw.Patch(OperationSlice{}.SetValueString("n0", "goodbye"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	err := IsolatedWriteContext(ctx, m, func(rw FindLoadPatcher) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	if err := (contextFindLoadPatcher{contextFindLoader{ctx, m}, m}).
+		Patch(OperationSlice{}.SetValueString("n0", "again")); !errors.Is(err, context.Canceled) {
+		t.Errorf("patch: expected %v, got %v", context.Canceled, err)
+	}
+	if m["n0"].ValueString != "goodbye" {
+		t.Errorf("got %#v, expected %#v", m["n0"].ValueString, "goodbye")
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// IDs of the built-in datatypes.
+const (
+	// TextPlain is the datatype of values that are plain UTF-8 text.
+	TextPlain ID = "org.note-maps.text.plain"
+
+	// TextISO8601 is the datatype of values that are ISO 8601 dates or
+	// timestamps, like "2006-01-02" or "2006-01-02T15:04:05Z".
+	TextISO8601 ID = "org.note-maps.text.iso8601"
+)
+
+// Datatype validates and normalizes the lexical representation of values of
+// a particular type.
+type Datatype interface {
+	// Normalize returns the canonical lexical representation of lexical, or
+	// an error if lexical does not represent a valid value of this type.
+	Normalize(lexical string) (string, error)
+}
+
+// DatatypeFunc is an adapter that allows an ordinary function to be used as a
+// Datatype.
+type DatatypeFunc func(lexical string) (string, error)
+
+// Normalize returns f(lexical).
+func (f DatatypeFunc) Normalize(lexical string) (string, error) { return f(lexical) }
+
+// DatatypeRegistry maps the IDs of datatypes to their implementations.
+//
+// A nil DatatypeRegistry enforces nothing: every value is accepted unchanged.
+type DatatypeRegistry map[ID]Datatype
+
+// NewDatatypeRegistry returns a new DatatypeRegistry that includes all the
+// built-in datatypes.
+func NewDatatypeRegistry() DatatypeRegistry {
+	return DatatypeRegistry{
+		TextPlain:   DatatypeFunc(normalizeTextPlain),
+		TextISO8601: DatatypeFunc(normalizeTextISO8601),
+	}
+}
+
+// Register adds dt to r as the implementation of datatype id, replacing any
+// implementation that was already registered for it.
+func (r DatatypeRegistry) Register(id ID, dt Datatype) { r[id] = dt }
+
+// Supports returns true if and only if values of datatype id can be validated
+// by r.
+//
+// The empty datatype is always supported, as is every datatype when r is nil.
+func (r DatatypeRegistry) Supports(id ID) bool {
+	if r == nil || id.Empty() {
+		return true
+	}
+	_, ok := r[id]
+	return ok
+}
+
+// Normalize returns the canonical lexical representation of a value of
+// datatype id.
+//
+// Values of the empty datatype are returned unchanged, as are all values when
+// r is nil. The returned error wraps UnsupportedDatatype if id is not
+// registered in r, or InvalidValue if lexical is not valid for id.
+func (r DatatypeRegistry) Normalize(id ID, lexical string) (string, error) {
+	if r == nil || id.Empty() {
+		return lexical, nil
+	}
+	dt, ok := r[id]
+	if !ok {
+		return lexical, fmt.Errorf("%#v: %w", string(id), UnsupportedDatatype)
+	}
+	normal, err := dt.Normalize(lexical)
+	if err != nil {
+		return lexical, fmt.Errorf("%#v is not a valid %v: %w: %v",
+			lexical, id, InvalidValue, err)
+	}
+	return normal, nil
+}
+
+// NormalizeOps returns a copy of ops in which every value that is set is
+// normalized according to its datatype.
+//
+// The datatype of values changed by OpSetValueString or OpValueDelta is found
+// by applying preceding operations to base, and such an operation is replaced
+// by an OpSetValueString if normalization changes the resulting value. An
+// error is returned for the first value that cannot be normalized.
+func (r DatatypeRegistry) NormalizeOps(base Loader, ops []Operation) ([]Operation, error) {
+	if r == nil {
+		return ops, nil
+	}
+	result := make([]Operation, len(ops))
+	for i, op := range ops {
+		switch o := op.(type) {
+		case OpSetValue:
+			lex, err := r.Normalize(o.Datatype, o.Lexical)
+			if err != nil {
+				return nil, err
+			}
+			o.Lexical = lex
+			op = o
+		case OpSetValueString, OpValueDelta:
+			id := o.(interface{ GetID() ID }).GetID()
+			stage := Stage{Ops: append(result[:i:i], op), Base: base}
+			lex, vt, err := stage.Note(id).GetValue()
+			if err != nil {
+				return nil, err
+			}
+			normal, err := r.Normalize(idOf(vt), lex)
+			if err != nil {
+				return nil, err
+			}
+			if normal != lex {
+				op = OpSetValueString{Op(id), normal}
+			}
+		}
+		result[i] = op
+	}
+	return result, nil
+}
+
+func normalizeTextPlain(lexical string) (string, error) {
+	if !utf8.ValidString(lexical) {
+		return lexical, errors.New("not valid UTF-8")
+	}
+	return lexical, nil
+}
+
+// iso8601Layouts are the supported ISO 8601 layouts, most specific first.
+var iso8601Layouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"2006-01",
+}
+
+func normalizeTextISO8601(lexical string) (string, error) {
+	trimmed := strings.TrimSpace(lexical)
+	for _, layout := range iso8601Layouts {
+		if t, err := time.Parse(layout, trimmed); err == nil {
+			return t.Format(layout), nil
+		}
+	}
+	return lexical, errors.New("not a supported ISO 8601 date or time")
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDatatypeRegistry_Normalize(t *testing.T) {
+	r := NewDatatypeRegistry()
+	r.Register("upper", DatatypeFunc(func(lex string) (string, error) {
+		return strings.ToUpper(lex), nil
+	}))
+	for _, test := range []struct {
+		Datatype ID
+		In       string
+		Expect   string
+		Err      error
+	}{
+		{EmptyID, "anything", "anything", nil},
+		{TextPlain, "hello", "hello", nil},
+		{TextPlain, "\xff", "", InvalidValue},
+		{TextISO8601, "2020-06-01", "2020-06-01", nil},
+		{TextISO8601, " 2020-06 ", "2020-06", nil},
+		{TextISO8601, "2020-06-01T12:30:00+00:00", "2020-06-01T12:30:00Z", nil},
+		{TextISO8601, "2020-06-01T12:30:00", "2020-06-01T12:30:00", nil},
+		{TextISO8601, "June 1st", "", InvalidValue},
+		{"upper", "hello", "HELLO", nil},
+		{"unknown", "hello", "", UnsupportedDatatype},
+	} {
+		got, err := r.Normalize(test.Datatype, test.In)
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("%v %#v: got error %v, expected %v",
+					test.Datatype, test.In, err, test.Err)
+			}
+		} else if err != nil {
+			t.Errorf("%v %#v: %v", test.Datatype, test.In, err)
+		} else if got != test.Expect {
+			t.Errorf("%v %#v: got %#v, expected %#v",
+				test.Datatype, test.In, got, test.Expect)
+		}
+	}
+}
+
+func TestDatatypeRegistry_nil(t *testing.T) {
+	var r DatatypeRegistry
+	if !r.Supports("unknown") {
+		t.Error("expected a nil registry to support every datatype")
+	}
+	if got, err := r.Normalize("unknown", "\xff"); err != nil || got != "\xff" {
+		t.Errorf("got %#v, %v, expected the value to be unchanged", got, err)
+	}
+}
+
+func TestDatatypeRegistry_NormalizeOps(t *testing.T) {
+	r := NewDatatypeRegistry()
+	var ops OperationSlice
+	ops = ops.SetValue("id0", " 2020-06-01 ", TextISO8601)
+	ops = ops.SetValueString("id0", "2020-06-02 ")
+	got, err := r.NormalizeOps(nil, ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []Operation{
+		OpSetValue{"id0", "2020-06-01", TextISO8601},
+		OpSetValueString{"id0", "2020-06-02"},
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("got %v, expected %v", got, expect)
+	}
+	ops = ops.SetValueString("id0", "tomorrow")
+	if _, err := r.NormalizeOps(nil, ops); !errors.Is(err, InvalidValue) {
+		t.Errorf("got %v, expected %v", err, InvalidValue)
+	}
+}
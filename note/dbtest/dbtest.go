@@ -72,6 +72,12 @@ func (n BrokenNote) GetContents() ([]note.GraphNote, error) { return nil, n.Err
 // GetTypes always returns n.Err.
 func (n BrokenNote) GetTypes() ([]note.GraphNote, error) { return nil, n.Err }
 
+// GetRoles always returns n.Err.
+func (n BrokenNote) GetRoles() ([]note.Role, error) { return nil, n.Err }
+
+// GetSubjectIdentifiers always returns n.Err.
+func (n BrokenNote) GetSubjectIdentifiers() ([]string, error) { return nil, n.Err }
+
 // BrokenNoteLoader loads instances of BrokenNote.
 type BrokenNoteLoader struct{ Err error }
 
@@ -26,10 +26,12 @@ func (id ID) Empty() bool { return id == EmptyID }
 // EmptyNote is simply an empty GraphNote with nothing more than an ID.
 type EmptyNote ID
 
-func (x EmptyNote) GetID() ID                            { return ID(x) }
-func (x EmptyNote) GetValue() (string, GraphNote, error) { return "", EmptyNote(EmptyID), nil }
-func (x EmptyNote) GetContents() ([]GraphNote, error)    { return nil, nil }
-func (x EmptyNote) GetTypes() ([]GraphNote, error)       { return nil, nil }
+func (x EmptyNote) GetID() ID                                { return ID(x) }
+func (x EmptyNote) GetValue() (string, GraphNote, error)     { return "", EmptyNote(EmptyID), nil }
+func (x EmptyNote) GetContents() ([]GraphNote, error)        { return nil, nil }
+func (x EmptyNote) GetTypes() ([]GraphNote, error)           { return nil, nil }
+func (x EmptyNote) GetRoles() ([]Role, error)                { return nil, nil }
+func (x EmptyNote) GetSubjectIdentifiers() ([]string, error) { return nil, nil }
 
 const (
 	// EmptyLoader implements the Loader interface for a note map that is
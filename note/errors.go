@@ -14,8 +14,61 @@
 
 package note
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
+// Errors returned by this package and by implementations of its interfaces
+// can be identified with errors.Is, whatever else they may be wrapped in.
 var (
+	// InvalidID indicates a note ID that cannot be used, typically because it
+	// is empty, or an ID that is not in a list where it was expected.
 	InvalidID = errors.New("invalid note id")
+
+	// ConflictingDelta indicates a delta that cannot be applied to the note it
+	// is meant to change, typically because it was made for a different
+	// version of the note.
+	ConflictingDelta = errors.New("conflicting delta")
+
+	// UnsupportedOperation indicates an Operation that cannot be applied,
+	// inverted, transformed or encoded by the code that was given it.
+	UnsupportedOperation = errors.New("unsupported operation")
+
+	// ClosedDatabase indicates an attempt to use a Database after it has been
+	// closed.
+	ClosedDatabase = errors.New("database is closed")
+
+	// ValidationFailed indicates a change that would leave a note map in a
+	// state that is not allowed. More specific errors, like InvalidValue,
+	// are also ValidationFailed errors.
+	ValidationFailed = errors.New("validation failed")
+
+	// UnsupportedDatatype indicates a value with a datatype that is not known
+	// to a DatatypeRegistry.
+	UnsupportedDatatype error = &validationError{"unsupported datatype"}
+
+	// InvalidValue indicates a lexical value that is not valid for its
+	// datatype.
+	InvalidValue error = &validationError{"invalid value"}
 )
+
+// validationError is a kind of ValidationFailed error.
+type validationError struct{ s string }
+
+func (e *validationError) Error() string        { return e.s }
+func (e *validationError) Is(target error) bool { return target == ValidationFailed }
+
+// NoteError records an error and the ID of the note that caused it.
+//
+// Callers can use errors.As to find out which note caused an error.
+type NoteError struct {
+	ID  ID
+	Err error
+}
+
+func (e *NoteError) Error() string { return fmt.Sprintf("note %#v: %v", string(e.ID), e.Err) }
+func (e *NoteError) Unwrap() error { return e.Err }
+
+// noteError returns err wrapped in a *NoteError about id.
+func noteError(id ID, err error) error { return &NoteError{id, err} }
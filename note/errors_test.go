@@ -0,0 +1,111 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/note-maps/otgen/runes"
+)
+
+// noIDOp is an Operation that does not say which note it affects.
+type noIDOp struct{}
+
+func (noIDOp) AffectsID(id ID) bool { return true }
+
+func TestErrors(t *testing.T) {
+	m := testNoteMap()
+	conflict := OperationSlice{}.PatchContent("n0", IDSlice{}.Delete(3, 1))
+	_, err := Invert(m, OperationSlice{unknownOp{"n0"}})
+	_, _, terr := Transform(OperationSlice{noIDOp{}}, OperationSlice{}.ClearNote("n0"))
+	for _, test := range []struct {
+		Title  string
+		Err    error
+		Is     []error
+		NoteID ID
+	}{
+		{
+			Title:  "patch conflicting content",
+			Err:    Patch(&TruncatedNote{ID: "n0"}, conflict),
+			Is:     []error{ConflictingDelta},
+			NoteID: "n0",
+		},
+		{
+			Title: "patch conflicting value",
+			Err: Patch(&TruncatedNote{ID: "n1"},
+				OperationSlice{}.PatchValue("n1", runes.StringDelta{}.Delete(5))),
+			Is:     []error{ConflictingDelta},
+			NoteID: "n1",
+		},
+		{
+			Title: "stage conflicting content",
+			Err: func() error {
+				_, err := (&Stage{Ops: conflict, Base: m}).Note("n0").GetContents()
+				return err
+			}(),
+			Is:     []error{ConflictingDelta},
+			NoteID: "n0",
+		},
+		{
+			Title: "stage empty id",
+			Err: func() error {
+				_, err := (&Stage{Base: m}).Load([]ID{EmptyID})
+				return err
+			}(),
+			Is: []error{InvalidID},
+		},
+		{
+			Title: "invalid value",
+			Err: func() error {
+				_, err := NewDatatypeRegistry().Normalize(TextISO8601, "yesterday")
+				return err
+			}(),
+			Is: []error{InvalidValue, ValidationFailed},
+		},
+		{
+			Title: "unsupported datatype",
+			Err: func() error {
+				_, err := NewDatatypeRegistry().Normalize("vt0", "x")
+				return err
+			}(),
+			Is: []error{UnsupportedDatatype, ValidationFailed},
+		},
+		{"invert unknown op", err, []error{UnsupportedOperation}, EmptyID},
+		{"transform unknown op", terr, []error{UnsupportedOperation}, EmptyID},
+	} {
+		t.Run(test.Title, func(t *testing.T) {
+			if test.Err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, target := range test.Is {
+				if !errors.Is(test.Err, target) {
+					t.Errorf("expected %#v to be %#v", test.Err.Error(), target.Error())
+				}
+			}
+			var ne *NoteError
+			if !errors.As(test.Err, &ne) {
+				if !test.NoteID.Empty() {
+					t.Errorf("expected a *NoteError about %v", test.NoteID)
+				}
+			} else if ne.ID != test.NoteID {
+				t.Errorf("got error about %#v, expected %#v", ne.ID, test.NoteID)
+			}
+		})
+	}
+	if errors.Is(ConflictingDelta, ValidationFailed) {
+		t.Error("expected ConflictingDelta not to be a validation failure")
+	}
+}
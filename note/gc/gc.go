@@ -0,0 +1,110 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gc finds and deletes orphaned notes.
+//
+// Removing a note from the content of its parent does not delete it, so a
+// note map accumulates notes that can no longer be reached. A note is an
+// orphan if it is not empty and cannot be reached from any of a set of root
+// notes by following references to content, types, value types, and the
+// types and players of roles.
+package gc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/note-maps/note"
+	"github.com/google/note-maps/note/traverse"
+)
+
+// Edges are the references followed to decide which notes are reachable.
+const Edges = traverse.Content | traverse.Type | traverse.ValueType | traverse.Role
+
+// NoRoots indicates an attempt to find orphans without any root notes, which
+// would make every note an orphan.
+var NoRoots = errors.New("no root notes")
+
+// Reachable returns the IDs of the notes that can be reached from roots,
+// including the roots themselves.
+func Reachable(l note.Loader, roots []note.ID) (map[note.ID]bool, error) {
+	reached := make(map[note.ID]bool)
+	w := traverse.Walker{Loader: l, Edges: Edges}
+	err := w.BFS(roots, func(n note.GraphNote, depth int) error {
+		reached[n.GetID()] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reached, nil
+}
+
+// Orphans returns the IDs of the notes in r that cannot be reached from
+// roots, in the order they are found.
+func Orphans(r note.FindLoader, roots []note.ID) ([]note.ID, error) {
+	if len(roots) == 0 {
+		return nil, NoRoots
+	}
+	reached, err := Reachable(r, roots)
+	if err != nil {
+		return nil, err
+	}
+	ns, err := r.Find(&note.Query{})
+	if err != nil {
+		return nil, err
+	}
+	var orphans []note.ID
+	for _, n := range ns {
+		if !reached[n.GetID()] {
+			orphans = append(orphans, n.GetID())
+		}
+	}
+	return orphans, nil
+}
+
+// Report returns the IDs of the orphans in db without changing anything.
+func Report(ctx context.Context, db note.DatabaseReader, roots []note.ID) ([]note.ID, error) {
+	var orphans []note.ID
+	err := note.IsolatedReadContext(ctx, db, func(r note.FindLoader) error {
+		var err error
+		orphans, err = Orphans(r, roots)
+		return err
+	})
+	return orphans, err
+}
+
+// Collect clears every orphan in db within a single isolated write, and
+// returns their IDs.
+func Collect(ctx context.Context, db note.DatabaseWriter, roots []note.ID) ([]note.ID, error) {
+	var orphans []note.ID
+	err := note.IsolatedWriteContext(ctx, db, func(rw note.FindLoadPatcher) error {
+		var err error
+		if orphans, err = Orphans(rw, roots); err != nil {
+			return err
+		}
+		var ops note.OperationSlice
+		for _, id := range orphans {
+			ops = ops.ClearNote(id)
+		}
+		if len(ops) == 0 {
+			return nil
+		}
+		return rw.Patch(ops)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orphans, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/note-maps/note"
+	"github.com/google/note-maps/note/notetest"
+)
+
+func TestOrphans(t *testing.T) {
+	db := notetest.MapDB{
+		"root":   {ID: "root", Contents: []note.ID{"child"}},
+		"child":  {ID: "child", ValueString: "2020", ValueType: "date", Types: []note.ID{"t"}},
+		"date":   {ID: "date", ValueString: "date type"},
+		"t":      {ID: "t", ValueString: "type"},
+		"link":   {ID: "link", Roles: []note.Role{{Type: "rt", Player: "child"}}},
+		"rt":     {ID: "rt", ValueString: "role type"},
+		"orphan": {ID: "orphan", Contents: []note.ID{"inner", "link"}},
+		"inner":  {ID: "inner", ValueString: "unreachable"},
+	}
+	got, err := Orphans(db, []note.ID{"root"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []note.ID{"inner", "link", "orphan", "rt"}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("got %v, expected %v", got, expect)
+	}
+	got, err = Orphans(db, []note.ID{"root", "link"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect = []note.ID{"inner", "orphan"}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("got %v, expected %v", got, expect)
+	}
+	if _, err := Orphans(db, nil); !errors.Is(err, NoRoots) {
+		t.Errorf("got %v, expected %v", err, NoRoots)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	ctx := context.Background()
+	db := notetest.MapDB{
+		"root":   {ID: "root", Contents: []note.ID{"child"}},
+		"child":  {ID: "child", ValueString: "2020", ValueType: "date", Types: []note.ID{"t"}},
+		"date":   {ID: "date", ValueString: "date type"},
+		"t":      {ID: "t", ValueString: "type"},
+		"link":   {ID: "link", Roles: []note.Role{{Type: "rt", Player: "child"}}},
+		"rt":     {ID: "rt", ValueString: "role type"},
+		"orphan": {ID: "orphan", Contents: []note.ID{"inner", "link"}},
+		"inner":  {ID: "inner", ValueString: "unreachable"},
+	}
+	root, orphan := db["root"], db["orphan"]
+	roots := []note.ID{"root", "link"}
+	reported, err := Report(ctx, db, roots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !db["orphan"].Equals(orphan) {
+		t.Error("expected a report not to change anything")
+	}
+	collected, err := Collect(ctx, db, roots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(collected, reported) {
+		t.Errorf("collected %v, expected %v", collected, reported)
+	}
+	for _, id := range collected {
+		if !db[id].Empty() {
+			t.Errorf("expected %v to be cleared, got %#v", id, db[id])
+		}
+	}
+	if !db["root"].Equals(root) {
+		t.Error("expected root to be unchanged")
+	}
+	if again, err := Collect(ctx, db, roots); err != nil {
+		t.Fatal(err)
+	} else if len(again) != 0 {
+		t.Errorf("got %v, expected no more orphans", again)
+	}
+}
@@ -24,11 +24,12 @@ import (
 
 func main() {
 	err := otgen.Generate(otgen.Options{
-		PackageName: "note",
-		ElementType: "ID",
-		SliceType:   "IDSlice",
-		BaseName:    "id_ot",
-		OpStringer:  true,
+		PackageName:   "note",
+		ElementType:   "ID",
+		SliceType:     "IDSlice",
+		BaseName:      "id_ot",
+		OpStringer:    true,
+		ConflictError: "ConflictingDelta",
 	})
 	if err != nil {
 		log.Fatalln(err)
@@ -0,0 +1,76 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/note-maps/note"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Error codes set as the "code" extension of GraphQL errors by
+// ErrorPresenter.
+const (
+	CodeConflictingDelta     = "CONFLICTING_DELTA"
+	CodeInvalidID            = "INVALID_ID"
+	CodeValidationFailed     = "VALIDATION_FAILED"
+	CodeUnsupportedOperation = "UNSUPPORTED_OPERATION"
+	CodeClosedDatabase       = "CLOSED_DATABASE"
+	CodeCanceled             = "CANCELED"
+)
+
+// ErrorCode returns the error code that describes err, or "" if there is
+// none.
+func ErrorCode(err error) string {
+	switch {
+	case errors.Is(err, note.ConflictingDelta):
+		return CodeConflictingDelta
+	case errors.Is(err, note.InvalidID):
+		return CodeInvalidID
+	case errors.Is(err, note.ValidationFailed):
+		return CodeValidationFailed
+	case errors.Is(err, note.UnsupportedOperation):
+		return CodeUnsupportedOperation
+	case errors.Is(err, note.ClosedDatabase):
+		return CodeClosedDatabase
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return CodeCanceled
+	}
+	return ""
+}
+
+// ErrorPresenter is a graphql.ErrorPresenterFunc that adds a "code" extension
+// to errors that come from package note, and a "note" extension with the ID
+// of the note that caused the error, if it is known.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	var gqlerr *gqlerror.Error
+	if !errors.As(err, &gqlerr) {
+		gqlerr = gqlerror.WrapPath(graphql.GetPath(ctx), err)
+	}
+	if code := ErrorCode(err); code != "" {
+		if gqlerr.Extensions == nil {
+			gqlerr.Extensions = make(map[string]interface{})
+		}
+		gqlerr.Extensions["code"] = code
+		var ne *note.NoteError
+		if errors.As(err, &ne) {
+			gqlerr.Extensions["note"] = string(ne.ID)
+		}
+	}
+	return gqlerr
+}
@@ -0,0 +1,49 @@
+// Copyright 2020-2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/note-maps/note"
+)
+
+func TestErrorPresenter(t *testing.T) {
+	for _, test := range []struct {
+		Err  error
+		Code interface{}
+		Note interface{}
+	}{
+		{fmt.Errorf("patching: %w", &note.NoteError{ID: "n0", Err: note.ConflictingDelta}),
+			CodeConflictingDelta, "n0"},
+		{fmt.Errorf("%w: not a date", note.InvalidValue), CodeValidationFailed, nil},
+		{note.ClosedDatabase, CodeClosedDatabase, nil},
+		{context.Canceled, CodeCanceled, nil},
+		{fmt.Errorf("something else"), nil, nil},
+	} {
+		gqlerr := ErrorPresenter(context.Background(), test.Err)
+		if gqlerr.Message != test.Err.Error() {
+			t.Errorf("got message %#v, expected %#v", gqlerr.Message, test.Err.Error())
+		}
+		if code := gqlerr.Extensions["code"]; code != test.Code {
+			t.Errorf("%v: got code %#v, expected %#v", test.Err, code, test.Code)
+		}
+		if id := gqlerr.Extensions["note"]; id != test.Note {
+			t.Errorf("%v: got note %#v, expected %#v", test.Err, id, test.Note)
+		}
+	}
+}
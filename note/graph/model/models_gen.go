@@ -2,6 +2,11 @@
 
 package model
 
+type Note struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
 type Status struct {
 	ID      string `json:"id"`
 	Summary string `json:"summary"`
@@ -14,6 +14,11 @@
 
 package graph
 
+import "github.com/google/note-maps/note"
+
 // Dependency injection. Add any required dependencies here.
 
-type Resolver struct{}
+type Resolver struct {
+	// DB is the note map that queries read from.
+	DB note.DatabaseReader
+}
@@ -5,7 +5,9 @@ package graph
 
 import (
 	"context"
+	"errors"
 
+	"github.com/google/note-maps/note"
 	"github.com/google/note-maps/note/graph/generated"
 	"github.com/google/note-maps/note/graph/model"
 )
@@ -17,6 +19,26 @@ func (r *queryResolver) Status(ctx context.Context) (*model.Status, error) {
 	}, nil
 }
 
+func (r *queryResolver) Note(ctx context.Context, id string, locales []string) (*model.Note, error) {
+	if r.DB == nil {
+		return nil, errors.New("no note map is open")
+	}
+	var result model.Note
+	err := note.IsolatedReadContext(ctx, r.DB, func(fl note.FindLoader) error {
+		ns, err := fl.Load([]note.ID{note.ID(id)})
+		if err != nil {
+			return err
+		}
+		result.ID = id
+		result.DisplayName, _, err = note.DisplayName(ns[0], locales...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
@@ -0,0 +1,156 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/note-maps/note/wire"
+)
+
+// FileStore is a Store that keeps entries in a file, so that they outlive the
+// process that recorded them.
+//
+// Each entry is written as a single line of JSON, with its operations encoded
+// by wire.MarshalJSON, and the file is synced after each entry is appended.
+// Entries are also kept in memory once the file has been read.
+type FileStore struct {
+	mu      sync.Mutex
+	f       *os.File
+	entries []Entry
+}
+
+// fileEntry is the JSON representation of an Entry in a FileStore.
+type fileEntry struct {
+	Revision Revision        `json:"revision"`
+	Time     time.Time       `json:"time"`
+	Author   string          `json:"author,omitempty"`
+	Ops      json.RawMessage `json:"ops"`
+	Inverse  json.RawMessage `json:"inverse"`
+}
+
+// OpenFileStore opens the FileStore at path, creating the file if it does not
+// exist.
+//
+// An incomplete entry at the end of the file, as left by a crash during
+// Append, is discarded.
+func OpenFileStore(path string) (*FileStore, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	s := &FileStore{f: f}
+	if err := s.read(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("reading history from %v: %w", path, err)
+	}
+	return s, nil
+}
+
+// read loads every complete entry from s.f, truncates anything that follows
+// them, and leaves s.f positioned for appending.
+func (s *FileStore) read() error {
+	r := bufio.NewReader(s.f)
+	var end int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		var fe fileEntry
+		if err := json.Unmarshal(line, &fe); err != nil {
+			return err
+		}
+		e := Entry{Revision: fe.Revision, Time: fe.Time, Author: fe.Author}
+		if e.Ops, err = wire.UnmarshalJSON(fe.Ops); err != nil {
+			return err
+		}
+		if e.Inverse, err = wire.UnmarshalJSON(fe.Inverse); err != nil {
+			return err
+		}
+		if want := Revision(len(s.entries)) + 1; e.Revision != want {
+			return fmt.Errorf("got revision %v, expected %v", e.Revision, want)
+		}
+		s.entries = append(s.entries, e)
+		end += int64(len(line))
+	}
+	if err := s.f.Truncate(end); err != nil {
+		return err
+	}
+	_, err := s.f.Seek(end, io.SeekStart)
+	return err
+}
+
+// Append adds e to the end of s and syncs the file.
+//
+// If the entry cannot be written in full, the file is truncated back to its
+// previous length.
+func (s *FileStore) Append(e Entry) error {
+	fe := fileEntry{Revision: e.Revision, Time: e.Time, Author: e.Author}
+	var err error
+	if fe.Ops, err = wire.MarshalJSON(e.Ops); err != nil {
+		return err
+	}
+	if fe.Inverse, err = wire.MarshalJSON(e.Inverse); err != nil {
+		return err
+	}
+	line, err := json.Marshal(fe)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	end, err := s.f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := s.f.Write(line); err != nil {
+		if terr := s.f.Truncate(end); terr != nil {
+			return fmt.Errorf("%w, and then truncating failed: %v", err, terr)
+		}
+		if _, serr := s.f.Seek(end, io.SeekStart); serr != nil {
+			return fmt.Errorf("%w, and then seeking failed: %v", err, serr)
+		}
+		return err
+	}
+	if err := s.f.Sync(); err != nil {
+		return err
+	}
+	s.entries = append(s.entries, e)
+	return nil
+}
+
+// Entries returns every entry in s.
+func (s *FileStore) Entries() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Entry(nil), s.entries...), nil
+}
+
+// Close closes the file.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
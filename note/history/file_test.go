@@ -0,0 +1,77 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/note-maps/note"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	s, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := newTestDatabase()
+	db.Store = s
+	write(t, db, note.OperationSlice{}.SetValueString("n0", "first"))
+	write(t, db, note.OperationSlice{}.
+		SetValueString("n0", "second").
+		InsertContent("n0", 0, "n1"))
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"revision":3,"ti`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	s, err = OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	db = &Database{DB: db.DB, Store: s}
+	if head, err := db.Head(); err != nil {
+		t.Fatal(err)
+	} else if head != 2 {
+		t.Fatalf("got head %v, expected 2", head)
+	}
+	es, err := s.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if es[0].Author != "tester" || es[1].Time.Before(es[0].Time) {
+		t.Errorf("got entries %#v", es)
+	}
+	expect := note.TruncatedNote{ID: "n0", ValueString: "first"}
+	if got := truncateAt(t, db, 1, "n0"); !got.Equals(expect) {
+		t.Errorf("got %#v, expected %#v", got, expect)
+	}
+	write(t, db, note.OperationSlice{}.SetValueString("n0", "third"))
+	if head, err := db.Head(); err != nil {
+		t.Fatal(err)
+	} else if head != 3 {
+		t.Errorf("got head %v, expected 3", head)
+	}
+}
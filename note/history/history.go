@@ -0,0 +1,395 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package history records each batch of changes written to a note map so that
+// earlier versions of the note map can be read and compared.
+//
+// History is opt-in: wrap a note.Database in a Database, and write through the
+// wrapper. Changes that reach the underlying database by other means, such as
+// replication from another peer, are not recorded, and reading an earlier
+// revision may fail with note.ConflictingDelta if they overlap with recorded
+// changes.
+package history
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/note-maps/note"
+)
+
+// Revision identifies a version of a note map by the number of recorded
+// batches that had been committed to it.
+//
+// Revision zero is the state of the note map before the first recorded batch.
+type Revision uint64
+
+// Entry is a recorded batch of changes.
+type Entry struct {
+	// Revision is the revision created by committing this batch.
+	Revision Revision
+
+	// Time is when the batch was committed.
+	Time time.Time
+
+	// Author identifies whoever committed the batch.
+	Author string
+
+	// Ops are the operations committed in the batch.
+	Ops []note.Operation
+
+	// Inverse are operations that undo Ops.
+	Inverse []note.Operation
+}
+
+// Store keeps the entries recorded by a Database.
+type Store interface {
+	// Append adds e to the end of the store. The revision of e is always one
+	// more than that of the previous entry.
+	Append(e Entry) error
+
+	// Entries returns every entry in the store in order of revision.
+	Entries() ([]Entry, error)
+}
+
+// MemoryStore is a Store that keeps entries in memory.
+//
+// The zero value is an empty store ready to use.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// Append adds e to the end of s.
+func (s *MemoryStore) Append(e Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, e)
+	return nil
+}
+
+// Entries returns every entry in s.
+func (s *MemoryStore) Entries() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Entry(nil), s.entries...), nil
+}
+
+// Database is a note.Database that records each batch of changes written
+// through it.
+//
+// A Database must not be copied after first use.
+type Database struct {
+	// DB is the database that changes are read from and written to.
+	DB note.Database
+
+	// Store keeps the recorded entries. If nil, entries are kept in memory
+	// for the lifetime of the Database. See FileStore for a Store that keeps
+	// entries across restarts.
+	Store Store
+
+	// Author is recorded with each batch.
+	Author string
+
+	// Now, if not nil, is used instead of time.Now to timestamp each batch.
+	Now func() time.Time
+
+	mu   sync.RWMutex
+	once sync.Once
+}
+
+var (
+	_ note.ContextDatabase = (*Database)(nil)
+	_ note.DatabaseWatcher = (*Database)(nil)
+)
+
+func (x *Database) store() Store {
+	x.once.Do(func() {
+		if x.Store == nil {
+			x.Store = &MemoryStore{}
+		}
+	})
+	return x.Store
+}
+
+func (x *Database) now() time.Time {
+	if x.Now != nil {
+		return x.Now()
+	}
+	return time.Now()
+}
+
+// IsolatedRead reads the current state of the note map.
+func (x *Database) IsolatedRead(f func(r note.FindLoader) error) error {
+	return x.IsolatedReadContext(context.Background(), f)
+}
+
+// IsolatedReadContext reads the current state of the note map under the
+// control of ctx.
+func (x *Database) IsolatedReadContext(ctx context.Context, f func(r note.FindLoader) error) error {
+	return note.IsolatedReadContext(ctx, x.DB, f)
+}
+
+// IsolatedWrite writes to the note map and, if the write succeeds, records
+// every operation patched by f as a single batch.
+func (x *Database) IsolatedWrite(f func(rw note.FindLoadPatcher) error) error {
+	return x.IsolatedWriteContext(context.Background(), f)
+}
+
+// IsolatedWriteContext is like IsolatedWrite, but under the control of ctx.
+//
+// f may read the history of x, for example with Head or IsolatedReadAt, but
+// it sees none of the batch that it is writing. The batch is appended to the
+// store only after it is committed.
+//
+// If the write is committed but the batch cannot be appended to the store,
+// the error from the store is returned and the batch is missing from the
+// history.
+func (x *Database) IsolatedWriteContext(ctx context.Context, f func(rw note.FindLoadPatcher) error) error {
+	var rec *recorder
+	if err := note.IsolatedWriteContext(ctx, x.DB, func(rw note.FindLoadPatcher) error {
+		rec = &recorder{FindLoadPatcher: rw}
+		return f(rec.withParents(rw))
+	}); err != nil {
+		return err
+	}
+	if len(rec.ops) == 0 {
+		return nil
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	es, err := x.store().Entries()
+	if err != nil {
+		return err
+	}
+	return x.store().Append(Entry{
+		Revision: Revision(len(es)) + 1,
+		Time:     x.now(),
+		Author:   x.Author,
+		Ops:      rec.ops,
+		Inverse:  rec.inverse,
+	})
+}
+
+// Watch returns the changes to the underlying database, which must be a
+// note.DatabaseWatcher.
+func (x *Database) Watch() (<-chan note.Change, func(), error) {
+	w, ok := x.DB.(note.DatabaseWatcher)
+	if !ok {
+		return nil, nil, fmt.Errorf("%T cannot be watched: %w", x.DB, note.UnsupportedOperation)
+	}
+	return w.Watch()
+}
+
+// Close closes the underlying database, and then the store if it is an
+// io.Closer.
+func (x *Database) Close() error {
+	err := x.DB.Close()
+	if c, ok := x.Store.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// recorder collects the operations patched within a single write, along
+// with the operations that would undo them.
+type recorder struct {
+	note.FindLoadPatcher
+	ops, inverse []note.Operation
+}
+
+// withParents returns r, and makes it a note.ParentLoader too if rw is one.
+func (r *recorder) withParents(rw note.FindLoadPatcher) note.FindLoadPatcher {
+	if pl, ok := rw.(note.ParentLoader); ok {
+		return struct {
+			*recorder
+			note.ParentLoader
+		}{r, pl}
+	}
+	return r
+}
+
+func (r *recorder) Patch(ops []note.Operation) error {
+	inv, err := note.Invert(r, ops)
+	if err != nil {
+		return err
+	}
+	if err := r.FindLoadPatcher.Patch(ops); err != nil {
+		return err
+	}
+	r.ops = append(r.ops, ops...)
+	r.inverse = append(inv, r.inverse...)
+	return nil
+}
+
+// Head returns the most recent revision.
+func (x *Database) Head() (Revision, error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	es, err := x.store().Entries()
+	return Revision(len(es)), err
+}
+
+// RevisionAt returns the most recent revision committed no later than t, or
+// zero if no revision was.
+func (x *Database) RevisionAt(t time.Time) (Revision, error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	es, err := x.store().Entries()
+	if err != nil {
+		return 0, err
+	}
+	i := sort.Search(len(es), func(i int) bool { return es[i].Time.After(t) })
+	return Revision(i), nil
+}
+
+// IsolatedReadAt invokes f with a FindLoader that reads the note map as it
+// was at rev.
+//
+// The notes read through f reflect the state at rev, but rev is not checked
+// against changes made by other writers since: see the package documentation.
+func (x *Database) IsolatedReadAt(rev Revision, f func(r note.FindLoader) error) error {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	es, err := x.store().Entries()
+	if err != nil {
+		return err
+	}
+	if int(rev) > len(es) {
+		return fmt.Errorf("revision %v is after the most recent revision %v", rev, len(es))
+	}
+	return x.DB.IsolatedRead(func(r note.FindLoader) error {
+		return f(rewind(r, es[rev:]))
+	})
+}
+
+// IsolatedReadAtTime is like IsolatedReadAt, reading the note map as it was
+// at time t.
+func (x *Database) IsolatedReadAtTime(t time.Time, f func(r note.FindLoader) error) error {
+	rev, err := x.RevisionAt(t)
+	if err != nil {
+		return err
+	}
+	return x.IsolatedReadAt(rev, f)
+}
+
+// rewind returns a FindLoader that reads r with each of es undone.
+func rewind(r note.FindLoader, es []Entry) note.FindLoader {
+	stage := &note.Stage{Base: r}
+	for i := len(es) - 1; i >= 0; i-- {
+		stage.Ops = append(stage.Ops, es[i].Inverse...)
+	}
+	return stage
+}
+
+// Changes returns the entries that changed the note with id, in order of
+// revision, each including only the operations that affect that note.
+func (x *Database) Changes(id note.ID) ([]Entry, error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	es, err := x.store().Entries()
+	if err != nil {
+		return nil, err
+	}
+	var changes []Entry
+	for _, e := range es {
+		e.Ops = affecting(id, e.Ops)
+		if len(e.Ops) == 0 {
+			continue
+		}
+		e.Inverse = affecting(id, e.Inverse)
+		changes = append(changes, e)
+	}
+	return changes, nil
+}
+
+func affecting(id note.ID, ops []note.Operation) []note.Operation {
+	var result []note.Operation
+	for _, op := range ops {
+		if op.AffectsID(id) {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+// Diff returns operations that, if applied to the note map as it was at
+// revision from, would make it match the note map as it was at revision to.
+//
+// Only the notes changed by the entries between the two revisions are
+// compared, and the operations for each note are produced by note.Diff.
+func (x *Database) Diff(from, to Revision) ([]note.Operation, error) {
+	lo, hi := from, to
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	es, err := x.store().Entries()
+	if err != nil {
+		return nil, err
+	}
+	if int(hi) > len(es) {
+		return nil, fmt.Errorf("revision %v is after the most recent revision %v", hi, len(es))
+	}
+	var (
+		ids  []note.ID
+		seen = make(map[note.ID]bool)
+	)
+	for _, e := range es[lo:hi] {
+		for _, op := range e.Ops {
+			o, ok := op.(interface{ GetID() note.ID })
+			if ok && !seen[o.GetID()] {
+				seen[o.GetID()] = true
+				ids = append(ids, o.GetID())
+			}
+		}
+	}
+	var ops note.OperationSlice
+	err = x.DB.IsolatedRead(func(r note.FindLoader) error {
+		a, err := truncateAll(rewind(r, es[from:]), ids)
+		if err != nil {
+			return err
+		}
+		b, err := truncateAll(rewind(r, es[to:]), ids)
+		if err != nil {
+			return err
+		}
+		for i := range ids {
+			ops = append(ops, note.Diff(a[i], b[i])...)
+		}
+		return nil
+	})
+	return ops, err
+}
+
+func truncateAll(l note.Loader, ids []note.ID) ([]note.TruncatedNote, error) {
+	ns, err := l.Load(ids)
+	if err != nil {
+		return nil, err
+	}
+	tns := make([]note.TruncatedNote, len(ns))
+	for i, n := range ns {
+		if tns[i], err = note.TruncateNote(n); err != nil {
+			return nil, err
+		}
+	}
+	return tns, nil
+}
@@ -0,0 +1,292 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/note-maps/note"
+	"github.com/google/note-maps/note/notetest"
+)
+
+// stageDB is an in-memory note.Database that keeps every operation in a
+// note.Stage.
+type stageDB struct{ note.Stage }
+
+func (db *stageDB) IsolatedRead(f func(r note.FindLoader) error) error {
+	return f(&db.Stage)
+}
+
+func (db *stageDB) IsolatedWrite(f func(rw note.FindLoadPatcher) error) error {
+	staged := note.Stage{Base: &db.Stage}
+	if err := f(stagePatcher{&staged}); err != nil {
+		return err
+	}
+	db.Ops = append(db.Ops, staged.Ops...)
+	return nil
+}
+
+func (db *stageDB) Close() error { return nil }
+
+type stagePatcher struct{ *note.Stage }
+
+func (s stagePatcher) Patch(ops []note.Operation) error {
+	s.Ops = append(s.Ops, ops...)
+	return nil
+}
+
+func newTestDatabase() *Database {
+	clock := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &Database{
+		DB:     &stageDB{},
+		Author: "tester",
+		Now: func() time.Time {
+			clock = clock.Add(time.Hour)
+			return clock
+		},
+	}
+}
+
+func write(t *testing.T, db *Database, ops note.OperationSlice) {
+	t.Helper()
+	if err := db.IsolatedWrite(func(rw note.FindLoadPatcher) error {
+		return rw.Patch(ops)
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func truncateAt(t *testing.T, db *Database, rev Revision, id note.ID) note.TruncatedNote {
+	t.Helper()
+	var tn note.TruncatedNote
+	if err := db.IsolatedReadAt(rev, func(r note.FindLoader) error {
+		n, err := note.LoadOne(r, id)
+		if err != nil {
+			return err
+		}
+		tn, err = note.TruncateNote(n)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return tn
+}
+
+func TestDatabase(t *testing.T) {
+	db := newTestDatabase()
+	write(t, db, note.OperationSlice{}.SetValue("n0", "first", note.TextPlain))
+	write(t, db, note.OperationSlice{}.
+		SetValueString("n0", "second").
+		InsertContent("n0", 0, "n1").
+		SetValueString("n1", "child"))
+	write(t, db, note.OperationSlice{}.ClearNote("n1"))
+	if err := db.IsolatedWrite(func(rw note.FindLoadPatcher) error {
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if head, err := db.Head(); err != nil {
+		t.Fatal(err)
+	} else if head != 3 {
+		t.Errorf("got head %v, expected 3", head)
+	}
+
+	for _, test := range []struct {
+		Rev    Revision
+		ID     note.ID
+		Expect note.TruncatedNote
+	}{
+		{0, "n0", note.TruncatedNote{ID: "n0"}},
+		{1, "n0", note.TruncatedNote{ID: "n0", ValueString: "first", ValueType: note.TextPlain}},
+		{2, "n0", note.TruncatedNote{ID: "n0", ValueString: "second", ValueType: note.TextPlain, Contents: []note.ID{"n1"}}},
+		{2, "n1", note.TruncatedNote{ID: "n1", ValueString: "child"}},
+		{3, "n1", note.TruncatedNote{ID: "n1"}},
+	} {
+		if got := truncateAt(t, db, test.Rev, test.ID); !got.Equals(test.Expect) {
+			t.Errorf("revision %v: got %#v, expected %#v", test.Rev, got, test.Expect)
+		}
+	}
+	if err := db.IsolatedReadAt(4, func(r note.FindLoader) error { return nil }); err == nil {
+		t.Error("expected an error reading a revision after the head")
+	}
+	if err := db.IsolatedReadAt(2, func(r note.FindLoader) error {
+		ns, err := r.Find(&note.Query{})
+		if len(ns) != 2 {
+			t.Errorf("found %v notes at revision 2, expected 2", len(ns))
+		}
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDatabase_time(t *testing.T) {
+	db := newTestDatabase()
+	write(t, db, note.OperationSlice{}.SetValueString("n0", "first"))
+	write(t, db, note.OperationSlice{}.SetValueString("n0", "second"))
+	for _, test := range []struct {
+		Time   time.Time
+		Expect Revision
+	}{
+		{time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), 0},
+		{time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC), 1},
+		{time.Date(2020, 1, 1, 1, 30, 0, 0, time.UTC), 1},
+		{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 2},
+	} {
+		if got, err := db.RevisionAt(test.Time); err != nil {
+			t.Fatal(err)
+		} else if got != test.Expect {
+			t.Errorf("at %v: got revision %v, expected %v", test.Time, got, test.Expect)
+		}
+	}
+	if err := db.IsolatedReadAtTime(time.Date(2020, 1, 1, 1, 30, 0, 0, time.UTC),
+		func(r note.FindLoader) error {
+			n, err := note.LoadOne(r, "n0")
+			if err != nil {
+				return err
+			}
+			if lex, _, err := n.GetValue(); err != nil || lex != "first" {
+				t.Errorf("got %#v, %v, expected %#v", lex, err, "first")
+			}
+			return nil
+		}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDatabase_Changes(t *testing.T) {
+	db := newTestDatabase()
+	write(t, db, note.OperationSlice{}.SetValueString("n0", "first"))
+	write(t, db, note.OperationSlice{}.SetValueString("n1", "other"))
+	write(t, db, note.OperationSlice{}.
+		SetValueString("n0", "second").
+		SetValueString("n1", "changed"))
+	changes, err := db.Changes("n0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var revs []Revision
+	for _, e := range changes {
+		revs = append(revs, e.Revision)
+		if e.Author != "tester" {
+			t.Errorf("got author %#v, expected %#v", e.Author, "tester")
+		}
+		for _, op := range append(e.Ops, e.Inverse...) {
+			if op.AffectsID("n1") {
+				t.Errorf("revision %v: unexpected operation %v", e.Revision, op)
+			}
+		}
+	}
+	if expect := []Revision{1, 3}; !reflect.DeepEqual(revs, expect) {
+		t.Errorf("got revisions %v, expected %v", revs, expect)
+	}
+}
+
+func TestDatabase_Diff(t *testing.T) {
+	db := newTestDatabase()
+	write(t, db, note.OperationSlice{}.SetValueString("n0", "first"))
+	write(t, db, note.OperationSlice{}.
+		SetValueString("n0", "second").
+		InsertContent("n0", 0, "n1"))
+	write(t, db, note.OperationSlice{}.SetValueString("n2", "unrelated"))
+	for _, test := range []struct{ From, To Revision }{
+		{0, 2}, {1, 2}, {2, 1}, {3, 0}, {2, 2},
+	} {
+		ops, err := db.Diff(test.From, test.To)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range []note.ID{"n0", "n1", "n2"} {
+			got := truncateAt(t, db, test.From, id)
+			if err := note.Patch(&got, ops); err != nil {
+				t.Fatal(err)
+			}
+			if expect := truncateAt(t, db, test.To, id); !got.Equals(expect) {
+				t.Errorf("%v to %v: got %#v, expected %#v", test.From, test.To, got, expect)
+			}
+		}
+	}
+	if _, err := db.Diff(0, 4); err == nil {
+		t.Error("expected an error diffing a revision after the head")
+	}
+}
+
+type failingStore struct{ MemoryStore }
+
+var errStore = errors.New("store failed")
+
+func (s *failingStore) Append(e Entry) error { return errStore }
+
+func TestDatabase_storeError(t *testing.T) {
+	db := newTestDatabase()
+	db.Store = &failingStore{}
+	err := db.IsolatedWrite(func(rw note.FindLoadPatcher) error {
+		return rw.Patch(note.OperationSlice{}.SetValueString("n0", "first"))
+	})
+	if !errors.Is(err, errStore) {
+		t.Errorf("got %v, expected %v", err, errStore)
+	}
+}
+
+func TestDatabase_readWithinWrite(t *testing.T) {
+	db := newTestDatabase()
+	write(t, db, note.OperationSlice{}.SetValueString("n0", "first"))
+	if err := db.IsolatedWrite(func(rw note.FindLoadPatcher) error {
+		if head, err := db.Head(); err != nil {
+			return err
+		} else if head != 1 {
+			t.Errorf("got head %v within a write, expected 1", head)
+		}
+		if tn := truncateAt(t, db, 0, "n0"); !tn.Empty() {
+			t.Errorf("got %#v at revision 0, expected an empty note", tn)
+		}
+		return rw.Patch(note.OperationSlice{}.SetValueString("n0", "second"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if head, err := db.Head(); err != nil {
+		t.Fatal(err)
+	} else if head != 2 {
+		t.Errorf("got head %v, expected 2", head)
+	}
+}
+
+func TestDatabase_forwarding(t *testing.T) {
+	db := newTestDatabase()
+	db.DB = notetest.MapDB{}
+	write(t, db, note.OperationSlice{}.InsertContent("n0", 0, "n1"))
+	if err := db.IsolatedWrite(func(rw note.FindLoadPatcher) error {
+		pl, ok := rw.(note.ParentLoader)
+		if !ok {
+			t.Fatalf("got %T, expected a note.ParentLoader", rw)
+		}
+		parents, err := pl.LoadParentIDs([]note.ID{"n1"})
+		if err != nil {
+			return err
+		}
+		if expect := [][]note.ID{{"n0"}}; !reflect.DeepEqual(parents, expect) {
+			t.Errorf("got parents %v, expected %v", parents, expect)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := db.Watch(); !errors.Is(err, note.UnsupportedOperation) {
+		t.Errorf("got %v, expected %v", err, note.UnsupportedOperation)
+	}
+}
@@ -2,7 +2,10 @@
 
 package note
 
-import "strconv"
+import (
+	"fmt"
+	"strconv"
+)
 
 func (xs IDSlice) Append(add ...ID) IDSliceDelta {
 	return xs.Insert(len(xs), add...)
@@ -158,6 +161,12 @@ func (x IDSliceOpInsert) Skip(n int) IDSliceOp { return x[n:] }
 func (x IDSliceOpInsert) Rebase(base IDSliceOp) (IDSliceOp, IDSliceOp, IDSliceOp) {
 	switch bo := base.(type) {
 	case IDSliceOpInsert:
+		// Where both insert at the same position, the insertion that sorts
+		// first is placed first, so that rebasing either one onto the other
+		// produces the same result.
+		if x.before(bo) {
+			return x, nil, bo
+		}
 		return IDSliceOpRetain(bo.Len()), x, nil
 	case IDSliceOpRetain:
 		return x, nil, bo
@@ -166,6 +175,16 @@ func (x IDSliceOpInsert) Rebase(base IDSliceOp) (IDSliceOp, IDSliceOp, IDSliceOp
 	}
 	panic("unknown base type")
 }
+
+// before returns true if x sorts before y.
+func (x IDSliceOpInsert) before(y IDSliceOpInsert) bool {
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			return x[i] < y[i]
+		}
+	}
+	return len(x) < len(y)
+}
 func (x IDSliceOpInsert) Compact(op IDSliceOp) (IDSliceOp, bool) {
 	if o, ok := op.(IDSliceOpInsert); ok {
 		return append(x, o...), true
@@ -281,6 +300,29 @@ func (xs IDSlice) CanApply(ops []IDSliceOp) bool {
 	return true
 }
 
+// Invert returns a delta that undoes x after it has been applied to base.
+//
+// An error is returned if x cannot be applied to base.
+func (x IDSliceDelta) Invert(base IDSlice) (IDSliceDelta, error) {
+	if !base.CanApply(x) {
+		return nil, fmt.Errorf("cannot invert a delta that cannot be applied: %w", ConflictingDelta)
+	}
+	var inv IDSliceDelta
+	for _, op := range x {
+		switch o := op.(type) {
+		case IDSliceOpInsert:
+			inv = append(inv, IDSliceOpDelete(len(o)))
+		case IDSliceOpRetain:
+			inv = append(inv, o)
+			base = base[o:]
+		case IDSliceOpDelete:
+			inv = append(inv, IDSliceOpInsert(append(IDSlice{}, base[:o]...)))
+			base = base[o:]
+		}
+	}
+	return inv, nil
+}
+
 func (xs IDSlice) Apply(ops []IDSliceOp) IDSlice {
 	var head, mid, tail IDSlice
 	tail = xs
@@ -313,6 +355,86 @@ func IDSliceDiff(a, b []ID) IDSliceDelta {
 	return ops
 }
 
+// IDSliceMerge produces a delta that can be applied to ours to include
+// the changes made from base to theirs.
+//
+// Returns false instead if ours and theirs change overlapping parts of base,
+// or if both insert at the same position.
+func IDSliceMerge(base, ours, theirs []ID) (IDSliceDelta, bool, error) {
+	equal := func(xs, ys []ID) bool {
+		return len(xs) == len(ys) && IDSlice(xs).PrefixMatch(ys) == len(xs)
+	}
+	switch {
+	case equal(ours, theirs), equal(theirs, base):
+		return nil, true, nil
+	case equal(ours, base):
+		return IDSliceDiff(ours, theirs), true, nil
+	}
+	od, td := IDSliceDiff(base, ours), IDSliceDiff(base, theirs)
+	if editSpansOverlap(od.editSpans(), td.editSpans()) {
+		return nil, false, nil
+	}
+	r, err := td.Rebase(od)
+	if err != nil {
+		return nil, false, err
+	}
+	if !IDSlice(ours).CanApply(r) {
+		return nil, false, fmt.Errorf("cannot merge changes that cannot be applied: %w", ConflictingDelta)
+	}
+	return r, true, nil
+}
+
+// editSpan is a range of a base slice changed by a delta, where adjacent
+// deletions and insertions form a single span.
+type editSpan struct {
+	start, end int
+	inserts    bool
+}
+
+// editSpans returns the ranges of a base slice changed by x.
+func (x IDSliceDelta) editSpans() []editSpan {
+	var (
+		spans []editSpan
+		pos   int
+		open  bool
+	)
+	for _, op := range x {
+		if r, ok := op.(IDSliceOpRetain); ok {
+			pos += int(r)
+			open = false
+			continue
+		}
+		if !open {
+			spans = append(spans, editSpan{start: pos, end: pos})
+			open = true
+		}
+		last := &spans[len(spans)-1]
+		if d, ok := op.(IDSliceOpDelete); ok {
+			pos += int(d)
+			last.end = pos
+		} else {
+			last.inserts = true
+		}
+	}
+	return spans
+}
+
+// editSpansOverlap returns true if any span in xs overlaps a span in ys, or if
+// both insert at the same position.
+func editSpansOverlap(xs, ys []editSpan) bool {
+	for _, a := range xs {
+		for _, b := range ys {
+			if a.start < b.end && b.start < a.end {
+				return true
+			}
+			if a.inserts && b.inserts && a.start <= b.end && b.start <= a.end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func idSliceLCS(a, b IDSlice) (ai, bi, ln int) {
 	ls := make([]int, len(a)*len(b))
 	max := 0
@@ -179,6 +179,60 @@ func TestIDSlice_Apply(t *testing.T) {
 	}
 }
 
+func TestIDSliceDelta_Rebase_converges(t *testing.T) {
+	base := IDSlice{TestID0, TestID1}
+	deltas := []IDSliceDelta{
+		base.Insert(0, TestID2),
+		base.Insert(0, TestID3, TestID0),
+		base.Insert(1, TestID2),
+		base.Append(TestID3),
+		base.Delete(0, 1),
+		base.Delete(0, 1).Insert(TestID3),
+		base.Retain(1).Delete(1),
+	}
+	for _, a := range deltas {
+		for _, b := range deltas {
+			a2, err := a.Rebase(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b2, err := b.Rebase(a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ab := base.Apply(a).Apply(b2)
+			ba := base.Apply(b).Apply(a2)
+			if !reflect.DeepEqual(ab, ba) {
+				t.Error(a, "and", b, "diverge:", ab, "and", ba)
+			}
+		}
+	}
+}
+
+func TestIDSliceDelta_Invert(t *testing.T) {
+	base := IDSlice{TestID0, TestID1, TestID2}
+	for _, delta := range []IDSliceDelta{
+		nil,
+		base.Append(TestID3),
+		base.Insert(1, TestID3),
+		base.Delete(0, 2),
+		base.DeleteElements(TestID1),
+		IDSliceDiff(base, IDSlice{TestID2, TestID3, TestID0}),
+	} {
+		inv, err := delta.Invert(base)
+		if err != nil {
+			t.Error(delta, err)
+			continue
+		}
+		if got := base.Apply(delta).Apply(inv); !reflect.DeepEqual(got, base) {
+			t.Error(delta, "then", inv, "got", got, "expected", base)
+		}
+	}
+	if _, err := base.Delete(0, 3).Invert(base[:1]); err == nil {
+		t.Error("expected an error for a delta that cannot be applied")
+	}
+}
+
 func TestIDSlice_Diff_andApply(t *testing.T) {
 	for _, test := range []struct {
 		N      string
@@ -211,6 +265,57 @@ func TestIDSlice_Diff_andApply(t *testing.T) {
 	}
 }
 
+func TestIDSliceMerge(t *testing.T) {
+	base := IDSlice{TestID0, TestID1, TestID2}
+	for _, test := range []struct {
+		N            string
+		Ours, Theirs IDSlice
+		Expect       IDSlice
+		OK           bool
+	}{
+		{"unchanged", base, base, base, true},
+		{"only ours", IDSlice{TestID0}, base, IDSlice{TestID0}, true},
+		{"only theirs", base, IDSlice{TestID0}, IDSlice{TestID0}, true},
+		{
+			"separate changes",
+			IDSlice{TestID3, TestID0, TestID1, TestID2},
+			IDSlice{TestID0, TestID1},
+			IDSlice{TestID3, TestID0, TestID1},
+			true,
+		},
+		{
+			"overlapping deletes",
+			IDSlice{TestID2},
+			IDSlice{TestID0},
+			IDSlice{TestID2},
+			false,
+		},
+		{
+			"inserts at the same position",
+			IDSlice{TestID0, TestID3, TestID1, TestID2},
+			IDSlice{TestID0, TestID2, TestID1, TestID2},
+			IDSlice{TestID0, TestID3, TestID1, TestID2},
+			false,
+		},
+	} {
+		t.Run(test.N, func(t *testing.T) {
+			delta, ok, err := IDSliceMerge(base, test.Ours, test.Theirs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != test.OK {
+				t.Fatal("got", ok, "expected", test.OK)
+			}
+			if !ok {
+				return
+			}
+			if actual := test.Ours.Apply(delta); !reflect.DeepEqual(actual, test.Expect) {
+				t.Error("got", actual, "expected", test.Expect)
+			}
+		})
+	}
+}
+
 func Test_idSliceLCS(t *testing.T) {
 	for _, test := range []struct {
 		N          string
@@ -253,6 +358,12 @@ func TestIDSliceDelta_Rebase(t *testing.T) {
 			IDSlice{}.Insert(0, TestID2),
 			IDSlice{}.Insert(1, TestID2),
 		},
+		{
+			"insert2 vs insert1",
+			IDSlice{}.Insert(0, TestID2),
+			IDSlice{}.Insert(0, TestID1),
+			IDSlice{}.Insert(0, TestID1),
+		},
 		{
 			"insert1 vs retain1",
 			IDSlice{}.Insert(0, TestID1),
@@ -0,0 +1,122 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"fmt"
+
+	"github.com/google/note-maps/otgen/runes"
+	"github.com/google/note-maps/otgen/strs"
+)
+
+// Invert returns operations that undo ops after they have been applied to the
+// notes in base.
+func Invert(base Loader, ops []Operation) ([]Operation, error) {
+	inverses := make([]OperationSlice, len(ops))
+	for i, op := range ops {
+		stage := Stage{Ops: ops[:i], Base: base}
+		inv, err := invertOne(&stage, op)
+		if err != nil {
+			return nil, err
+		}
+		inverses[i] = inv
+	}
+	var result OperationSlice
+	for i := len(inverses) - 1; i >= 0; i-- {
+		result = append(result, inverses[i]...)
+	}
+	return result, nil
+}
+
+// invertOne returns operations that undo op after it has been applied to the
+// notes in stage.
+func invertOne(stage *Stage, op Operation) (OperationSlice, error) {
+	var inv OperationSlice
+	switch o := op.(type) {
+	case OpSetValue:
+		lex, vt, err := stage.Note(o.GetID()).GetValue()
+		if err != nil {
+			return nil, err
+		}
+		inv = inv.SetValue(o.GetID(), lex, idOf(vt))
+	case OpSetValueString:
+		lex, _, err := stage.Note(o.GetID()).GetValue()
+		if err != nil {
+			return nil, err
+		}
+		inv = inv.SetValueString(o.GetID(), lex)
+	case OpValueDelta:
+		lex, _, err := stage.Note(o.GetID()).GetValue()
+		if err != nil {
+			return nil, err
+		}
+		delta, err := runes.StringDelta(o.StringOps).Invert([]rune(lex))
+		if err != nil {
+			return nil, err
+		}
+		inv = inv.PatchValue(o.GetID(), delta)
+	case OpContentDelta:
+		cids, err := stage.Note(o.GetID()).GetContentIDs()
+		if err != nil {
+			return nil, err
+		}
+		delta, err := IDSliceDelta(o.IDSliceOps).Invert(cids)
+		if err != nil {
+			return nil, err
+		}
+		inv = inv.PatchContent(o.GetID(), delta)
+	case OpTypesDelta:
+		tids, err := stage.Note(o.GetID()).GetTypeIDs()
+		if err != nil {
+			return nil, err
+		}
+		delta, err := IDSliceDelta(o.IDSliceOps).Invert(tids)
+		if err != nil {
+			return nil, err
+		}
+		inv = inv.PatchTypes(o.GetID(), delta)
+	case OpRolesDelta:
+		rs, err := stage.Note(o.GetID()).GetRoles()
+		if err != nil {
+			return nil, err
+		}
+		add, remove := RoleSliceDiff(RoleSlice(rs).Apply(o.Add, o.Remove), rs)
+		inv = inv.PatchRoles(o.GetID(), add, remove)
+	case OpSubjectIdentifiersDelta:
+		sis, err := stage.Note(o.GetID()).GetSubjectIdentifiers()
+		if err != nil {
+			return nil, err
+		}
+		delta, err := strs.StringsDelta(o.StringsOps).Invert(sis)
+		if err != nil {
+			return nil, err
+		}
+		inv = inv.PatchSubjectIdentifiers(o.GetID(), delta)
+	case OpClearNote:
+		tn, err := TruncateNote(stage.Note(o.GetID()))
+		if err != nil {
+			return nil, err
+		}
+		cleared := TruncatedNote{ID: tn.ID}
+		if tn.ValueString != "" || !tn.ValueType.Empty() {
+			inv = inv.SetValue(tn.ID, tn.ValueString, tn.ValueType)
+			cleared.ValueString, cleared.ValueType = tn.ValueString, tn.ValueType
+		}
+		inv = append(inv, Diff(cleared, tn)...)
+	default:
+		return nil, fmt.Errorf("cannot invert %T: %w", op, UnsupportedOperation)
+	}
+	return inv, nil
+}
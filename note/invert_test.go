@@ -0,0 +1,78 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"testing"
+
+	"github.com/google/note-maps/otgen/runes"
+	"github.com/google/note-maps/otgen/strs"
+)
+
+func TestInvert(t *testing.T) {
+	for _, test := range []struct {
+		Title string
+		Ops   OperationSlice
+	}{
+		{"no ops", nil},
+		{"set value", OperationSlice{}.SetValue("n0", "goodbye", "vt1")},
+		{"set value string", OperationSlice{}.SetValueString("n1", "planet")},
+		{"patch value", OperationSlice{}.
+			PatchValue("n0", runes.StringDiff([]rune("hello"), []rune("jello!"))).
+			PatchValue("n1", runes.StringDelta{}.Delete(5))},
+		{"patch content", OperationSlice{}.
+			PatchContent("n0", IDSlice{"n1"}.Append("n2")).
+			PatchContent("n0", IDSlice{"n1", "n2"}.DeleteElements("n1"))},
+		{"patch types", OperationSlice{}.PatchTypes("n0", IDSlice{"t0"}.Delete(0, 1))},
+		{"patch roles", OperationSlice{}.
+			PatchRoles("n1", []Role{{"r0", "n0"}, {"r1", "n2"}}, []Role{{"r0", "n0"}})},
+		{"patch subject identifiers", OperationSlice{}.
+			PatchSubjectIdentifiers("n0", strs.Strings{"si0"}.Insert(0, "si1"))},
+		{"clear", OperationSlice{}.ClearNote("n0").ClearNote("n1")},
+		{"change then clear", OperationSlice{}.
+			SetValue("n2", "new", EmptyID).
+			ClearNote("n2")},
+	} {
+		t.Run(test.Title, func(t *testing.T) {
+			m := testNoteMap()
+			inv, err := Invert(m, test.Ops)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := m.Patch(test.Ops); err != nil {
+				t.Fatal(err)
+			}
+			if err := m.Patch(inv); err != nil {
+				t.Fatal(err)
+			}
+			for id, expect := range testNoteMap() {
+				if got := m[id]; !got.Equals(expect) {
+					t.Errorf("got %#v, expected %#v", got, expect)
+				}
+			}
+			if got := m["n2"]; !got.Empty() {
+				t.Errorf("got %#v, expected an empty note", got)
+			}
+		})
+	}
+}
+
+type unknownOp struct{ Op }
+
+func TestInvert_unknownOperation(t *testing.T) {
+	if _, err := Invert(EmptyLoader, []Operation{unknownOp{"n0"}}); err == nil {
+		t.Error("expected an error")
+	}
+}
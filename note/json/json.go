@@ -0,0 +1,241 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package json reads and writes whole note maps in the JSON representation
+// described in docs/data-model.md.
+//
+// A note map is a JSON array of notes. Within the content of a note, each
+// element is either the ID of another note or a whole note written inline,
+// and an inline note may omit its ID.
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/google/note-maps/note"
+)
+
+// Note is the JSON representation of a note.
+type Note struct {
+	ID                 note.ID               `json:"id,omitempty"`
+	Value              string                `json:"value,omitempty"`
+	ValueTypeID        note.ID               `json:"value_type_id,omitempty"`
+	SubjectIdentifiers []string              `json:"subject_identifiers,omitempty"`
+	TypeIDs            []note.ID             `json:"type_ids,omitempty"`
+	ContentIDs         []Content             `json:"content_ids,omitempty"`
+	RolePlayers        map[note.ID][]note.ID `json:"role_players,omitempty"`
+}
+
+// Content is an element of the content of a note: either the ID of another
+// note, or another note written inline.
+type Content struct {
+	ID   note.ID
+	Note *Note
+}
+
+// MarshalJSON writes c as a string if it is an ID, or as an object if it is
+// an inline note.
+func (c Content) MarshalJSON() ([]byte, error) {
+	if c.Note != nil {
+		return json.Marshal(c.Note)
+	}
+	return json.Marshal(c.ID)
+}
+
+// UnmarshalJSON reads c from either a string or an object.
+func (c *Content) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		c.ID, c.Note = note.EmptyID, &Note{}
+		return json.Unmarshal(data, c.Note)
+	}
+	c.Note = nil
+	return json.Unmarshal(data, &c.ID)
+}
+
+// FromNote returns the JSON representation of n, with the content of n
+// written as IDs.
+func FromNote(n note.GraphNote) (*Note, error) {
+	tn, err := note.TruncateNote(n)
+	if err != nil {
+		return nil, err
+	}
+	jn := &Note{
+		ID:                 tn.ID,
+		Value:              tn.ValueString,
+		ValueTypeID:        tn.ValueType,
+		SubjectIdentifiers: tn.SubjectIdentifiers,
+		TypeIDs:            tn.Types,
+	}
+	for _, id := range tn.Contents {
+		jn.ContentIDs = append(jn.ContentIDs, Content{ID: id})
+	}
+	for _, r := range tn.Roles {
+		if jn.RolePlayers == nil {
+			jn.RolePlayers = make(map[note.ID][]note.ID)
+		}
+		jn.RolePlayers[r.Type] = append(jn.RolePlayers[r.Type], r.Player)
+	}
+	return jn, nil
+}
+
+// Encoder writes notes to a stream as the elements of a JSON array.
+type Encoder struct {
+	w     io.Writer
+	count int
+}
+
+// NewEncoder returns an Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder { return &Encoder{w: w} }
+
+// Encode writes n as the next element of the array.
+func (e *Encoder) Encode(n note.GraphNote) error {
+	jn, err := FromNote(n)
+	if err != nil {
+		return err
+	}
+	bs, err := json.Marshal(jn)
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if e.count == 0 {
+		sep = "[\n"
+	}
+	e.count++
+	if _, err = io.WriteString(e.w, sep); err != nil {
+		return err
+	}
+	_, err = e.w.Write(bs)
+	return err
+}
+
+// Close ends the array. It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	end := "\n]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+// Export writes every note that r can find to w.
+func Export(w io.Writer, r note.FindLoader) error {
+	ns, err := r.Find(&note.Query{})
+	if err != nil {
+		return err
+	}
+	e := NewEncoder(w)
+	for _, n := range ns {
+		if err := e.Encode(n); err != nil {
+			return err
+		}
+	}
+	return e.Close()
+}
+
+// Import reads a note map from r and returns the operations that would make
+// each note it describes in base match its description.
+//
+// Inline notes without IDs are given new random IDs that are not used in
+// base. Notes are read one at a time, so the whole note map is never held in
+// memory in its JSON form.
+func Import(r io.Reader, base note.Loader) ([]note.Operation, error) {
+	d := json.NewDecoder(r)
+	if err := expectDelim(d, '['); err != nil {
+		return nil, err
+	}
+	stage := &note.Stage{Base: base}
+	for d.More() {
+		var jn Note
+		if err := d.Decode(&jn); err != nil {
+			return nil, err
+		}
+		if jn.ID.Empty() {
+			return nil, fmt.Errorf("top-level note has no id: %w", note.InvalidID)
+		}
+		if _, err := stageNote(stage, &jn); err != nil {
+			return nil, err
+		}
+	}
+	if err := expectDelim(d, ']'); err != nil {
+		return nil, err
+	}
+	return stage.Ops, nil
+}
+
+func expectDelim(d *json.Decoder, delim json.Delim) error {
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+	if t != delim {
+		return fmt.Errorf("expected %v, got %v", delim, t)
+	}
+	return nil
+}
+
+// stageNote adds operations to stage that make the note described by jn, and
+// each of its inline notes, match that description. It returns the ID of the
+// note.
+func stageNote(stage *note.Stage, jn *Note) (note.ID, error) {
+	id := jn.ID
+	if id.Empty() {
+		sn, err := stage.NewNote()
+		if err != nil {
+			return note.EmptyID, err
+		}
+		id = sn.ID
+	}
+	tn := note.TruncatedNote{
+		ID:                 id,
+		ValueString:        jn.Value,
+		ValueType:          jn.ValueTypeID,
+		Types:              jn.TypeIDs,
+		SubjectIdentifiers: jn.SubjectIdentifiers,
+	}
+	// Inline notes are staged first so that each gets a distinct new ID.
+	for _, c := range jn.ContentIDs {
+		cid := c.ID
+		if c.Note != nil {
+			var err error
+			if cid, err = stageNote(stage, c.Note); err != nil {
+				return note.EmptyID, err
+			}
+		}
+		if cid.Empty() {
+			return note.EmptyID, note.InvalidID
+		}
+		tn.Contents = append(tn.Contents, cid)
+	}
+	types := make([]note.ID, 0, len(jn.RolePlayers))
+	for t := range jn.RolePlayers {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	for _, t := range types {
+		for _, p := range jn.RolePlayers[t] {
+			tn.Roles = append(tn.Roles, note.Role{Type: t, Player: p})
+		}
+	}
+	if err := stage.Note(id).Replace(tn); err != nil {
+		return note.EmptyID, err
+	}
+	return id, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/note-maps/note"
+)
+
+// dataModelExample is the example from docs/data-model.md.
+const dataModelExample = `[
+  {
+    "id": "05f5652c-f2ec-4923-898c-c9aed4a22268",
+    "subject_identifiers": ["https://git-scm.com"],
+    "type_ids": ["492a47dc-c350-4aae-952a-b9d8602837e8"],
+    "content_ids": [
+      { "value": "git", "type_ids": ["org.note-maps.name"] },
+      { "value": "A distributed version-control system." },
+      "d6d42492-231f-41c9-a6af-c3c80e8dbd09"
+    ]
+  },
+  {
+    "id": "492a47dc-c350-4aae-952a-b9d8602837e8",
+    "content_ids": [
+      { "type_ids": ["org.note-maps.name"], "value": "software" }
+    ]
+  },
+  {
+    "id": "d6d42492-231f-41c9-a6af-c3c80e8dbd09",
+    "role_players": {
+      "1eff6b0c-1fef-4fe3-9f9b-52420ec9feb6": ["05f5652c-f2ec-4923-898c-c9aed4a22268"],
+      "": ["3532f60d-0842-456e-bcf4-b28c68d96371"]
+    }
+  },
+  {
+    "id": "1eff6b0c-1fef-4fe3-9f9b-52420ec9feb6",
+    "content_ids": [
+      { "type_ids": ["org.note-maps.name"], "value": "implementation" }
+    ]
+  },
+  {
+    "id": "3532f60d-0842-456e-bcf4-b28c68d96371",
+    "type_ids": ["f5650c12-7f8d-4fa4-af25-f47fd20154ad"],
+    "content_ids": [
+      { "type_ids": ["org.note-maps.name"], "value": "merkle tree" }
+    ]
+  },
+  {
+    "id": "f5650c12-7f8d-4fa4-af25-f47fd20154ad",
+    "content_ids": [
+      { "type_ids": ["org.note-maps.name"], "value": "data structure" }
+    ]
+  }
+]
+`
+
+func importStage(t *testing.T, src string, base note.Loader) *note.Stage {
+	t.Helper()
+	ops, err := Import(strings.NewReader(src), base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &note.Stage{Ops: ops, Base: base}
+}
+
+func TestImport(t *testing.T) {
+	stage := importStage(t, dataModelExample, nil)
+	git, err := note.TruncateNote(stage.Note("05f5652c-f2ec-4923-898c-c9aed4a22268"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(git.Contents) != 3 || git.Contents[2] != "d6d42492-231f-41c9-a6af-c3c80e8dbd09" {
+		t.Fatalf("got contents %v", git.Contents)
+	}
+	if len(git.SubjectIdentifiers) != 1 || git.SubjectIdentifiers[0] != "https://git-scm.com" {
+		t.Errorf("got subject identifiers %v", git.SubjectIdentifiers)
+	}
+	name, err := note.TruncateNote(stage.Note(git.Contents[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name.ValueString != "git" || len(name.Types) != 1 || name.Types[0] != note.Name {
+		t.Errorf("got inline note %#v", name)
+	}
+	if git.Contents[0] == git.Contents[1] {
+		t.Error("expected inline notes to have distinct ids")
+	}
+	link, err := note.TruncateNote(stage.Note("d6d42492-231f-41c9-a6af-c3c80e8dbd09"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectRoles := note.RoleSlice{
+		{Type: "", Player: "3532f60d-0842-456e-bcf4-b28c68d96371"},
+		{Type: "1eff6b0c-1fef-4fe3-9f9b-52420ec9feb6", Player: "05f5652c-f2ec-4923-898c-c9aed4a22268"},
+	}
+	if !expectRoles.Equals(link.Roles) {
+		t.Errorf("got roles %v, expected %v", link.Roles, expectRoles)
+	}
+	ns, err := stage.Find(&note.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ns) != 12 {
+		t.Errorf("got %v notes, expected 12", len(ns))
+	}
+}
+
+func TestImport_update(t *testing.T) {
+	base := importStage(t, `[{"id": "n0", "value": "old", "content_ids": ["n1"]}]`, nil)
+	stage := importStage(t, `[{"id": "n0", "value": "new", "value_type_id": "dt"}]`, base)
+	n0, err := note.TruncateNote(stage.Note("n0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := note.TruncatedNote{ID: "n0", ValueString: "new", ValueType: "dt"}
+	if !n0.Equals(expect) {
+		t.Errorf("got %#v, expected %#v", n0, expect)
+	}
+}
+
+func TestImport_errors(t *testing.T) {
+	for _, src := range []string{
+		``,
+		`{}`,
+		`[{"value": "no id"}]`,
+		`[{"id": "n0", "content_ids": [""]}]`,
+		`[{"id": "n0"}`,
+		`[{"id": 5}]`,
+	} {
+		if _, err := Import(strings.NewReader(src), nil); err == nil {
+			t.Errorf("%#v: expected an error", src)
+		}
+	}
+	_, err := Import(strings.NewReader(`[{"value": "no id"}]`), nil)
+	if !errors.Is(err, note.InvalidID) {
+		t.Errorf("got %v, expected %v", err, note.InvalidID)
+	}
+}
+
+func TestExport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Export(&buf, &note.Stage{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("got %#v for an empty note map", buf.String())
+	}
+
+	stage := importStage(t, dataModelExample, nil)
+	buf.Reset()
+	if err := Export(&buf, stage); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(),
+		`{"id":"d6d42492-231f-41c9-a6af-c3c80e8dbd09","role_players":{"":["3532f60d-0842-456e-bcf4-b28c68d96371"],"1eff6b0c-1fef-4fe3-9f9b-52420ec9feb6":["05f5652c-f2ec-4923-898c-c9aed4a22268"]}}`) {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+	again := importStage(t, buf.String(), nil)
+	ns, err := stage.Find(&note.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range ns {
+		a, err := note.TruncateNote(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := note.TruncateNote(again.Note(n.GetID()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.Equals(b) {
+			t.Errorf("got %#v after export and import, expected %#v", b, a)
+		}
+	}
+}
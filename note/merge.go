@@ -0,0 +1,212 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"fmt"
+
+	"github.com/google/note-maps/otgen/runes"
+	"github.com/google/note-maps/otgen/strs"
+)
+
+// MergeConflict describes a field of a note that was changed in incompatible
+// ways by both sides of a three-way merge.
+type MergeConflict struct {
+	// ID is the ID of the note.
+	ID ID
+
+	// Field is the conflicting field of the note: "value", "content",
+	// "types", or "subject identifiers".
+	Field string
+
+	// Base, Ours, and Theirs are the three versions of the note.
+	Base, Ours, Theirs TruncatedNote
+}
+
+func (c MergeConflict) String() string {
+	return fmt.Sprintf("note %#v: conflicting changes to %v", string(c.ID), c.Field)
+}
+
+// Merge combines the changes made to base in ours with the changes made to
+// base in theirs.
+//
+// Each of base, ours, and theirs is a set of notes, where a note missing from
+// a set is the same as an empty note. The result applies to ours: patching a
+// note map that matches ours with the returned operations makes it include
+// the changes from theirs too.
+//
+// Changes are merged field by field. Edits to a value, content, types, or
+// subject identifiers are merged when they change different parts of the
+// field, and roles are merged as sets. Otherwise, and also where both sides
+// insert into the same position or insert the same note into different
+// positions, the field conflicts: the merged note keeps our version of the
+// field and the conflict is reported.
+func Merge(base, ours, theirs []TruncatedNote) ([]Operation, []MergeConflict, error) {
+	var (
+		ids  []ID
+		seen = make(map[ID]bool)
+		sets [3]map[ID]TruncatedNote
+	)
+	for i, ns := range [][]TruncatedNote{ours, theirs, base} {
+		sets[i] = make(map[ID]TruncatedNote, len(ns))
+		for _, n := range ns {
+			if n.ID.Empty() {
+				return nil, nil, InvalidID
+			}
+			if _, dup := sets[i][n.ID]; dup {
+				return nil, nil, noteError(n.ID,
+					fmt.Errorf("appears more than once in a set: %w", InvalidID))
+			}
+			sets[i][n.ID] = n
+			if !seen[n.ID] {
+				seen[n.ID] = true
+				ids = append(ids, n.ID)
+			}
+		}
+	}
+	var (
+		ops       OperationSlice
+		conflicts []MergeConflict
+	)
+	for _, id := range ids {
+		get := func(set map[ID]TruncatedNote) TruncatedNote {
+			if n, ok := set[id]; ok {
+				return n
+			}
+			return TruncatedNote{ID: id}
+		}
+		nops, ncs, err := mergeNote(get(sets[2]), get(sets[0]), get(sets[1]))
+		if err != nil {
+			return nil, nil, err
+		}
+		ops = append(ops, nops...)
+		conflicts = append(conflicts, ncs...)
+	}
+	return ops, conflicts, nil
+}
+
+// mergeNote returns operations that apply the changes from b to t onto o.
+func mergeNote(b, o, t TruncatedNote) (OperationSlice, []MergeConflict, error) {
+	switch {
+	case o.Equals(t), b.Equals(t):
+		return nil, nil, nil
+	case b.Equals(o):
+		return Diff(o, t), nil, nil
+	}
+	var (
+		ops       OperationSlice
+		conflicts []MergeConflict
+		conflict  = func(field string) {
+			conflicts = append(conflicts,
+				MergeConflict{ID: o.ID, Field: field, Base: b, Ours: o, Theirs: t})
+		}
+	)
+
+	vt, vtok := mergeID(b.ValueType, o.ValueType, t.ValueType)
+	vd, vsok, err := runes.StringMerge([]rune(b.ValueString), []rune(o.ValueString), []rune(t.ValueString))
+	if err != nil {
+		return nil, nil, noteError(o.ID, fmt.Errorf("value: %w", err))
+	}
+	switch {
+	case !vtok || !vsok:
+		conflict("value")
+	case vt != o.ValueType:
+		ops = ops.SetValue(o.ID, string(runes.String(o.ValueString).Apply(vd)), vt)
+	default:
+		ops = ops.PatchValue(o.ID, vd)
+	}
+
+	cd, ok, err := mergeIDs(b.Contents, o.Contents, t.Contents)
+	if err != nil {
+		return nil, nil, noteError(o.ID, fmt.Errorf("content: %w", err))
+	} else if !ok {
+		conflict("content")
+	}
+	ops = ops.PatchContent(o.ID, cd)
+
+	td, ok, err := mergeIDs(b.Types, o.Types, t.Types)
+	if err != nil {
+		return nil, nil, noteError(o.ID, fmt.Errorf("types: %w", err))
+	} else if !ok {
+		conflict("types")
+	}
+	ops = ops.PatchTypes(o.ID, td)
+
+	var add, remove []Role
+	for _, r := range t.Roles {
+		if !RoleSlice(b.Roles).Has(r) && !RoleSlice(o.Roles).Has(r) {
+			add = append(add, r)
+		}
+	}
+	for _, r := range b.Roles {
+		if RoleSlice(o.Roles).Has(r) && !RoleSlice(t.Roles).Has(r) {
+			remove = append(remove, r)
+		}
+	}
+	ops = ops.PatchRoles(o.ID, add, remove)
+
+	sd, ok, err := strs.StringsMerge(b.SubjectIdentifiers, o.SubjectIdentifiers, t.SubjectIdentifiers)
+	if err != nil {
+		return nil, nil, noteError(o.ID, fmt.Errorf("subject identifiers: %w", err))
+	} else if !ok {
+		conflict("subject identifiers")
+	}
+	ops = ops.PatchSubjectIdentifiers(o.ID, sd)
+
+	return ops, conflicts, nil
+}
+
+// mergeID returns the result of a three-way merge of a single ID, and false
+// if o and t both changed b in different ways.
+func mergeID(b, o, t ID) (ID, bool) {
+	switch {
+	case o == t, t == b:
+		return o, true
+	case o == b:
+		return t, true
+	}
+	return o, false
+}
+
+// mergeIDs returns a delta that applies the changes from b to t onto o, or
+// false if they cannot be merged.
+//
+// Besides the conflicts found by IDSliceMerge, changes conflict where the
+// merged slice would include an ID more often than both o and t do.
+func mergeIDs(b, o, t []ID) (IDSliceDelta, bool, error) {
+	r, ok, err := IDSliceMerge(b, o, t)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	merged := IDSlice(o).Apply(r)
+	count := make(map[ID]int, len(merged))
+	for _, id := range merged {
+		count[id]++
+		if count[id] > countID(o, id) && count[id] > countID(t, id) {
+			return nil, false, nil
+		}
+	}
+	return r, true, nil
+}
+
+func countID(ids []ID, id ID) int {
+	n := 0
+	for _, x := range ids {
+		if x == id {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,165 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	for _, test := range []struct {
+		Title              string
+		Base, Ours, Theirs TruncatedNote
+		Expect             TruncatedNote
+		Conflicts          []string
+	}{
+		{
+			Title:  "only theirs changed",
+			Base:   TruncatedNote{ID: "n0", ValueString: "hello"},
+			Ours:   TruncatedNote{ID: "n0", ValueString: "hello"},
+			Theirs: TruncatedNote{ID: "n0", ValueString: "goodbye", Contents: []ID{"a"}},
+			Expect: TruncatedNote{ID: "n0", ValueString: "goodbye", Contents: []ID{"a"}},
+		},
+		{
+			Title:  "only ours changed",
+			Base:   TruncatedNote{ID: "n0", ValueString: "hello"},
+			Ours:   TruncatedNote{ID: "n0", ValueString: "goodbye"},
+			Theirs: TruncatedNote{ID: "n0", ValueString: "hello"},
+			Expect: TruncatedNote{ID: "n0", ValueString: "goodbye"},
+		},
+		{
+			Title:  "separate value edits",
+			Base:   TruncatedNote{ID: "n0", ValueString: "hello world"},
+			Ours:   TruncatedNote{ID: "n0", ValueString: "Hello world"},
+			Theirs: TruncatedNote{ID: "n0", ValueString: "hello world!", ValueType: TextPlain},
+			Expect: TruncatedNote{ID: "n0", ValueString: "Hello world!", ValueType: TextPlain},
+		},
+		{
+			Title:     "overlapping value edits",
+			Base:      TruncatedNote{ID: "n0", ValueString: "cat", Types: []ID{"t0"}},
+			Ours:      TruncatedNote{ID: "n0", ValueString: "dog", Types: []ID{"t0"}},
+			Theirs:    TruncatedNote{ID: "n0", ValueString: "cow", Types: []ID{"t0", "t1"}},
+			Expect:    TruncatedNote{ID: "n0", ValueString: "dog", Types: []ID{"t0", "t1"}},
+			Conflicts: []string{"value"},
+		},
+		{
+			Title:     "conflicting value types",
+			Base:      TruncatedNote{ID: "n0", ValueString: "x"},
+			Ours:      TruncatedNote{ID: "n0", ValueString: "x", ValueType: TextPlain},
+			Theirs:    TruncatedNote{ID: "n0", ValueString: "x", ValueType: TextISO8601},
+			Expect:    TruncatedNote{ID: "n0", ValueString: "x", ValueType: TextPlain},
+			Conflicts: []string{"value"},
+		},
+		{
+			Title:  "separate content edits",
+			Base:   TruncatedNote{ID: "n0", ValueString: "v", Contents: []ID{"a", "b", "c"}},
+			Ours:   TruncatedNote{ID: "n0", ValueString: "v2", Contents: []ID{"a", "b", "c", "d"}},
+			Theirs: TruncatedNote{ID: "n0", ValueString: "v", Contents: []ID{"x", "a", "c"}},
+			Expect: TruncatedNote{ID: "n0", ValueString: "v2", Contents: []ID{"x", "a", "c", "d"}},
+		},
+		{
+			Title:     "insertions at the same position",
+			Base:      TruncatedNote{ID: "n0", Contents: []ID{"a", "b"}},
+			Ours:      TruncatedNote{ID: "n0", Contents: []ID{"a", "b", "c"}},
+			Theirs:    TruncatedNote{ID: "n0", Contents: []ID{"a", "b", "d"}},
+			Expect:    TruncatedNote{ID: "n0", Contents: []ID{"a", "b", "c"}},
+			Conflicts: []string{"content"},
+		},
+		{
+			Title:     "same note moved to different positions",
+			Base:      TruncatedNote{ID: "n0", Contents: []ID{"a", "b", "c"}},
+			Ours:      TruncatedNote{ID: "n0", Contents: []ID{"c", "a", "b"}},
+			Theirs:    TruncatedNote{ID: "n0", Contents: []ID{"a", "c", "b"}},
+			Expect:    TruncatedNote{ID: "n0", Contents: []ID{"c", "a", "b"}},
+			Conflicts: []string{"content"},
+		},
+		{
+			Title:     "same note inserted at different positions",
+			Base:      TruncatedNote{ID: "n0", Contents: []ID{"a", "b", "c"}},
+			Ours:      TruncatedNote{ID: "n0", Contents: []ID{"z", "a", "b", "c"}},
+			Theirs:    TruncatedNote{ID: "n0", Contents: []ID{"a", "b", "c", "z"}},
+			Expect:    TruncatedNote{ID: "n0", Contents: []ID{"z", "a", "b", "c"}},
+			Conflicts: []string{"content"},
+		},
+		{
+			Title:  "roles and subject identifiers",
+			Base:   TruncatedNote{ID: "n0", Roles: []Role{{"r0", "p0"}, {"r1", "p1"}}, SubjectIdentifiers: []string{"si0", "si1"}},
+			Ours:   TruncatedNote{ID: "n0", Roles: []Role{{"r0", "p0"}, {"r1", "p1"}, {"r2", "p2"}}, SubjectIdentifiers: []string{"si0"}},
+			Theirs: TruncatedNote{ID: "n0", Roles: []Role{{"r0", "p0"}, {"r3", "p3"}}, SubjectIdentifiers: []string{"si", "si0", "si1"}},
+			Expect: TruncatedNote{ID: "n0", Roles: []Role{{"r0", "p0"}, {"r2", "p2"}, {"r3", "p3"}}, SubjectIdentifiers: []string{"si", "si0"}},
+		},
+	} {
+		t.Run(test.Title, func(t *testing.T) {
+			ops, conflicts, err := Merge(
+				[]TruncatedNote{test.Base}, []TruncatedNote{test.Ours}, []TruncatedNote{test.Theirs})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fields []string
+			for _, c := range conflicts {
+				if c.ID != "n0" || !c.Base.Equals(test.Base) || !c.Ours.Equals(test.Ours) || !c.Theirs.Equals(test.Theirs) {
+					t.Errorf("unexpected conflict %#v", c)
+				}
+				fields = append(fields, c.Field)
+			}
+			if !reflect.DeepEqual(fields, test.Conflicts) {
+				t.Errorf("got conflicts %#v, expected %#v", fields, test.Conflicts)
+			}
+			got := test.Ours
+			if err := Patch(&got, ops); err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equals(test.Expect) {
+				t.Errorf("got %#v, expected %#v", got, test.Expect)
+			}
+		})
+	}
+}
+
+func TestMerge_sets(t *testing.T) {
+	base := []TruncatedNote{{ID: "n0", ValueString: "zero"}, {ID: "n1", ValueString: "one"}}
+	ours := []TruncatedNote{{ID: "n0", ValueString: "zero!"}}
+	theirs := []TruncatedNote{
+		{ID: "n0", ValueString: "zero"},
+		{ID: "n1", ValueString: "one"},
+		{ID: "n2", ValueString: "two"},
+	}
+	ops, conflicts, err := Merge(base, ours, theirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("unexpected conflicts %v", conflicts)
+	}
+	m := mapFindLoader{}
+	for _, n := range ours {
+		m[n.ID] = n
+	}
+	if err := m.Patch(ops); err != nil {
+		t.Fatal(err)
+	}
+	for id, expect := range map[ID]string{"n0": "zero!", "n1": "", "n2": "two"} {
+		if m[id].ValueString != expect {
+			t.Errorf("%v: got %#v, expected %#v", id, m[id].ValueString, expect)
+		}
+	}
+
+	_, _, err = Merge(nil, []TruncatedNote{{ID: "n0"}, {ID: "n0"}}, nil)
+	if !errors.Is(err, InvalidID) {
+		t.Errorf("got %v, expected %v", err, InvalidID)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// Name is the type of notes whose values are names of the note that
+	// contains them.
+	Name ID = "org.note-maps.name"
+
+	// LocalePrefix is the prefix of the IDs of types that give the locale of
+	// a name, as in "org.note-maps.locale.en" or
+	// "org.note-maps.locale.pt-BR".
+	LocalePrefix = "org.note-maps.locale."
+)
+
+// builtinNames are the names of built-in notes, by locale.
+var builtinNames = map[string]map[ID]string{
+	"en": {
+		Name:        "name",
+		TextPlain:   "text",
+		TextISO8601: "date",
+	},
+}
+
+// DisplayName returns the name by which n should be displayed when nothing
+// else about it is shown, as described by requirement UR1.
+//
+// The result is the value of one of the names in the content of n, preferring
+// names in the first of locales that matches any name, then names with no
+// locale, then any other name. Locales are BCP 47 tags like "en" or "en-US";
+// a name in "en" matches a preference for "en-US" if there is no name in
+// "en-US". Only names that are usable as identifiers are considered: they must
+// be non-empty, fit on one line, and have no leading or trailing space.
+//
+// If n has no such name, DisplayName returns the built-in name of n if it is
+// one of the notes that has one, and otherwise returns the ID of n with ok set
+// to false.
+func DisplayName(n GraphNote, locales ...string) (name string, ok bool, err error) {
+	cs, err := n.GetContents()
+	if err != nil {
+		return "", false, err
+	}
+	var (
+		best     string
+		bestRank = -1
+	)
+	for _, c := range cs {
+		value, locale, isName, err := nameOf(c)
+		if err != nil {
+			return "", false, err
+		}
+		if !isName || !ValidName(value) {
+			continue
+		}
+		if rank := localeRank(locale, locales); rank > bestRank {
+			best, bestRank = value, rank
+		}
+	}
+	if bestRank >= 0 {
+		return best, true, nil
+	}
+	if name, ok := builtinName(n.GetID(), locales); ok {
+		return name, true, nil
+	}
+	return n.GetID().String(), false, nil
+}
+
+// ValidName returns true if value can be used as the display name of a note.
+func ValidName(value string) bool {
+	return value != "" &&
+		strings.TrimSpace(value) == value &&
+		strings.IndexFunc(value, func(r rune) bool {
+			return r == '\n' || r == '\r' || !unicode.IsPrint(r) && r != ' '
+		}) < 0
+}
+
+// nameOf returns the value and locale of c if it is a name.
+func nameOf(c GraphNote) (value, locale string, isName bool, err error) {
+	ts, err := c.GetTypes()
+	if err != nil {
+		return "", "", false, err
+	}
+	for _, t := range ts {
+		id := t.GetID()
+		switch {
+		case id == Name:
+			isName = true
+		case locale == "" && strings.HasPrefix(string(id), LocalePrefix):
+			locale = string(id)[len(LocalePrefix):]
+		}
+	}
+	if !isName {
+		return "", "", false, nil
+	}
+	value, _, err = c.GetValue()
+	return value, locale, true, err
+}
+
+// localeRank ranks a name in locale against the preferred locales, with
+// higher ranks for better matches.
+func localeRank(locale string, locales []string) int {
+	if locale == "" {
+		return 1
+	}
+	for i, pref := range locales {
+		switch {
+		case strings.EqualFold(locale, pref):
+			return 3 + 2*(len(locales)-i)
+		case strings.EqualFold(locale, baseLanguage(pref)):
+			return 2 + 2*(len(locales)-i)
+		}
+	}
+	return 0
+}
+
+func builtinName(id ID, locales []string) (string, bool) {
+	for _, l := range locales {
+		for _, tag := range []string{l, baseLanguage(l)} {
+			if name, ok := builtinNames[strings.ToLower(tag)][id]; ok {
+				return name, true
+			}
+		}
+	}
+	name, ok := builtinNames["en"][id]
+	return name, ok
+}
+
+// baseLanguage returns the language subtag of a BCP 47 tag, as in "en" for
+// "en-US".
+func baseLanguage(tag string) string {
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		return tag[:i]
+	}
+	return tag
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import "testing"
+
+func TestDisplayName(t *testing.T) {
+	m := mapFindLoader{
+		"n0": {ID: "n0", Contents: []ID{"name0", "name1", "name2", "text0"}},
+		"name0": {ID: "name0", ValueString: "Colour",
+			Types: []ID{Name, LocalePrefix + "en-GB"}},
+		"name1": {ID: "name1", ValueString: "Color",
+			Types: []ID{Name, LocalePrefix + "en"}},
+		"name2": {ID: "name2", ValueString: "Couleur",
+			Types: []ID{Name, LocalePrefix + "fr"}},
+		"text0": {ID: "text0", ValueString: "Not a name"},
+		"n1":    {ID: "n1", Contents: []ID{"bad0", "bad1", "name3"}},
+		"bad0":  {ID: "bad0", ValueString: "two\nlines", Types: []ID{Name}},
+		"bad1":  {ID: "bad1", ValueString: " padded ", Types: []ID{Name}},
+		"name3": {ID: "name3", ValueString: "Plain", Types: []ID{Name}},
+		"n2":    {ID: "n2", Contents: []ID{"bad0", "text0"}},
+	}
+	for _, test := range []struct {
+		ID      ID
+		Locales []string
+		Name    string
+		OK      bool
+	}{
+		{"n0", nil, "Colour", true},
+		{"n0", []string{"en-US"}, "Color", true},
+		{"n0", []string{"en-GB"}, "Colour", true},
+		{"n0", []string{"fr-CA", "en"}, "Couleur", true},
+		{"n0", []string{"de", "en"}, "Color", true},
+		{"n1", []string{"en"}, "Plain", true},
+		{"n2", nil, "n2", false},
+		{Name, []string{"fr"}, "name", true},
+		{TextPlain, nil, "text", true},
+		{TextISO8601, []string{"en_US"}, "date", true},
+	} {
+		ns, err := m.Load([]ID{test.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		name, ok, err := DisplayName(ns[0], test.Locales...)
+		if err != nil {
+			t.Error(err)
+		} else if name != test.Name || ok != test.OK {
+			t.Errorf("%v in %v: got %#v, %v; expected %#v, %v",
+				test.ID, test.Locales, name, ok, test.Name, test.OK)
+		}
+	}
+}
+
+func TestValidName(t *testing.T) {
+	for _, test := range []struct {
+		Value  string
+		Expect bool
+	}{
+		{"name", true},
+		{"two words", true},
+		{"", false},
+		{" leading", false},
+		{"trailing\t", false},
+		{"two\nlines", false},
+		{"tab\tinside", false},
+	} {
+		if got := ValidName(test.Value); got != test.Expect {
+			t.Errorf("%#v: got %v, expected %v", test.Value, got, test.Expect)
+		}
+	}
+}
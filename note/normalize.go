@@ -0,0 +1,223 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/google/note-maps/otgen/strs"
+)
+
+// Normalize returns the operations that would normalize every note found in
+// fl, as described in docs/data-model.md:
+//
+//   - line breaks in values are replaced with spaces,
+//   - empty IDs and subject identifiers are removed,
+//   - the ID of every association is added to the content of its players, and
+//   - content cycles are broken by removing one edge from each cycle.
+//
+// When an edge must be removed to break a cycle, edges that are required by
+// associations are kept if possible. If every edge in a cycle is required,
+// the role that requires the removed edge is removed too.
+//
+// A note map is normalized if and only if the result is empty, so Normalize
+// can be used as a validator. The result can also be passed to the Patch
+// method of the same FindLoadPatcher within IsolatedWrite to fix the note map.
+func Normalize(fl FindLoader) ([]Operation, error) {
+	ns, err := fl.Find(&Query{})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]ID, len(ns))
+	for i, n := range ns {
+		ids[i] = n.GetID()
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	stage := Stage{Base: fl}
+	for _, id := range ids {
+		if err := normalizeNote(stage.Note(id)); err != nil {
+			return nil, err
+		}
+	}
+	for {
+		cycle, err := findContentCycle(&stage, ids)
+		if err != nil {
+			return nil, err
+		} else if cycle == nil {
+			break
+		}
+		parent, child, err := chooseEdge(&stage, cycle)
+		if err != nil {
+			return nil, err
+		}
+		if err := breakEdge(&stage, parent, child); err != nil {
+			return nil, err
+		}
+	}
+	return stage.Ops, nil
+}
+
+var lineBreaks = strings.NewReplacer(
+	"\r\n", " ",
+	"\n", " ",
+	"\r", " ",
+	"\v", " ",
+	"\f", " ",
+	"\u0085", " ",
+	"\u2028", " ",
+	"\u2029", " ",
+)
+
+// normalizeNote stages operations that normalize the fields of x and add x to
+// the content of the players of its roles.
+func normalizeNote(x *StageNote) error {
+	lex, _, err := x.GetValue()
+	if err != nil {
+		return err
+	}
+	if fixed := lineBreaks.Replace(lex); fixed != lex {
+		x.Stage.Ops = x.Stage.Ops.SetValueString(x.ID, fixed)
+	}
+	cids, err := x.GetContentIDs()
+	if err != nil {
+		return err
+	}
+	if containsAny(cids, []ID{EmptyID}) {
+		x.Stage.Ops = x.Stage.Ops.PatchContent(x.ID, IDSlice(cids).DeleteElements(EmptyID))
+	}
+	tids, err := x.GetTypeIDs()
+	if err != nil {
+		return err
+	}
+	if containsAny(tids, []ID{EmptyID}) {
+		x.Stage.Ops = x.Stage.Ops.PatchTypes(x.ID, IDSlice(tids).DeleteElements(EmptyID))
+	}
+	sis, err := x.GetSubjectIdentifiers()
+	if err != nil {
+		return err
+	}
+	for _, si := range sis {
+		if si == "" {
+			x.Stage.Ops = x.Stage.Ops.PatchSubjectIdentifiers(x.ID, strs.Strings(sis).DeleteElements(""))
+			break
+		}
+	}
+	rs, err := x.GetRoles()
+	if err != nil {
+		return err
+	}
+	for _, r := range rs {
+		if r.Player.Empty() {
+			x.Stage.Ops = x.Stage.Ops.PatchRoles(x.ID, nil, []Role{r})
+			continue
+		}
+		pcids, err := x.Note(r.Player).GetContentIDs()
+		if err != nil {
+			return err
+		}
+		if !containsAny(pcids, []ID{x.ID}) {
+			x.Stage.Ops = x.Stage.Ops.PatchContent(r.Player, IDSlice(pcids).Append(x.ID))
+		}
+	}
+	return nil
+}
+
+// findContentCycle searches the content graph reachable from ids for a cycle.
+//
+// If a cycle is found, the result is the path of IDs along it, beginning and
+// ending with the same ID. Otherwise the result is nil.
+func findContentCycle(stage *Stage, ids []ID) ([]ID, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[ID]int)
+	var path []ID
+	var visit func(id ID) ([]ID, error)
+	visit = func(id ID) ([]ID, error) {
+		switch state[id] {
+		case visiting:
+			for i := range path {
+				if path[i] == id {
+					return append(append([]ID{}, path[i:]...), id), nil
+				}
+			}
+		case visited:
+			return nil, nil
+		}
+		state[id] = visiting
+		path = append(path, id)
+		cids, err := stage.Note(id).GetContentIDs()
+		if err != nil {
+			return nil, err
+		}
+		for _, cid := range cids {
+			if cycle, err := visit(cid); cycle != nil || err != nil {
+				return cycle, err
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = visited
+		return nil, nil
+	}
+	for _, id := range ids {
+		if cycle, err := visit(id); cycle != nil || err != nil {
+			return cycle, err
+		}
+	}
+	return nil, nil
+}
+
+// chooseEdge returns the first edge along cycle that is not required by an
+// association, or the last edge if they are all required.
+func chooseEdge(stage *Stage, cycle []ID) (parent, child ID, err error) {
+	for i := 1; i < len(cycle); i++ {
+		parent, child = cycle[i-1], cycle[i]
+		rs, err := stage.Note(child).GetRoles()
+		if err != nil {
+			return "", "", err
+		}
+		if !RoleSlice(rs).HasPlayer(parent) {
+			return parent, child, nil
+		}
+	}
+	return parent, child, nil
+}
+
+// breakEdge stages operations that remove child from the content of parent,
+// and remove any roles of child played by parent so that the edge is not
+// required by an association.
+func breakEdge(stage *Stage, parent, child ID) error {
+	cids, err := stage.Note(parent).GetContentIDs()
+	if err != nil {
+		return err
+	}
+	stage.Ops = stage.Ops.PatchContent(parent, IDSlice(cids).DeleteElements(child))
+	rs, err := stage.Note(child).GetRoles()
+	if err != nil {
+		return err
+	}
+	var remove []Role
+	for _, r := range rs {
+		if r.Player == parent {
+			remove = append(remove, r)
+		}
+	}
+	if len(remove) > 0 {
+		stage.Ops = stage.Ops.PatchRoles(child, nil, remove)
+	}
+	return nil
+}
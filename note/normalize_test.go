@@ -0,0 +1,186 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// mapFindLoader is a minimal in-memory FindLoadPatcher for testing.
+type mapFindLoader map[ID]TruncatedNote
+
+func (m mapFindLoader) Load(ids []ID) ([]GraphNote, error) {
+	ns := make([]GraphNote, len(ids))
+	for i, id := range ids {
+		tn, ok := m[id]
+		if !ok {
+			tn.ID = id
+		}
+		ns[i] = ExpandNote(tn, m)
+	}
+	return ns, nil
+}
+
+func (m mapFindLoader) Find(q *Query) ([]GraphNote, error) {
+	var ids []ID
+	for id, tn := range m {
+		if q.Match(tn) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return m.Load(q.Page(ids))
+}
+
+func (m mapFindLoader) FindBySubjectIdentifier(si string) ([]GraphNote, error) {
+	return m.Find(&Query{SubjectIdentifier: si})
+}
+
+// LoadParentIDs returns parents in order of their IDs.
+func (m mapFindLoader) LoadParentIDs(ids []ID) ([][]ID, error) {
+	parents := make([][]ID, len(ids))
+	for i, id := range ids {
+		for pid, tn := range m {
+			if containsAny(tn.Contents, []ID{id}) {
+				parents[i] = append(parents[i], pid)
+			}
+		}
+		sort.Slice(parents[i], func(a, b int) bool { return parents[i][a] < parents[i][b] })
+	}
+	return parents, nil
+}
+
+func (m mapFindLoader) Patch(ops []Operation) error {
+	for _, op := range ops {
+		id := op.(interface{ GetID() ID }).GetID()
+		tn, ok := m[id]
+		if !ok {
+			tn.ID = id
+		}
+		if err := Patch(&tn, []Operation{op}); err != nil {
+			return err
+		}
+		m[id] = tn
+	}
+	return nil
+}
+
+// testNoteMap returns a new copy of the small note map shared by the tests in
+// this package that only need a note with some of everything.
+func testNoteMap() mapFindLoader {
+	return mapFindLoader{
+		"n0": {
+			ID:          "n0",
+			ValueString: "hello",
+			ValueType:   "vt0",
+			Contents:    []ID{"n1"},
+			Types:       []ID{"t0"},
+
+			SubjectIdentifiers: []string{"si0"},
+		},
+		"n1": {ID: "n1", ValueString: "world", Roles: []Role{{"r0", "n0"}}},
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	m := mapFindLoader{
+		"text": {ID: "text", ValueString: "one\r\ntwo\nthree four", ValueType: "vt"},
+		"empties": {
+			ID:                 "empties",
+			Contents:           []ID{"text", EmptyID},
+			Types:              []ID{EmptyID, "t0"},
+			SubjectIdentifiers: []string{"", "si0"},
+		},
+		"assoc": {ID: "assoc", Roles: []Role{{"r0", "p0"}}, Contents: []ID{"p0"}},
+		"p0":    {ID: "p0", Contents: []ID{"c0"}},
+		"c0":    {ID: "c0", Contents: []ID{"c1"}},
+		"c1":    {ID: "c1", Contents: []ID{"c0"}},
+	}
+	ops, err := Normalize(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) == 0 {
+		t.Fatal("expected operations to normalize the note map")
+	}
+	if err := m.Patch(ops); err != nil {
+		t.Fatal(err)
+	}
+	for _, expect := range []TruncatedNote{
+		{ID: "text", ValueString: "one two three four", ValueType: "vt"},
+		{
+			ID:                 "empties",
+			Contents:           []ID{"text"},
+			Types:              []ID{"t0"},
+			SubjectIdentifiers: []string{"si0"},
+		},
+		{ID: "assoc", Roles: []Role{{"r0", "p0"}}},
+		{ID: "p0", Contents: []ID{"c0", "assoc"}},
+		{ID: "c0"},
+		{ID: "c1", Contents: []ID{"c0"}},
+	} {
+		if got := m[expect.ID]; !got.Equals(expect) {
+			t.Errorf("got %#v, expected %#v", got, expect)
+		}
+	}
+	if ops, err := Normalize(m); err != nil {
+		t.Error(err)
+	} else if len(ops) != 0 {
+		t.Errorf("got %v, expected no operations for a normalized note map", ops)
+	}
+}
+
+func TestNormalize_validNoteMap(t *testing.T) {
+	m := mapFindLoader{
+		"assoc": {ID: "assoc", Roles: []Role{{"r0", "p0"}}},
+		"p0":    {ID: "p0", ValueString: "hello", Contents: []ID{"assoc"}},
+	}
+	ops, err := Normalize(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ops, []Operation(nil)) {
+		t.Errorf("got %v, expected no operations", ops)
+	}
+}
+
+func TestNormalize_requiredCycle(t *testing.T) {
+	m := mapFindLoader{
+		"a0": {ID: "a0", Roles: []Role{{"r0", "a1"}}, Contents: []ID{"a1"}},
+		"a1": {ID: "a1", Roles: []Role{{"r0", "a0"}}, Contents: []ID{"a0"}},
+	}
+	ops, err := Normalize(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Patch(ops); err != nil {
+		t.Fatal(err)
+	}
+	for _, expect := range []TruncatedNote{
+		{ID: "a0", Contents: []ID{"a1"}},
+		{ID: "a1", Roles: []Role{{"r0", "a0"}}},
+	} {
+		if got := m[expect.ID]; !got.Equals(expect) {
+			t.Errorf("got %#v, expected %#v", got, expect)
+		}
+	}
+	if ops, err := Normalize(m); err != nil {
+		t.Error(err)
+	} else if len(ops) != 0 {
+		t.Errorf("got %v, expected no operations after normalizing twice", ops)
+	}
+}
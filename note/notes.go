@@ -17,7 +17,11 @@
 package note
 
 import (
+	"fmt"
 	"io"
+
+	"github.com/google/note-maps/otgen/runes"
+	"github.com/google/note-maps/otgen/strs"
 )
 
 // TruncatedNote is a minimal representation of a note intended for storage
@@ -29,6 +33,9 @@ type TruncatedNote struct {
 	ValueType   ID
 	Contents    []ID
 	Types       []ID
+	Roles       []Role
+
+	SubjectIdentifiers []string
 }
 
 // TruncateNote returns a TruncatedNote representation of n.
@@ -37,10 +44,6 @@ func TruncateNote(n GraphNote) (TruncatedNote, error) {
 	if err != nil {
 		return TruncatedNote{}, err
 	}
-	var vtid ID
-	if vt != nil {
-		vtid = vt.GetID()
-	}
 	cs, err := n.GetContents()
 	if err != nil {
 		return TruncatedNote{}, err
@@ -57,12 +60,22 @@ func TruncateNote(n GraphNote) (TruncatedNote, error) {
 	for i, t := range ts {
 		tids[i] = t.GetID()
 	}
+	rs, err := n.GetRoles()
+	if err != nil {
+		return TruncatedNote{}, err
+	}
+	sis, err := n.GetSubjectIdentifiers()
+	if err != nil {
+		return TruncatedNote{}, err
+	}
 	return TruncatedNote{
-		ID:          n.GetID(),
-		ValueString: vs,
-		ValueType:   vtid,
-		Contents:    cids,
-		Types:       tids,
+		ID:                 n.GetID(),
+		ValueString:        vs,
+		ValueType:          idOf(vt),
+		Contents:           cids,
+		Types:              tids,
+		Roles:              rs,
+		SubjectIdentifiers: sis,
 	}, nil
 }
 
@@ -72,7 +85,18 @@ func (x TruncatedNote) Equals(y TruncatedNote) bool {
 		x.ValueString == y.ValueString && x.ValueType == y.ValueType &&
 		len(x.Contents) == len(y.Contents) && len(x.Types) == len(y.Types) &&
 		IDSlice(x.Contents).PrefixMatch(y.Contents) == len(x.Contents) &&
-		IDSlice(x.Types).PrefixMatch(y.Types) == len(x.Types)
+		IDSlice(x.Types).PrefixMatch(y.Types) == len(x.Types) &&
+		RoleSlice(x.Roles).Equals(y.Roles) &&
+		len(x.SubjectIdentifiers) == len(y.SubjectIdentifiers) &&
+		strs.Strings(x.SubjectIdentifiers).PrefixMatch(y.SubjectIdentifiers) == len(x.SubjectIdentifiers)
+}
+
+// Empty returns true if and only if every field of x other than its ID is
+// empty, which means the note is effectively deleted.
+func (x TruncatedNote) Empty() bool {
+	return x.ValueString == "" && x.ValueType.Empty() &&
+		len(x.Contents) == 0 && len(x.Types) == 0 && len(x.Roles) == 0 &&
+		len(x.SubjectIdentifiers) == 0
 }
 
 // Diff produces a set of operations that if applied to a would make it match
@@ -86,10 +110,15 @@ func Diff(a, b TruncatedNote) []Operation {
 	if a.ValueType != b.ValueType {
 		ops = ops.SetValue(a.ID, b.ValueString, b.ValueType)
 	} else if a.ValueString != b.ValueString {
-		ops = ops.SetValueString(a.ID, b.ValueString)
+		ops = ops.PatchValue(a.ID,
+			runes.StringDiff([]rune(a.ValueString), []rune(b.ValueString)))
 	}
 	ops = ops.PatchContent(a.ID, IDSliceDiff(a.Contents, b.Contents))
 	ops = ops.PatchTypes(a.ID, IDSliceDiff(a.Types, b.Types))
+	add, remove := RoleSliceDiff(a.Roles, b.Roles)
+	ops = ops.PatchRoles(a.ID, add, remove)
+	ops = ops.PatchSubjectIdentifiers(a.ID,
+		strs.StringsDiff(a.SubjectIdentifiers, b.SubjectIdentifiers))
 	return ops
 }
 
@@ -105,10 +134,31 @@ func Patch(a *TruncatedNote, ops []Operation) error {
 			a.ValueType = o.Datatype
 		case OpSetValueString:
 			a.ValueString = o.Lexical
+		case OpValueDelta:
+			rs := runes.String(a.ValueString)
+			if !rs.CanApply(o.StringOps) {
+				return noteError(a.ID, fmt.Errorf("value: %w", ConflictingDelta))
+			}
+			a.ValueString = string(rs.Apply(o.StringOps))
 		case OpContentDelta:
+			if !IDSlice(a.Contents).CanApply(o.IDSliceOps) {
+				return noteError(a.ID, fmt.Errorf("content: %w", ConflictingDelta))
+			}
 			a.Contents = IDSlice(a.Contents).Apply(o.IDSliceOps)
 		case OpTypesDelta:
+			if !IDSlice(a.Types).CanApply(o.IDSliceOps) {
+				return noteError(a.ID, fmt.Errorf("types: %w", ConflictingDelta))
+			}
 			a.Types = IDSlice(a.Types).Apply(o.IDSliceOps)
+		case OpRolesDelta:
+			a.Roles = RoleSlice(a.Roles).Apply(o.Add, o.Remove)
+		case OpSubjectIdentifiersDelta:
+			if !strs.Strings(a.SubjectIdentifiers).CanApply(o.StringsOps) {
+				return noteError(a.ID, fmt.Errorf("subject identifiers: %w", ConflictingDelta))
+			}
+			a.SubjectIdentifiers = strs.Strings(a.SubjectIdentifiers).Apply(o.StringsOps)
+		case OpClearNote:
+			*a = TruncatedNote{ID: a.ID}
 		}
 	}
 	return nil
@@ -124,6 +174,8 @@ type GraphNote interface {
 	GetValue() (string, GraphNote, error)
 	GetContents() ([]GraphNote, error)
 	GetTypes() ([]GraphNote, error)
+	GetRoles() ([]Role, error)
+	GetSubjectIdentifiers() ([]string, error)
 }
 
 // ExpandNote uses tn and l to provide a full GraphNote implementation.
@@ -144,17 +196,36 @@ func (n *loaderNote) GetValue() (string, GraphNote, error) {
 	vtype, err := LoadOne(n.l, n.ValueType)
 	return n.ValueString, vtype, err
 }
+func (n *loaderNote) GetValueTypeID() (ID, error) {
+	return n.ValueType, nil
+}
 func (n *loaderNote) GetContents() ([]GraphNote, error) {
 	return n.l.Load(n.Contents)
 }
+func (n *loaderNote) GetContentIDs() ([]ID, error) {
+	return n.Contents, nil
+}
 func (n *loaderNote) GetTypes() ([]GraphNote, error) {
 	return n.l.Load(n.Types)
 }
+func (n *loaderNote) GetTypeIDs() ([]ID, error) {
+	return n.Types, nil
+}
+func (n *loaderNote) GetRoles() ([]Role, error) {
+	return n.Roles, nil
+}
+func (n *loaderNote) GetSubjectIdentifiers() ([]string, error) {
+	return n.SubjectIdentifiers, nil
+}
 
 // Finder can be implemented to support finding notes in a note map according
 // to a query.
 type Finder interface {
 	Find(*Query) ([]GraphNote, error)
+
+	// FindBySubjectIdentifier returns all notes that have si as one of their
+	// subject identifiers.
+	FindBySubjectIdentifier(si string) ([]GraphNote, error)
 }
 
 // Loader can be implemented to support loading notes by id.
@@ -181,6 +252,14 @@ func LoadOne(l Loader, id ID) (GraphNote, error) {
 	return ns[0], nil
 }
 
+// ParentLoader can be implemented to find the parents of notes: the notes
+// that include them in their content.
+type ParentLoader interface {
+	// LoadParentIDs returns, for each of ids, the IDs of the notes that
+	// include it in their content.
+	LoadParentIDs(ids []ID) ([][]ID, error)
+}
+
 // Patcher can be implemented to support making changes to notes in a note map
 // by applying a set of differences to them.
 type Patcher interface {
@@ -211,6 +290,29 @@ type DatabaseWriter interface {
 	IsolatedWrite(f func(rw FindLoadPatcher) error) error
 }
 
+// Change describes a change that has been committed to a note map.
+type Change struct {
+	// IDs identifies the notes that were changed.
+	IDs []ID
+
+	// Ops is the sequence of operations that made the change, if it is known.
+	//
+	// Changes that arrive by other means, such as replication from another
+	// copy of the note map, may be described by IDs alone.
+	Ops []Operation
+}
+
+// DatabaseWatcher provides notification of changes to a note map.
+type DatabaseWatcher interface {
+	// Watch returns a channel that receives a Change after each successful
+	// IsolatedWrite, and after any change that arrives by other means.
+	//
+	// The channel is closed when stop is called or when the database is
+	// closed. Changes are queued for each channel, so a slow receiver does
+	// not block writers.
+	Watch() (changes <-chan Change, stop func(), err error)
+}
+
 // Database provides atomic isolated read and write operations over a note map.
 //
 // An instance of Database should be closed when it is no longer needed.
@@ -23,16 +23,20 @@ import (
 
 type nn struct {
 	ID
-	VS string
-	VT GraphNote
-	CS []GraphNote
-	TS []GraphNote
+	VS  string
+	VT  GraphNote
+	CS  []GraphNote
+	TS  []GraphNote
+	RS  []Role
+	SIS []string
 }
 
-func (n nn) GetID() ID                            { return n.ID }
-func (n nn) GetValue() (string, GraphNote, error) { return n.VS, n.VT, nil }
-func (n nn) GetContents() ([]GraphNote, error)    { return n.CS, nil }
-func (n nn) GetTypes() ([]GraphNote, error)       { return n.TS, nil }
+func (n nn) GetID() ID                                { return n.ID }
+func (n nn) GetValue() (string, GraphNote, error)     { return n.VS, n.VT, nil }
+func (n nn) GetContents() ([]GraphNote, error)        { return n.CS, nil }
+func (n nn) GetTypes() ([]GraphNote, error)           { return n.TS, nil }
+func (n nn) GetRoles() ([]Role, error)                { return n.RS, nil }
+func (n nn) GetSubjectIdentifiers() ([]string, error) { return n.SIS, nil }
 
 type brokenValue struct{ nn }
 
@@ -101,7 +105,7 @@ func TestTruncateNote_Equals(t *testing.T) {
 		{TruncatedNote{}, TruncatedNote{}, true},
 		{
 			TruncatedNote{},
-			TruncatedNote{"", "", "", []ID{}, []ID{}},
+			TruncatedNote{"", "", "", []ID{}, []ID{}, []Role{}, []string{}},
 			true,
 		},
 		{TruncatedNote{ID: "0"}, TruncatedNote{}, false},
@@ -136,6 +140,26 @@ func TestTruncateNote_Equals(t *testing.T) {
 			TruncatedNote{Contents: []ID{"x"}},
 			true,
 		},
+		{
+			TruncatedNote{SubjectIdentifiers: []string{"x"}},
+			TruncatedNote{SubjectIdentifiers: []string{"y"}},
+			false,
+		},
+		{
+			TruncatedNote{SubjectIdentifiers: []string{"x"}},
+			TruncatedNote{SubjectIdentifiers: []string{"x"}},
+			true,
+		},
+		{
+			TruncatedNote{Roles: []Role{{"r", "x"}}},
+			TruncatedNote{Roles: []Role{{"r", "y"}}},
+			false,
+		},
+		{
+			TruncatedNote{Roles: []Role{{"r", "x"}, {"r", "y"}}},
+			TruncatedNote{Roles: []Role{{"r", "y"}, {"r", "x"}}},
+			true,
+		},
 	} {
 		if test.A.Equals(test.B) != test.B.Equals(test.A) {
 			t.Errorf("A.Equals(B) != B.Equals(A) : %v != %v",
@@ -167,6 +191,9 @@ func TestDiffPatch(t *testing.T) {
 		{Title: "change value string",
 			A: TruncatedNote{ValueString: "a"},
 			B: TruncatedNote{ValueString: "b"}},
+		{Title: "edit value string",
+			A: TruncatedNote{ValueString: "hello world"},
+			B: TruncatedNote{ValueString: "hello, wide world"}},
 		{Title: "change value type",
 			A: TruncatedNote{ValueType: "vt0"},
 			B: TruncatedNote{ValueType: "vt1"}},
@@ -182,6 +209,15 @@ func TestDiffPatch(t *testing.T) {
 		{Title: "swap content",
 			A: TruncatedNote{Contents: []ID{"a", "b"}},
 			B: TruncatedNote{Contents: []ID{"b", "a"}}},
+		{Title: "insert subject identifier",
+			A: TruncatedNote{SubjectIdentifiers: []string{"a", "c"}},
+			B: TruncatedNote{SubjectIdentifiers: []string{"a", "b", "c"}}},
+		{Title: "add role",
+			A: TruncatedNote{Roles: []Role{{"r0", "p0"}}},
+			B: TruncatedNote{Roles: []Role{{"r0", "p0"}, {"r1", "p1"}}}},
+		{Title: "replace role",
+			A: TruncatedNote{Roles: []Role{{"r0", "p0"}, {"r1", "p1"}}},
+			B: TruncatedNote{Roles: []Role{{"r1", "p1"}, {"r0", "p2"}}}},
 	} {
 		t.Run(test.Title, func(t *testing.T) {
 			ops := Diff(test.A, test.B)
@@ -196,6 +232,28 @@ func TestDiffPatch(t *testing.T) {
 	}
 }
 
+func TestPatch_clear(t *testing.T) {
+	x := TruncatedNote{
+		ID:          "id0",
+		ValueString: "a",
+		ValueType:   "vt0",
+		Contents:    []ID{"c0"},
+		Types:       []ID{"t0"},
+		Roles:       []Role{{"r0", "p0"}},
+
+		SubjectIdentifiers: []string{"si0"},
+	}
+	if x.Empty() {
+		t.Error("expected a non-empty note")
+	}
+	if err := Patch(&x, OperationSlice{}.ClearNote("id0")); err != nil {
+		t.Fatal(err)
+	}
+	if !x.Empty() || x.ID != "id0" {
+		t.Errorf("got %#v, expected an empty note with the same ID", x)
+	}
+}
+
 func TestExpandNote(t *testing.T) {
 	n := ExpandNote(TruncatedNote{
 		ID:          "id0",
@@ -0,0 +1,127 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notetest
+
+import (
+	"sort"
+
+	"github.com/google/note-maps/note"
+)
+
+// MapDB is a minimal in-memory note map for use in tests.
+//
+// It implements note.Database, note.FindLoadPatcher, and note.ParentLoader.
+// Notes that are not in the map are loaded as empty notes, and empty notes
+// are never found.
+type MapDB map[note.ID]note.TruncatedNote
+
+// Load implements note.Loader.
+func (m MapDB) Load(ids []note.ID) ([]note.GraphNote, error) {
+	ns := make([]note.GraphNote, len(ids))
+	for i, id := range ids {
+		if id.Empty() {
+			return nil, note.InvalidID
+		}
+		tn, ok := m[id]
+		if !ok {
+			tn.ID = id
+		}
+		ns[i] = note.ExpandNote(tn, m)
+	}
+	return ns, nil
+}
+
+// Find implements note.Finder, returning notes in order of their IDs.
+func (m MapDB) Find(q *note.Query) ([]note.GraphNote, error) {
+	if q == nil {
+		q = &note.Query{}
+	}
+	var ids []note.ID
+	for id, tn := range m {
+		if !tn.Empty() && q.Match(tn) {
+			ids = append(ids, id)
+		}
+	}
+	sortIDs(ids)
+	return m.Load(q.Page(ids))
+}
+
+// FindBySubjectIdentifier implements note.Finder.
+func (m MapDB) FindBySubjectIdentifier(si string) ([]note.GraphNote, error) {
+	return m.Find(&note.Query{SubjectIdentifier: si})
+}
+
+// LoadParentIDs implements note.ParentLoader, returning parents in order of
+// their IDs.
+func (m MapDB) LoadParentIDs(ids []note.ID) ([][]note.ID, error) {
+	parents := make([][]note.ID, len(ids))
+	for i, id := range ids {
+		for pid, tn := range m {
+			for _, c := range tn.Contents {
+				if c == id {
+					parents[i] = append(parents[i], pid)
+					break
+				}
+			}
+		}
+		sortIDs(parents[i])
+	}
+	return parents, nil
+}
+
+// Patch implements note.Patcher.
+func (m MapDB) Patch(ops []note.Operation) error {
+	for _, op := range ops {
+		id := op.(interface{ GetID() note.ID }).GetID()
+		tn, ok := m[id]
+		if !ok {
+			tn.ID = id
+		}
+		if err := note.Patch(&tn, []note.Operation{op}); err != nil {
+			return err
+		}
+		m[id] = tn
+	}
+	return nil
+}
+
+// IsolatedRead implements note.DatabaseReader.
+func (m MapDB) IsolatedRead(f func(r note.FindLoader) error) error { return f(m) }
+
+// IsolatedWrite implements note.DatabaseWriter. Changes are made to a copy of
+// m, and copied back only if f succeeds.
+func (m MapDB) IsolatedWrite(f func(rw note.FindLoadPatcher) error) error {
+	staged := make(MapDB, len(m))
+	for id, tn := range m {
+		staged[id] = tn
+	}
+	if err := f(staged); err != nil {
+		return err
+	}
+	for id := range m {
+		delete(m, id)
+	}
+	for id, tn := range staged {
+		m[id] = tn
+	}
+	return nil
+}
+
+// Close implements note.Database.
+func (m MapDB) Close() error { return nil }
+
+func sortIDs(ids []note.ID) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notetest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/note-maps/note"
+)
+
+func TestMapDB(t *testing.T) {
+	m := MapDB{}
+	var _ note.Database = m
+	var _ note.FindLoadPatcher = m
+	var _ note.ParentLoader = m
+	if err := m.IsolatedWrite(func(rw note.FindLoadPatcher) error {
+		return rw.Patch(note.OperationSlice{}.
+			SetValueString("c", "child").
+			InsertContent("p1", 0, "c").
+			InsertContent("p0", 0, "c", "e"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	abort := errors.New("abort")
+	if err := m.IsolatedWrite(func(rw note.FindLoadPatcher) error {
+		if err := rw.Patch(note.OperationSlice{}.ClearNote("c")); err != nil {
+			return err
+		}
+		return abort
+	}); err != abort {
+		t.Fatalf("got %v, expected %v", err, abort)
+	}
+	if m["c"].ValueString != "child" {
+		t.Errorf("expected an aborted write to be discarded, got %#v", m["c"])
+	}
+	ns, err := m.Find(&note.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found []note.ID
+	for _, n := range ns {
+		found = append(found, n.GetID())
+	}
+	if expect := []note.ID{"c", "p0", "p1"}; !reflect.DeepEqual(found, expect) {
+		t.Errorf("found %v, expected %v", found, expect)
+	}
+	parents, err := m.LoadParentIDs([]note.ID{"c", "e", "p0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := [][]note.ID{{"p0", "p1"}, {"p0"}, nil}; !reflect.DeepEqual(parents, expect) {
+		t.Errorf("got parents %v, expected %v", parents, expect)
+	}
+	if _, err := m.Load([]note.ID{note.EmptyID}); !errors.Is(err, note.InvalidID) {
+		t.Errorf("got %v, expected %v", err, note.InvalidID)
+	}
+}
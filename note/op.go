@@ -14,7 +14,10 @@
 
 package note
 
-import "github.com/google/note-maps/otgen/runes"
+import (
+	"github.com/google/note-maps/otgen/runes"
+	"github.com/google/note-maps/otgen/strs"
+)
 
 // Operation is implemented by types that can describe changes that might be
 // made to a note map.
@@ -66,6 +69,33 @@ func (os OperationSlice) SetValueString(id ID, vs string) OperationSlice {
 	return append(os, OpSetValueString{Op(id), vs})
 }
 
+// OpValueDelta applies StringOps to the runes of the value of a note, leaving
+// its datatype unchanged.
+//
+// Unlike OpSetValueString, concurrent OpValueDelta operations on the same note
+// can be merged.
+type OpValueDelta struct {
+	Op
+	StringOps []runes.StringOp
+}
+
+func (o OpValueDelta) String() string {
+	s := "patch value of " + string(o.Op) + ":"
+	for _, op := range o.StringOps {
+		s += " " + op.String()
+	}
+	return s + "."
+}
+
+// PatchValue returns a new OperationSlice that also applies ops to the value
+// of note id.
+func (os OperationSlice) PatchValue(id ID, ops []runes.StringOp) OperationSlice {
+	if len(ops) == 0 {
+		return os
+	}
+	return append(os, OpValueDelta{Op(id), ops})
+}
+
 type OpIDSliceDelta struct {
 	Op
 	IDSliceOps []IDSliceOp
@@ -114,100 +144,60 @@ func (os OperationSlice) PatchTypes(id ID, ops []IDSliceOp) OperationSlice {
 	return append(os, OpTypesDelta{Op(id), ops})
 }
 
-type NoteOp interface{}
-type NoteDelta []NoteOp
-type NoteOpID ID
-type NoteOpValueDelta runes.StringDelta
-type NoteOpValueTypeDelta ID
-type NoteOpContentsDelta IDSliceDelta
-type NoteOpTypesDelta IDSliceDelta
-
-func NoteDeltaFromTruncatedNote(n TruncatedNote) NoteDelta {
-	return NoteDelta{}.
-		ChangeValueType(n.ValueType).
-		ChangeValueString(runes.String("").Append([]rune(n.ValueString)...)).
-		ChangeContents(IDSlice{}.Append(n.Contents...)).
-		ChangeTypes(IDSlice{}.Append(n.Types...))
-}
-func (xs NoteDelta) GetID() ID {
-	id := EmptyID
-	for _, x := range xs {
-		switch o := x.(type) {
-		case NoteOpID:
-			id = ID(o)
-		}
-	}
-	return id
-}
-func (xs NoteDelta) GetValueTypeID() ID {
-	vt := EmptyID
-	for _, x := range xs {
-		switch o := x.(type) {
-		case NoteOpValueTypeDelta:
-			vt = ID(o)
-		}
-	}
-	return vt
-}
-func (xs NoteDelta) GetValueString() runes.String {
-	var vs runes.String
-	for _, x := range xs {
-		switch o := x.(type) {
-		case NoteOpValueDelta:
-			vs = vs.Apply(runes.StringDelta(o))
-		}
-	}
-	return vs
-}
-func (xs NoteDelta) GetContentIDs() IDSlice {
-	var ids IDSlice
-	for _, x := range xs {
-		switch o := x.(type) {
-		case NoteOpContentsDelta:
-			ids = ids.Apply(IDSliceDelta(o))
-		}
-	}
-	return ids
-}
-func (xs NoteDelta) GetTypeIDs() IDSlice {
-	var ids IDSlice
-	for _, x := range xs {
-		switch o := x.(type) {
-		case NoteOpTypesDelta:
-			ids = ids.Apply(IDSliceDelta(o))
-		}
-	}
-	return ids
-}
-func (xs NoteDelta) Truncate() TruncatedNote {
-	return TruncatedNote{
-		ID:          xs.GetID(),
-		ValueString: xs.GetValueString().String(),
-		ValueType:   xs.GetValueTypeID(),
-		Contents:    xs.GetContentIDs(),
-		Types:       xs.GetTypeIDs(),
-	}
+// OpRolesDelta removes Remove from and then adds Add to the roles of a note.
+type OpRolesDelta struct {
+	Op
+	Add    []Role
+	Remove []Role
 }
-func (xs NoteDelta) SetID(id ID) NoteDelta {
-	return append(xs, NoteOpID(id))
+
+func (o OpRolesDelta) String() string {
+	return "patch roles of " + string(o.Op) +
+		": remove " + RoleSlice(o.Remove).String() +
+		" add " + RoleSlice(o.Add).String() + "."
 }
-func (xs NoteDelta) ChangeValueType(vt ID) NoteDelta {
-	return append(xs, NoteOpValueTypeDelta(vt))
+
+// PatchRoles returns a new OperationSlice that also removes remove from and
+// adds add to the roles of note id.
+func (os OperationSlice) PatchRoles(id ID, add, remove []Role) OperationSlice {
+	if len(add) == 0 && len(remove) == 0 {
+		return os
+	}
+	return append(os, OpRolesDelta{Op(id), add, remove})
 }
-func (xs NoteDelta) ChangeValueString(d runes.StringDelta) NoteDelta {
-	return append(xs, NoteOpValueDelta(d))
+
+// OpSubjectIdentifiersDelta applies StringsOps to the subject identifiers of
+// a note.
+type OpSubjectIdentifiersDelta struct {
+	Op
+	StringsOps []strs.StringsOp
 }
-func (xs NoteDelta) ChangeContents(d IDSliceDelta) NoteDelta {
-	return append(xs, NoteOpContentsDelta(d))
+
+func (o OpSubjectIdentifiersDelta) String() string {
+	s := "patch subject identifiers of " + string(o.Op) + ":"
+	for _, op := range o.StringsOps {
+		s += " " + op.String()
+	}
+	return s + "."
 }
-func (xs NoteDelta) ChangeTypes(d IDSliceDelta) NoteDelta {
-	return append(xs, NoteOpTypesDelta(d))
+
+// PatchSubjectIdentifiers returns a new OperationSlice that also applies ops
+// to the subject identifiers of note id.
+func (os OperationSlice) PatchSubjectIdentifiers(id ID, ops []strs.StringsOp) OperationSlice {
+	if len(ops) == 0 {
+		return os
+	}
+	return append(os, OpSubjectIdentifiersDelta{Op(id), ops})
+}
+
+// OpClearNote clears every field of a note, effectively deleting it.
+type OpClearNote struct {
+	Op
 }
 
-type NoteMapOp interface{}
-type NoteMapOpNoteDelta NoteDelta
-type NoteMapDelta []NoteMapOp
+func (o OpClearNote) String() string { return "clear " + string(o.Op) }
 
-func (xs NoteMapDelta) ChangeNote(n NoteDelta) NoteMapDelta {
-	return append(xs, NoteMapOpNoteDelta(n))
+// ClearNote returns a new OperationSlice that also clears note id.
+func (os OperationSlice) ClearNote(id ID) OperationSlice {
+	return append(os, OpClearNote{Op(id)})
 }
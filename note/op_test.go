@@ -17,6 +17,9 @@ package note
 import (
 	"reflect"
 	"testing"
+
+	"github.com/google/note-maps/otgen/runes"
+	"github.com/google/note-maps/otgen/strs"
 )
 
 func TestOp(t *testing.T) {
@@ -73,6 +76,49 @@ func TestOperationSlice_InsertContent(t *testing.T) {
 	}
 }
 
+func TestOperationSlice_PatchRoles(t *testing.T) {
+	var ops OperationSlice
+	ops = ops.PatchRoles("id0", nil, nil)
+	ops = ops.PatchRoles("id0", []Role{{"r0", "p0"}}, []Role{{"r1", "p1"}})
+	if !reflect.DeepEqual(ops, OperationSlice{
+		OpRolesDelta{"id0", []Role{{"r0", "p0"}}, []Role{{"r1", "p1"}}},
+	}) {
+		t.Error(ops)
+	}
+}
+
+func TestOperationSlice_PatchSubjectIdentifiers(t *testing.T) {
+	var ops OperationSlice
+	ops = ops.PatchSubjectIdentifiers("id0", nil)
+	ops = ops.PatchSubjectIdentifiers("id0", strs.Strings{"a"}.Append("b"))
+	if !reflect.DeepEqual(ops, OperationSlice{
+		OpSubjectIdentifiersDelta{"id0", []strs.StringsOp{strs.StringsOpRetain(1), strs.StringsOpInsert{"b"}}},
+	}) {
+		t.Error(ops)
+	}
+}
+
+func TestOperationSlice_PatchValue(t *testing.T) {
+	var ops OperationSlice
+	ops = ops.PatchValue("id0", nil)
+	if len(ops) != 0 {
+		t.Error(ops)
+	}
+	ops = ops.PatchValue("id0", runes.StringDelta{}.Retain(1).Insert('x'))
+	if !reflect.DeepEqual(ops, OperationSlice{OpValueDelta{"id0",
+		[]runes.StringOp{runes.StringOpRetain(1), runes.StringOpInsert("x")}}}) {
+		t.Error(ops)
+	}
+}
+
+func TestOperationSlice_ClearNote(t *testing.T) {
+	var ops OperationSlice
+	ops = ops.ClearNote("id0")
+	if !reflect.DeepEqual(ops, OperationSlice{OpClearNote{"id0"}}) {
+		t.Error(ops)
+	}
+}
+
 func TestOperationSlice_RemoveContent(t *testing.T) {
 	var ops OperationSlice
 	ops = ops.PatchContent("id0", IDSlice{"c0", "c1", "c2"}.DeleteElements("c1", "c2"))
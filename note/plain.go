@@ -27,6 +27,15 @@ type Plain struct {
 	ValueType   *Plain
 	Contents    []*Plain
 	Types       []*Plain
+	Roles       []PlainRole
+
+	SubjectIdentifiers []string
+}
+
+// PlainRole is a "plain old Go object" representation of a Role.
+type PlainRole struct {
+	Type   *Plain
+	Player *Plain
 }
 
 // GraphNote returns a proxy to x that implements the GraphNote interface.
@@ -52,6 +61,21 @@ func (x graphPlain) GetContents() ([]GraphNote, error) {
 func (x graphPlain) GetTypes() ([]GraphNote, error) {
 	return nmslice(x.Types)
 }
+func (x graphPlain) GetRoles() ([]Role, error) {
+	rs := make([]Role, len(x.Roles))
+	for i, r := range x.Roles {
+		if r.Type != nil {
+			rs[i].Type = r.Type.ID
+		}
+		if r.Player != nil {
+			rs[i].Player = r.Player.ID
+		}
+	}
+	return rs, nil
+}
+func (x graphPlain) GetSubjectIdentifiers() ([]string, error) {
+	return x.SubjectIdentifiers, nil
+}
 
 func nmslice(ps []*Plain) ([]GraphNote, error) {
 	gs := make([]GraphNote, len(ps))
@@ -14,9 +14,167 @@
 
 package note
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // Query limits the notes that will be found when loading information from a
 // graph.
 //
-// The default query matches all notes.
+// The default query matches all notes. Each non-zero field further limits the
+// notes that match.
 type Query struct {
+	// ValueContains matches notes with a value string that contains it.
+	ValueContains string
+
+	// ValuePrefix matches notes with a value string that begins with it.
+	ValuePrefix string
+
+	// ValueType matches notes with a value of exactly this type.
+	ValueType ID
+
+	// AnyTypes matches notes that have at least one of these types.
+	AnyTypes []ID
+
+	// AllTypes matches notes that have every one of these types.
+	AllTypes []ID
+
+	// SubjectIdentifier matches notes that have it as one of their subject
+	// identifiers.
+	SubjectIdentifier string
+
+	// ContentOf matches notes that are in the content of this note. When it is
+	// set, matching notes are found in the order of that content.
+	ContentOf ID
+
+	// Offset is the number of matching notes to skip.
+	Offset int
+
+	// Limit is the maximum number of notes to find, or zero for no limit.
+	Limit int
+}
+
+// Match returns true if and only if n satisfies the value and type criteria
+// of q.
+//
+// Match does not consider ContentOf, Offset, or Limit since they cannot be
+// evaluated from a single note.
+func (q *Query) Match(n TruncatedNote) bool {
+	if q == nil {
+		return true
+	}
+	if !strings.Contains(n.ValueString, q.ValueContains) ||
+		!strings.HasPrefix(n.ValueString, q.ValuePrefix) {
+		return false
+	}
+	if !q.ValueType.Empty() && n.ValueType != q.ValueType {
+		return false
+	}
+	if len(q.AnyTypes) > 0 && !containsAny(n.Types, q.AnyTypes) {
+		return false
+	}
+	for _, t := range q.AllTypes {
+		if !containsAny(n.Types, []ID{t}) {
+			return false
+		}
+	}
+	if q.SubjectIdentifier != "" {
+		for _, si := range n.SubjectIdentifiers {
+			if si == q.SubjectIdentifier {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Page returns the part of ids that is selected by q.Offset and q.Limit.
+func (q *Query) Page(ids []ID) []ID {
+	if q == nil {
+		return ids
+	}
+	if q.Offset > 0 {
+		if q.Offset >= len(ids) {
+			return nil
+		}
+		ids = ids[q.Offset:]
+	}
+	if q.Limit > 0 && q.Limit < len(ids) {
+		ids = ids[:q.Limit]
+	}
+	return ids
+}
+
+func containsAny(ids []ID, any []ID) bool {
+	for _, id := range ids {
+		for _, a := range any {
+			if id == a {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseQuery parses simple text input into a Query.
+//
+// The input is split into space-separated terms. A term of the form key:value
+// sets a criterion according to key:
+//
+//	prefix:text    value string begins with text
+//	vtype:id       value type is id
+//	type:id        has type id; may be repeated, all must match
+//	anytype:id     has type id; may be repeated, any may match
+//	si:iri         has subject identifier iri
+//	in:id          is in the content of note id
+//	offset:n       skip the first n matches
+//	limit:n        find at most n matches
+//
+// All other terms are joined with single spaces and must be contained in the
+// value string of a matching note.
+func ParseQuery(s string) (*Query, error) {
+	var (
+		q     Query
+		words []string
+	)
+	for _, term := range strings.Fields(s) {
+		i := strings.IndexRune(term, ':')
+		if i < 0 {
+			words = append(words, term)
+			continue
+		}
+		key, val := term[:i], term[i+1:]
+		var err error
+		switch key {
+		case "prefix":
+			q.ValuePrefix = val
+		case "vtype":
+			q.ValueType = ID(val)
+		case "type":
+			q.AllTypes = append(q.AllTypes, ID(val))
+		case "anytype":
+			q.AnyTypes = append(q.AnyTypes, ID(val))
+		case "si":
+			q.SubjectIdentifier = val
+		case "in":
+			q.ContentOf = ID(val)
+		case "offset":
+			q.Offset, err = strconv.Atoi(val)
+		case "limit":
+			q.Limit, err = strconv.Atoi(val)
+		default:
+			words = append(words, term)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid query term %#v: %w", term, err)
+		}
+		if q.Offset < 0 || q.Limit < 0 {
+			return nil, fmt.Errorf("invalid query term %#v: must not be negative", term)
+		}
+	}
+	q.ValueContains = strings.Join(words, " ")
+	return &q, nil
 }
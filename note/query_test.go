@@ -0,0 +1,114 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuery_Match(t *testing.T) {
+	n := TruncatedNote{
+		ID:          "id0",
+		ValueString: "hello world",
+		ValueType:   "vt0",
+		Types:       []ID{"t0", "t1"},
+
+		SubjectIdentifiers: []string{"si0", "si1"},
+	}
+	for _, test := range []struct {
+		Title string
+		Q     *Query
+		Match bool
+	}{
+		{"nil query", nil, true},
+		{"default query", &Query{}, true},
+		{"contains", &Query{ValueContains: "o w"}, true},
+		{"does not contain", &Query{ValueContains: "planet"}, false},
+		{"prefix", &Query{ValuePrefix: "hello"}, true},
+		{"not prefix", &Query{ValuePrefix: "world"}, false},
+		{"value type", &Query{ValueType: "vt0"}, true},
+		{"other value type", &Query{ValueType: "vt1"}, false},
+		{"any types", &Query{AnyTypes: []ID{"t2", "t1"}}, true},
+		{"no types", &Query{AnyTypes: []ID{"t2", "t3"}}, false},
+		{"all types", &Query{AllTypes: []ID{"t0", "t1"}}, true},
+		{"not all types", &Query{AllTypes: []ID{"t0", "t2"}}, false},
+		{"subject identifier", &Query{SubjectIdentifier: "si1"}, true},
+		{"other subject identifier", &Query{SubjectIdentifier: "si2"}, false},
+		{"content of is ignored", &Query{ContentOf: "other"}, true},
+	} {
+		t.Run(test.Title, func(t *testing.T) {
+			if got := test.Q.Match(n); got != test.Match {
+				t.Errorf("got %v, expected %v", got, test.Match)
+			}
+		})
+	}
+}
+
+func TestQuery_Page(t *testing.T) {
+	ids := []ID{"0", "1", "2", "3"}
+	for _, test := range []struct {
+		Q      *Query
+		Expect []ID
+	}{
+		{nil, ids},
+		{&Query{}, ids},
+		{&Query{Offset: 1}, []ID{"1", "2", "3"}},
+		{&Query{Limit: 2}, []ID{"0", "1"}},
+		{&Query{Offset: 1, Limit: 2}, []ID{"1", "2"}},
+		{&Query{Offset: 3, Limit: 2}, []ID{"3"}},
+		{&Query{Offset: 4}, nil},
+	} {
+		if got := test.Q.Page(ids); !reflect.DeepEqual(got, test.Expect) {
+			t.Errorf("%#v: got %#v, expected %#v", test.Q, got, test.Expect)
+		}
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	for _, test := range []struct {
+		In     string
+		Expect Query
+	}{
+		{"", Query{}},
+		{"hello  world", Query{ValueContains: "hello world"}},
+		{
+			"prefix:he vtype:vt type:t0 type:t1 anytype:t2 in:p offset:1 limit:2",
+			Query{
+				ValuePrefix: "he",
+				ValueType:   "vt",
+				AllTypes:    []ID{"t0", "t1"},
+				AnyTypes:    []ID{"t2"},
+				ContentOf:   "p",
+				Offset:      1,
+				Limit:       2,
+			},
+		},
+		{"http://example.com", Query{ValueContains: "http://example.com"}},
+		{"si:http://example.com", Query{SubjectIdentifier: "http://example.com"}},
+	} {
+		q, err := ParseQuery(test.In)
+		if err != nil {
+			t.Errorf("%#v: %v", test.In, err)
+		} else if !reflect.DeepEqual(*q, test.Expect) {
+			t.Errorf("%#v: got %#v, expected %#v", test.In, *q, test.Expect)
+		}
+	}
+	for _, in := range []string{"limit:x", "offset:-1"} {
+		if _, err := ParseQuery(in); err == nil {
+			t.Errorf("%#v: expected an error", in)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+// Role is a role player pair: Player identifies a note that plays a role of
+// type Type in an association.
+//
+// A note with any roles represents an association between the players of
+// those roles. The type of a role may be empty, but a role without a player
+// is ignored.
+type Role struct {
+	Type   ID
+	Player ID
+}
+
+func (r Role) String() string { return string(r.Type) + "=" + string(r.Player) }
+
+// RoleSlice is a set of roles, represented as a slice in which each role
+// appears only once.
+type RoleSlice []Role
+
+// Has returns true if and only if r is in rs.
+func (rs RoleSlice) Has(r Role) bool {
+	for _, x := range rs {
+		if x == r {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPlayer returns true if and only if player plays any role in rs.
+func (rs RoleSlice) HasPlayer(player ID) bool {
+	for _, x := range rs {
+		if x.Player == player {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns a new RoleSlice without any of the roles in remove and with
+// all the roles in add.
+//
+// Roles that are already present are not added again, and roles without a
+// player are ignored.
+func (rs RoleSlice) Apply(add, remove []Role) RoleSlice {
+	var result RoleSlice
+	for _, r := range rs {
+		if !RoleSlice(remove).Has(r) && !r.Player.Empty() && !result.Has(r) {
+			result = append(result, r)
+		}
+	}
+	for _, r := range add {
+		if !r.Player.Empty() && !result.Has(r) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// Equals returns true if and only if rs and other contain the same roles,
+// in any order.
+func (rs RoleSlice) Equals(other []Role) bool {
+	add, remove := RoleSliceDiff(rs, other)
+	return len(add) == 0 && len(remove) == 0
+}
+
+func (rs RoleSlice) String() string {
+	s := "["
+	for i, r := range rs {
+		if i > 0 {
+			s += ","
+		}
+		s += r.String()
+	}
+	return s + "]"
+}
+
+// RoleSliceDiff returns the roles that would have to be added to and removed
+// from a to make it match b.
+func RoleSliceDiff(a, b []Role) (add, remove []Role) {
+	for _, r := range b {
+		if !RoleSlice(a).Has(r) {
+			add = append(add, r)
+		}
+	}
+	for _, r := range a {
+		if !RoleSlice(b).Has(r) {
+			remove = append(remove, r)
+		}
+	}
+	return add, remove
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoleSlice_Apply(t *testing.T) {
+	for _, test := range []struct {
+		Title       string
+		In          RoleSlice
+		Add, Remove []Role
+		Expect      RoleSlice
+	}{
+		{Title: "empty"},
+		{
+			Title:  "add",
+			In:     RoleSlice{{"r0", "p0"}},
+			Add:    []Role{{"r1", "p1"}},
+			Expect: RoleSlice{{"r0", "p0"}, {"r1", "p1"}},
+		},
+		{
+			Title:  "add existing",
+			In:     RoleSlice{{"r0", "p0"}},
+			Add:    []Role{{"r0", "p0"}},
+			Expect: RoleSlice{{"r0", "p0"}},
+		},
+		{
+			Title:  "add without player",
+			In:     RoleSlice{{"r0", "p0"}},
+			Add:    []Role{{"r1", EmptyID}},
+			Expect: RoleSlice{{"r0", "p0"}},
+		},
+		{
+			Title:  "remove",
+			In:     RoleSlice{{"r0", "p0"}, {"r1", "p1"}},
+			Remove: []Role{{"r0", "p0"}},
+			Expect: RoleSlice{{"r1", "p1"}},
+		},
+		{
+			Title:  "empty role type",
+			In:     RoleSlice{{"r0", "p0"}},
+			Add:    []Role{{EmptyID, "p1"}},
+			Expect: RoleSlice{{"r0", "p0"}, {EmptyID, "p1"}},
+		},
+	} {
+		t.Run(test.Title, func(t *testing.T) {
+			got := test.In.Apply(test.Add, test.Remove)
+			if !reflect.DeepEqual(got, test.Expect) {
+				t.Errorf("got %v, expected %v", got, test.Expect)
+			}
+		})
+	}
+}
+
+func TestRoleSliceDiff(t *testing.T) {
+	a := []Role{{"r0", "p0"}, {"r1", "p1"}}
+	b := []Role{{"r1", "p1"}, {"r2", "p2"}}
+	add, remove := RoleSliceDiff(a, b)
+	if expect := []Role{{"r2", "p2"}}; !reflect.DeepEqual(add, expect) {
+		t.Errorf("add: got %v, expected %v", add, expect)
+	}
+	if expect := []Role{{"r0", "p0"}}; !reflect.DeepEqual(remove, expect) {
+		t.Errorf("remove: got %v, expected %v", remove, expect)
+	}
+	if got := RoleSlice(a).Apply(add, remove); !got.Equals(b) {
+		t.Errorf("got %v, expected %v", got, b)
+	}
+}
@@ -14,7 +14,13 @@
 
 package note
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/note-maps/otgen/runes"
+	"github.com/google/note-maps/otgen/strs"
+)
 
 // Stage describes a set of changes that might be made to a note map.
 //
@@ -22,9 +28,17 @@ import "errors"
 // note map.
 //
 // A default Stage{} is an empty set of changes made to an empty note map.
+//
+// Stage also implements FindLoader to read the hypothetical state of the note
+// map with all the staged changes applied. If Base also implements Finder, it
+// is used to find notes that have not been changed.
 type Stage struct {
 	Ops  OperationSlice
 	Base Loader
+
+	// Datatypes, if not nil, is used to validate and normalize values as they
+	// are staged.
+	Datatypes DatatypeRegistry
 }
 
 // Add simply appends o to the set of operations described by x.
@@ -36,6 +50,112 @@ func (x *Stage) Add(o Operation) *Stage {
 // Note returns a note-specific StageNote focused on note with id.
 func (x *Stage) Note(id ID) *StageNote { return &StageNote{x, id} }
 
+// NewNote returns a StageNote focused on a note with a new random ID that is
+// not yet used in x or in x.Base.
+func (x *Stage) NewNote() (*StageNote, error) {
+	for {
+		n := x.Note(RandomID())
+		used := false
+		for _, op := range x.Ops {
+			if op.AffectsID(n.ID) {
+				used = true
+				break
+			}
+		}
+		if used {
+			continue
+		}
+		tn, err := TruncateNote(n)
+		if err != nil {
+			return nil, err
+		}
+		if tn.Empty() {
+			return n, nil
+		}
+	}
+}
+
+// Commit applies the operations described by x to p and, if that succeeds,
+// clears them from x.
+func (x *Stage) Commit(p Patcher) error {
+	if err := p.Patch(x.Ops); err != nil {
+		return err
+	}
+	x.Ops = nil
+	return nil
+}
+
+// Load returns a StageNote for each of ids, so that the notes and every note
+// they refer to are read with the staged changes applied.
+func (x *Stage) Load(ids []ID) ([]GraphNote, error) {
+	ns := make([]GraphNote, len(ids))
+	for i, id := range ids {
+		if id == EmptyID {
+			return nil, InvalidID
+		}
+		ns[i] = x.Note(id)
+	}
+	return ns, nil
+}
+
+// Find returns the notes that match q with the staged changes applied.
+//
+// Notes found in x.Base, if it implements Finder, come first in the order
+// they were found there, followed by any other notes changed by x.Ops in the
+// order they were first changed.
+func (x *Stage) Find(q *Query) ([]GraphNote, error) {
+	var candidates []ID
+	if q != nil && !q.ContentOf.Empty() {
+		cids, err := x.Note(q.ContentOf).GetContentIDs()
+		if err != nil {
+			return nil, err
+		}
+		candidates = cids
+	} else {
+		seen := make(map[ID]bool)
+		if f, ok := x.GetBase().(Finder); ok {
+			var all Query
+			if q != nil {
+				all = *q
+				all.Offset, all.Limit = 0, 0
+			}
+			ns, err := f.Find(&all)
+			if err != nil {
+				return nil, err
+			}
+			for _, n := range ns {
+				if id := n.GetID(); !seen[id] {
+					seen[id] = true
+					candidates = append(candidates, id)
+				}
+			}
+		}
+		for _, op := range x.Ops {
+			if o, ok := op.(interface{ GetID() ID }); ok && !seen[o.GetID()] {
+				seen[o.GetID()] = true
+				candidates = append(candidates, o.GetID())
+			}
+		}
+	}
+	var ids []ID
+	for _, id := range candidates {
+		tn, err := TruncateNote(x.Note(id))
+		if err != nil {
+			return nil, err
+		}
+		if !tn.Empty() && q.Match(tn) {
+			ids = append(ids, id)
+		}
+	}
+	return x.Load(q.Page(ids))
+}
+
+// FindBySubjectIdentifier returns the notes that have si as one of their
+// subject identifiers with the staged changes applied.
+func (x *Stage) FindBySubjectIdentifier(si string) ([]GraphNote, error) {
+	return x.Find(&Query{SubjectIdentifier: si})
+}
+
 // GetBase returns a non-nil Loader derived from x.Base.
 func (x *Stage) GetBase() Loader {
 	base := x.Base
@@ -68,6 +188,16 @@ func (x *StageNote) GetValue() (string, GraphNote, error) {
 			switch o := op.(type) {
 			case OpSetValue:
 				lex, dtype = o.Lexical, x.Stage.Note(o.Datatype)
+			case OpSetValueString:
+				lex = o.Lexical
+			case OpValueDelta:
+				rs := runes.String(lex)
+				if !rs.CanApply(o.StringOps) {
+					return "", nil, noteError(x.ID, fmt.Errorf("value: %w", ConflictingDelta))
+				}
+				lex = string(rs.Apply(o.StringOps))
+			case OpClearNote:
+				lex, dtype = "", EmptyNote(EmptyID)
 			}
 		}
 	}
@@ -79,29 +209,28 @@ func (x *StageNote) GetContents() ([]GraphNote, error) {
 	if err != nil {
 		return nil, err
 	}
-	return x.Stage.GetBase().Load(cids)
+	return x.Stage.Load(cids)
 }
 func (x *StageNote) GetContentIDs() ([]ID, error) {
 	base, err := LoadOne(x.Stage.GetBase(), x.ID)
 	if err != nil {
 		return nil, err
 	}
-	ns, err := base.GetContents()
+	ids, err := contentIDsOf(base)
 	if err != nil {
 		return nil, err
 	}
-	cids := make(IDSlice, len(ns))
-	for i, n := range ns {
-		cids[i] = n.GetID()
-	}
+	cids := append(IDSlice(nil), ids...)
 	for _, op := range x.Stage.Ops {
 		if op.AffectsID(x.ID) {
 			switch o := op.(type) {
 			case OpContentDelta:
 				if !cids.CanApply(o.IDSliceOps) {
-					return nil, errors.New("cannot apply delta")
+					return nil, noteError(x.ID, fmt.Errorf("content: %w", ConflictingDelta))
 				}
 				cids = cids.Apply(o.IDSliceOps)
+			case OpClearNote:
+				cids = nil
 			}
 		}
 	}
@@ -113,47 +242,161 @@ func (x *StageNote) GetTypes() ([]GraphNote, error) {
 	if err != nil {
 		return nil, err
 	}
-	return x.Stage.GetBase().Load(tids)
+	return x.Stage.Load(tids)
 }
 func (x *StageNote) GetTypeIDs() ([]ID, error) {
 	base, err := LoadOne(x.Stage.GetBase(), x.ID)
 	if err != nil {
 		return nil, err
 	}
-	ns, err := base.GetTypes()
+	ids, err := typeIDsOf(base)
 	if err != nil {
 		return nil, err
 	}
-	tids := make(IDSlice, len(ns))
-	for i, n := range ns {
-		tids[i] = n.GetID()
-	}
+	tids := append(IDSlice(nil), ids...)
 	for _, op := range x.Stage.Ops {
 		if op.AffectsID(x.ID) {
 			switch o := op.(type) {
 			case OpTypesDelta:
 				if !tids.CanApply(o.IDSliceOps) {
-					return nil, errors.New("cannot apply delta")
+					return nil, noteError(x.ID, fmt.Errorf("types: %w", ConflictingDelta))
 				}
 				tids = tids.Apply(o.IDSliceOps)
+			case OpClearNote:
+				tids = nil
 			}
 		}
 	}
 	return tids, nil
 }
 
+func (x *StageNote) GetRoles() ([]Role, error) {
+	base, err := LoadOne(x.Stage.GetBase(), x.ID)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := base.GetRoles()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range x.Stage.Ops {
+		if op.AffectsID(x.ID) {
+			switch o := op.(type) {
+			case OpRolesDelta:
+				rs = RoleSlice(rs).Apply(o.Add, o.Remove)
+			case OpClearNote:
+				rs = nil
+			}
+		}
+	}
+	return rs, nil
+}
+
+func (x *StageNote) GetSubjectIdentifiers() ([]string, error) {
+	base, err := LoadOne(x.Stage.GetBase(), x.ID)
+	if err != nil {
+		return nil, err
+	}
+	bsis, err := base.GetSubjectIdentifiers()
+	if err != nil {
+		return nil, err
+	}
+	sis := strs.Strings(bsis)
+	for _, op := range x.Stage.Ops {
+		if op.AffectsID(x.ID) {
+			switch o := op.(type) {
+			case OpSubjectIdentifiersDelta:
+				if !sis.CanApply(o.StringsOps) {
+					return nil, noteError(x.ID, fmt.Errorf("subject identifiers: %w", ConflictingDelta))
+				}
+				sis = sis.Apply(o.StringsOps)
+			case OpClearNote:
+				sis = nil
+			}
+		}
+	}
+	return sis, nil
+}
+
 // Note returns the identified note from the underlying stage.
 func (x *StageNote) Note(id ID) *StageNote { return x.Stage.Note(id) }
 
 // SetValue expands the staged operations to update the value of this note.
+//
+// If x.Stage.Datatypes is not nil, lexical is normalized according to
+// datatype, and an error is returned if that is not possible.
 func (x *StageNote) SetValue(lexical string, datatype ID) error {
 	if x.ID == EmptyID {
 		panic("cannot set value before specifying an ID")
 	}
+	lexical, err := x.Stage.Datatypes.Normalize(datatype, lexical)
+	if err != nil {
+		return err
+	}
 	x.Stage.Ops = x.Stage.Ops.SetValue(x.ID, lexical, datatype)
 	return nil
 }
 
+// SetValueString expands the staged operations to update the value of this
+// note without changing its datatype.
+//
+// If x.Stage.Datatypes is not nil, lexical is normalized according to the
+// datatype of this note, and an error is returned if that is not possible.
+func (x *StageNote) SetValueString(lexical string) error {
+	if x.ID == EmptyID {
+		panic("cannot set value before specifying an ID")
+	}
+	_, dtype, err := x.GetValue()
+	if err != nil {
+		return err
+	}
+	lexical, err = x.Stage.Datatypes.Normalize(idOf(dtype), lexical)
+	if err != nil {
+		return err
+	}
+	x.Stage.Ops = x.Stage.Ops.SetValueString(x.ID, lexical)
+	return nil
+}
+
+// EditValue expands the staged operations to delete n runes from the value of
+// this note at rune index i, and then to insert s in their place.
+//
+// Unlike SetValueString, EditValue stages only the change itself so that it
+// can be merged with concurrent edits to the same value. However, if
+// x.Stage.Datatypes is not nil and the edited value is not already in its
+// normal form, the normalized value is staged instead.
+func (x *StageNote) EditValue(i, n int, s string) error {
+	if x.ID == EmptyID {
+		panic("cannot set value before specifying an ID")
+	}
+	lex, dtype, err := x.GetValue()
+	if err != nil {
+		return err
+	}
+	rs := runes.String(lex)
+	if i < 0 || n < 0 || i+n > len(rs) {
+		return errors.New("index out of range")
+	}
+	delta := rs.Retain(i)
+	if n > 0 {
+		delta = delta.Delete(n)
+	}
+	if len(s) > 0 {
+		delta = delta.Insert([]rune(s)...)
+	}
+	edited := string(rs.Apply(delta))
+	normal, err := x.Stage.Datatypes.Normalize(idOf(dtype), edited)
+	if err != nil {
+		return err
+	}
+	if normal != edited {
+		x.Stage.Ops = x.Stage.Ops.SetValueString(x.ID, normal)
+	} else {
+		x.Stage.Ops = x.Stage.Ops.PatchValue(x.ID, delta)
+	}
+	return nil
+}
+
 // AddContent expands the staged operations to add content to this note.
 func (x *StageNote) AddContent(id ID) (*StageNote, error) {
 	if x.ID == EmptyID {
@@ -167,7 +410,89 @@ func (x *StageNote) AddContent(id ID) (*StageNote, error) {
 	return &StageNote{x.Stage, id}, nil
 }
 
-// AddContent expands the staged operations to add content to this note.
+// AddNewContent expands the staged operations to add a new note, with an ID
+// chosen by x.Stage.NewNote, to the content of this note.
+func (x *StageNote) AddNewContent() (*StageNote, error) {
+	if x.ID == EmptyID {
+		panic("cannot add content before specifying an ID")
+	}
+	n, err := x.Stage.NewNote()
+	if err != nil {
+		return nil, err
+	}
+	return x.AddContent(n.ID)
+}
+
+// InsertContent expands the staged operations to insert ids into the content
+// of this note at index i.
+func (x *StageNote) InsertContent(i int, ids ...ID) error {
+	if x.ID == EmptyID {
+		panic("cannot add content before specifying an ID")
+	}
+	cids, err := x.GetContentIDs()
+	if err != nil {
+		return err
+	}
+	if i < 0 || i > len(cids) {
+		return errors.New("index out of range")
+	}
+	x.Stage.Ops = x.Stage.Ops.PatchContent(x.ID, IDSlice(cids).Insert(i, ids...))
+	return nil
+}
+
+// RemoveContent expands the staged operations to remove every occurrence of
+// ids from the content of this note.
+func (x *StageNote) RemoveContent(ids ...ID) error {
+	if x.ID == EmptyID {
+		panic("cannot remove content before specifying an ID")
+	}
+	cids, err := x.GetContentIDs()
+	if err != nil {
+		return err
+	}
+	if containsAny(cids, ids) {
+		x.Stage.Ops = x.Stage.Ops.PatchContent(x.ID, IDSlice(cids).DeleteElements(ids...))
+	}
+	return nil
+}
+
+// MoveContent expands the staged operations to move id to index i in the
+// content of this note.
+//
+// The index is interpreted as a position in the content after the move. An
+// error is returned if id is not already in the content of this note.
+func (x *StageNote) MoveContent(id ID, i int) error {
+	if x.ID == EmptyID {
+		panic("cannot move content before specifying an ID")
+	}
+	cids, err := x.GetContentIDs()
+	if err != nil {
+		return err
+	}
+	moved, err := moveID(cids, id, i)
+	if err != nil {
+		return err
+	}
+	x.Stage.Ops = x.Stage.Ops.PatchContent(x.ID, IDSliceDiff(cids, moved))
+	return nil
+}
+
+// ReplaceContent expands the staged operations to replace the content of this
+// note with ids.
+func (x *StageNote) ReplaceContent(ids ...ID) error {
+	if x.ID == EmptyID {
+		panic("cannot set content before specifying an ID")
+	}
+	cids, err := x.GetContentIDs()
+	if err != nil {
+		return err
+	}
+	x.Stage.Ops = x.Stage.Ops.PatchContent(x.ID, IDSliceDiff(cids, ids))
+	return nil
+}
+
+// InsertTypes expands the staged operations to insert add into the types of
+// this note at index i.
 func (x *StageNote) InsertTypes(i int, add ...ID) error {
 	if x.ID == EmptyID {
 		panic("cannot set types before specifying an ID")
@@ -176,7 +501,196 @@ func (x *StageNote) InsertTypes(i int, add ...ID) error {
 	if err != nil {
 		return err
 	}
-	x.Stage.Ops = x.Stage.Ops.PatchTypes(x.ID, IDSlice(tids).Append(add...))
+	if i < 0 || i > len(tids) {
+		return errors.New("index out of range")
+	}
+	x.Stage.Ops = x.Stage.Ops.PatchTypes(x.ID, IDSlice(tids).Insert(i, add...))
+	return nil
+}
+
+// RemoveTypes expands the staged operations to remove every occurrence of ids
+// from the types of this note.
+func (x *StageNote) RemoveTypes(ids ...ID) error {
+	if x.ID == EmptyID {
+		panic("cannot set types before specifying an ID")
+	}
+	tids, err := x.GetTypeIDs()
+	if err != nil {
+		return err
+	}
+	if containsAny(tids, ids) {
+		x.Stage.Ops = x.Stage.Ops.PatchTypes(x.ID, IDSlice(tids).DeleteElements(ids...))
+	}
+	return nil
+}
+
+// MoveType expands the staged operations to move id to index i in the types
+// of this note.
+//
+// The index is interpreted as a position in the types after the move. An
+// error is returned if id is not already one of the types of this note.
+func (x *StageNote) MoveType(id ID, i int) error {
+	if x.ID == EmptyID {
+		panic("cannot set types before specifying an ID")
+	}
+	tids, err := x.GetTypeIDs()
+	if err != nil {
+		return err
+	}
+	moved, err := moveID(tids, id, i)
+	if err != nil {
+		return err
+	}
+	x.Stage.Ops = x.Stage.Ops.PatchTypes(x.ID, IDSliceDiff(tids, moved))
+	return nil
+}
+
+// ReplaceTypes expands the staged operations to replace the types of this
+// note with ids.
+func (x *StageNote) ReplaceTypes(ids ...ID) error {
+	if x.ID == EmptyID {
+		panic("cannot set types before specifying an ID")
+	}
+	tids, err := x.GetTypeIDs()
+	if err != nil {
+		return err
+	}
+	x.Stage.Ops = x.Stage.Ops.PatchTypes(x.ID, IDSliceDiff(tids, ids))
+	return nil
+}
+
+// idOf returns the ID of n, or EmptyID if n is nil.
+func idOf(n GraphNote) ID {
+	if n == nil {
+		return EmptyID
+	}
+	return n.GetID()
+}
+
+// moveID returns a copy of ids in which the first occurrence of id has been
+// moved to index i.
+func moveID(ids []ID, id ID, i int) ([]ID, error) {
+	from := -1
+	for j, x := range ids {
+		if x == id {
+			from = j
+			break
+		}
+	}
+	if from < 0 {
+		return nil, fmt.Errorf("%v: %w", id, InvalidID)
+	}
+	if i < 0 || i >= len(ids) {
+		return nil, errors.New("index out of range")
+	}
+	moved := make([]ID, 0, len(ids))
+	moved = append(moved, ids[:from]...)
+	moved = append(moved, ids[from+1:]...)
+	moved = append(moved[:i], append([]ID{id}, moved[i:]...)...)
+	return moved, nil
+}
+
+// AddRole expands the staged operations to make this note an association in
+// which player plays a role of type typ.
+//
+// The ID of this note is also added to the content of player, if it is not
+// already there.
+func (x *StageNote) AddRole(typ, player ID) error {
+	if x.ID == EmptyID {
+		panic("cannot add roles before specifying an ID")
+	}
+	if player == EmptyID {
+		return InvalidID
+	}
+	cids, err := x.Note(player).GetContentIDs()
+	if err != nil {
+		return err
+	}
+	x.Stage.Ops = x.Stage.Ops.PatchRoles(x.ID, []Role{{typ, player}}, nil)
+	if !containsAny(cids, []ID{x.ID}) {
+		x.Stage.Ops = x.Stage.Ops.PatchContent(player, IDSlice(cids).Append(x.ID))
+	}
+	return nil
+}
+
+// RemoveRole expands the staged operations to remove the role of type typ
+// played by player in this note.
+//
+// If player no longer plays any role in this note, the ID of this note is
+// also removed from the content of player.
+func (x *StageNote) RemoveRole(typ, player ID) error {
+	if x.ID == EmptyID {
+		panic("cannot remove roles before specifying an ID")
+	}
+	rs, err := x.GetRoles()
+	if err != nil {
+		return err
+	}
+	rs = RoleSlice(rs).Apply(nil, []Role{{typ, player}})
+	x.Stage.Ops = x.Stage.Ops.PatchRoles(x.ID, nil, []Role{{typ, player}})
+	if RoleSlice(rs).HasPlayer(player) {
+		return nil
+	}
+	cids, err := x.Note(player).GetContentIDs()
+	if err != nil {
+		return err
+	}
+	x.Stage.Ops = x.Stage.Ops.PatchContent(player, IDSlice(cids).DeleteElements(x.ID))
+	return nil
+}
+
+// InsertSubjectIdentifiers expands the staged operations to insert sis into
+// the subject identifiers of this note at index i.
+func (x *StageNote) InsertSubjectIdentifiers(i int, sis ...string) error {
+	if x.ID == EmptyID {
+		panic("cannot set subject identifiers before specifying an ID")
+	}
+	existing, err := x.GetSubjectIdentifiers()
+	if err != nil {
+		return err
+	}
+	if i < 0 || i > len(existing) {
+		return errors.New("index out of range")
+	}
+	x.Stage.Ops = x.Stage.Ops.PatchSubjectIdentifiers(x.ID, strs.Strings(existing).Insert(i, sis...))
+	return nil
+}
+
+// Clear expands the staged operations to clear the value, types, content,
+// roles, and subject identifiers of this note, effectively deleting it.
+//
+// The ID of this note is also removed from the content of every note that
+// plays a role in it.
+func (x *StageNote) Clear() error {
+	if x.ID == EmptyID {
+		panic("cannot clear a note before specifying an ID")
+	}
+	rs, err := x.GetRoles()
+	if err != nil {
+		return err
+	}
+	for _, r := range rs {
+		cids, err := x.Note(r.Player).GetContentIDs()
+		if err != nil {
+			return err
+		}
+		x.Stage.Ops = x.Stage.Ops.PatchContent(r.Player, IDSlice(cids).DeleteElements(x.ID))
+	}
+	x.Stage.Ops = x.Stage.Ops.ClearNote(x.ID)
+	return nil
+}
+
+// Replace expands the staged operations to make every field of this note
+// match the corresponding field of tn, except for its ID.
+func (x *StageNote) Replace(tn TruncatedNote) error {
+	if x.ID == EmptyID {
+		panic("cannot replace a note before specifying an ID")
+	}
+	current, err := TruncateNote(x)
+	if err != nil {
+		return err
+	}
+	x.Stage.Ops = append(x.Stage.Ops, Diff(current, tn)...)
 	return nil
 }
 
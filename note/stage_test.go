@@ -18,6 +18,8 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+
+	"github.com/google/note-maps/otgen/runes"
 )
 
 type breakingLoader struct {
@@ -40,10 +42,12 @@ type brokenNote struct {
 	err error
 }
 
-func (n brokenNote) GetID() ID                            { return n.ID }
-func (n brokenNote) GetValue() (string, GraphNote, error) { return "", nil, n.err }
-func (n brokenNote) GetContents() ([]GraphNote, error)    { return nil, n.err }
-func (n brokenNote) GetTypes() ([]GraphNote, error)       { return nil, n.err }
+func (n brokenNote) GetID() ID                                { return n.ID }
+func (n brokenNote) GetValue() (string, GraphNote, error)     { return "", nil, n.err }
+func (n brokenNote) GetContents() ([]GraphNote, error)        { return nil, n.err }
+func (n brokenNote) GetTypes() ([]GraphNote, error)           { return nil, n.err }
+func (n brokenNote) GetRoles() ([]Role, error)                { return nil, n.err }
+func (n brokenNote) GetSubjectIdentifiers() ([]string, error) { return nil, n.err }
 
 type brokenNoteLoader struct{ err error }
 
@@ -171,6 +175,421 @@ func TestStage_Note(t *testing.T) {
 	}
 }
 
+func TestStageNote_AddRole(t *testing.T) {
+	var s Stage
+	a := s.Note("a")
+	if err := a.AddRole("r0", "p0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.AddRole("r1", "p0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.AddRole("r0", "p1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.AddRole("r0", EmptyID); err != InvalidID {
+		t.Error("got", err, "expected", InvalidID)
+	}
+	if rs, err := a.GetRoles(); err != nil {
+		t.Error(err)
+	} else if expect := []Role{{"r0", "p0"}, {"r1", "p0"}, {"r0", "p1"}}; !reflect.DeepEqual(rs, expect) {
+		t.Errorf("got %v, expected %v", rs, expect)
+	}
+	for _, p := range []ID{"p0", "p1"} {
+		if cids, err := s.Note(p).GetContentIDs(); err != nil {
+			t.Error(err)
+		} else if expect := []ID{"a"}; !reflect.DeepEqual(cids, expect) {
+			t.Errorf("content of %v: got %v, expected %v", p, cids, expect)
+		}
+	}
+	if err := a.RemoveRole("r0", "p0"); err != nil {
+		t.Fatal(err)
+	}
+	if cids, err := s.Note("p0").GetContentIDs(); err != nil {
+		t.Error(err)
+	} else if expect := []ID{"a"}; !reflect.DeepEqual(cids, expect) {
+		t.Errorf("p0 still plays r1: got %v, expected %v", cids, expect)
+	}
+	if err := a.RemoveRole("r1", "p0"); err != nil {
+		t.Fatal(err)
+	}
+	if cids, err := s.Note("p0").GetContentIDs(); err != nil {
+		t.Error(err)
+	} else if len(cids) != 0 {
+		t.Errorf("p0 plays no role: got %v, expected no content", cids)
+	}
+}
+
+func TestStageNote_Clear(t *testing.T) {
+	var s Stage
+	a := s.Note("a")
+	a.SetValue("value", "vt")
+	a.AddContent("c0")
+	a.InsertTypes(0, "t0")
+	if err := a.AddRole("r0", "p0"); err != nil {
+		t.Fatal(err)
+	}
+	s.Note("p0").AddContent("c1")
+	if err := a.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	tn, err := TruncateNote(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tn.Empty() {
+		t.Errorf("got %#v, expected an empty note", tn)
+	}
+	if cids, err := s.Note("p0").GetContentIDs(); err != nil {
+		t.Error(err)
+	} else if expect := []ID{"c1"}; !reflect.DeepEqual(cids, expect) {
+		t.Errorf("got %v, expected %v", cids, expect)
+	}
+}
+
+func TestStageNote_Replace(t *testing.T) {
+	var s Stage
+	a := s.Note("a")
+	a.SetValue("value", "vt")
+	a.AddContent("c0")
+	a.InsertTypes(0, "t0")
+	expect := TruncatedNote{
+		ID:                 "a",
+		ValueString:        "new value",
+		Contents:           []ID{"c1", "c0"},
+		Roles:              []Role{{"r0", "p0"}},
+		SubjectIdentifiers: []string{"si0"},
+	}
+	if err := a.Replace(TruncatedNote{ID: "ignored", ValueString: expect.ValueString,
+		Contents: expect.Contents, Roles: expect.Roles,
+		SubjectIdentifiers: expect.SubjectIdentifiers}); err != nil {
+		t.Fatal(err)
+	}
+	if tn, err := TruncateNote(a); err != nil {
+		t.Fatal(err)
+	} else if !tn.Equals(expect) {
+		t.Errorf("got %#v, expected %#v", tn, expect)
+	}
+}
+
+func TestStageNote_SetValue_datatypes(t *testing.T) {
+	s := Stage{Datatypes: NewDatatypeRegistry()}
+	a := s.Note("a")
+	if err := a.SetValue(" 2020-06-01 ", TextISO8601); err != nil {
+		t.Fatal(err)
+	}
+	if lex, _, err := a.GetValue(); err != nil {
+		t.Error(err)
+	} else if lex != "2020-06-01" {
+		t.Errorf("got %#v, expected a normalized value", lex)
+	}
+	if err := a.SetValue("tomorrow", TextISO8601); !errors.Is(err, InvalidValue) {
+		t.Errorf("got %v, expected %v", err, InvalidValue)
+	}
+	if err := a.SetValue("hello", "unknown"); !errors.Is(err, UnsupportedDatatype) {
+		t.Errorf("got %v, expected %v", err, UnsupportedDatatype)
+	}
+	if len(s.Ops) != 1 {
+		t.Errorf("got %v, expected only the valid value to be staged", s.Ops)
+	}
+}
+
+func TestStageNote_GetValue_delta(t *testing.T) {
+	var s Stage
+	a := s.Note("a")
+	if err := a.SetValue("hello", "vt"); err != nil {
+		t.Fatal(err)
+	}
+	s.Ops = s.Ops.PatchValue("a", runes.StringDelta{}.Retain(5).Insert([]rune(", world")...))
+	if lex, vt, err := a.GetValue(); err != nil {
+		t.Error(err)
+	} else if lex != "hello, world" || vt.GetID() != "vt" {
+		t.Errorf("got %#v %#v, expected %#v %#v", lex, vt.GetID(), "hello, world", "vt")
+	}
+	s.Ops = s.Ops.PatchValue("a", runes.StringDelta{}.Delete(100))
+	if _, _, err := a.GetValue(); err == nil {
+		t.Error("expected an error applying a delta longer than the value")
+	}
+}
+
+func TestStageNote_InsertSubjectIdentifiers(t *testing.T) {
+	var s Stage
+	n := s.Note("n")
+	if err := n.InsertSubjectIdentifiers(0, "a", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.InsertSubjectIdentifiers(1, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.InsertSubjectIdentifiers(4, "e"); err == nil {
+		t.Error("expected an error for an index out of range")
+	}
+	if sis, err := n.GetSubjectIdentifiers(); err != nil {
+		t.Error(err)
+	} else if expect := []string{"a", "b", "c"}; !reflect.DeepEqual(sis, expect) {
+		t.Errorf("got %v, expected %v", sis, expect)
+	}
+}
+
+func TestStageNote_SetValueString(t *testing.T) {
+	s := Stage{Datatypes: NewDatatypeRegistry()}
+	a := s.Note("a")
+	if err := a.SetValue("2020-06-01", TextISO8601); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.SetValueString(" 2020-07-01 "); err != nil {
+		t.Fatal(err)
+	}
+	if lex, vt, err := a.GetValue(); err != nil {
+		t.Error(err)
+	} else if lex != "2020-07-01" || vt.GetID() != TextISO8601 {
+		t.Errorf("got %#v %#v, expected a normalized value", lex, vt.GetID())
+	}
+	if err := a.SetValueString("someday"); !errors.Is(err, InvalidValue) {
+		t.Errorf("got %v, expected %v", err, InvalidValue)
+	}
+}
+
+func TestStageNote_EditValue(t *testing.T) {
+	var s Stage
+	a := s.Note("a")
+	if err := a.SetValue("hello world", EmptyID); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.EditValue(5, 1, ", wide "); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.EditValue(0, 1, "j"); err != nil {
+		t.Fatal(err)
+	}
+	if lex, _, err := a.GetValue(); err != nil {
+		t.Error(err)
+	} else if expect := "jello, wide world"; lex != expect {
+		t.Errorf("got %#v, expected %#v", lex, expect)
+	}
+	if _, ok := s.Ops[len(s.Ops)-1].(OpValueDelta); !ok {
+		t.Errorf("got %#v, expected a value delta", s.Ops[len(s.Ops)-1])
+	}
+	if err := a.EditValue(10, 100, ""); err == nil {
+		t.Error("expected an error for an index out of range")
+	}
+}
+
+func TestStageNote_content(t *testing.T) {
+	var s Stage
+	a := s.Note("a")
+	expect := func(expect ...ID) {
+		t.Helper()
+		if cids, err := a.GetContentIDs(); err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(cids, expect) {
+			t.Errorf("got %v, expected %v", cids, expect)
+		}
+	}
+	if err := a.InsertContent(0, "c0", "c2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.InsertContent(1, "c1"); err != nil {
+		t.Fatal(err)
+	}
+	expect("c0", "c1", "c2")
+	if err := a.InsertContent(4, "c3"); err == nil {
+		t.Error("expected an error for an index out of range")
+	}
+	if err := a.MoveContent("c0", 2); err != nil {
+		t.Fatal(err)
+	}
+	expect("c1", "c2", "c0")
+	if err := a.MoveContent("c9", 0); !errors.Is(err, InvalidID) {
+		t.Errorf("got %v, expected %v", err, InvalidID)
+	}
+	if err := a.RemoveContent("c2"); err != nil {
+		t.Fatal(err)
+	}
+	expect("c1", "c0")
+	if err := a.ReplaceContent("c3", "c1"); err != nil {
+		t.Fatal(err)
+	}
+	expect("c3", "c1")
+}
+
+func TestStageNote_types(t *testing.T) {
+	var s Stage
+	a := s.Note("a")
+	expect := func(expect ...ID) {
+		t.Helper()
+		if tids, err := a.GetTypeIDs(); err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(tids, expect) {
+			t.Errorf("got %v, expected %v", tids, expect)
+		}
+	}
+	if err := a.InsertTypes(0, "t1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.InsertTypes(0, "t0"); err != nil {
+		t.Fatal(err)
+	}
+	expect("t0", "t1")
+	if err := a.MoveType("t1", 0); err != nil {
+		t.Fatal(err)
+	}
+	expect("t1", "t0")
+	if err := a.RemoveTypes("t1", "t2"); err != nil {
+		t.Fatal(err)
+	}
+	expect("t0")
+	if err := a.ReplaceTypes("t2", "t3"); err != nil {
+		t.Fatal(err)
+	}
+	expect("t2", "t3")
+}
+
+func TestStageNote_AddNewContent(t *testing.T) {
+	s := Stage{Base: testNoteMap()}
+	n0 := s.Note("n0")
+	c, err := n0.AddNewContent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ID == EmptyID || c.ID == "n0" || c.ID == "n1" {
+		t.Errorf("got %#v, expected a fresh ID", c.ID)
+	}
+	d, err := n0.AddNewContent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.ID == c.ID {
+		t.Errorf("got %#v twice, expected a fresh ID", d.ID)
+	}
+	if cids, err := n0.GetContentIDs(); err != nil {
+		t.Error(err)
+	} else if expect := []ID{"n1", c.ID, d.ID}; !reflect.DeepEqual(cids, expect) {
+		t.Errorf("got %v, expected %v", cids, expect)
+	}
+}
+
+func TestStage_Commit(t *testing.T) {
+	m := testNoteMap()
+	s := Stage{Base: m}
+	if err := s.Note("n0").SetValueString("goodbye"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Commit(m); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Ops) != 0 {
+		t.Errorf("got %v, expected no staged operations", s.Ops)
+	}
+	if got := m["n0"].ValueString; got != "goodbye" {
+		t.Errorf("got %#v, expected %#v", got, "goodbye")
+	}
+}
+
+func TestStage_Load(t *testing.T) {
+	s := Stage{Base: testNoteMap()}
+	if err := s.Note("n1").SetValueString("planet"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Note("t0").SetValueString("greeting"); err != nil {
+		t.Fatal(err)
+	}
+	n0, err := LoadOne(&s, "n0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs, err := n0.GetContents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lex, _, err := cs[0].GetValue(); err != nil {
+		t.Error(err)
+	} else if lex != "planet" {
+		t.Errorf("got %#v, expected staged value of content", lex)
+	}
+	ts, err := n0.GetTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lex, _, err := ts[0].GetValue(); err != nil {
+		t.Error(err)
+	} else if lex != "greeting" {
+		t.Errorf("got %#v, expected staged value of type", lex)
+	}
+	if _, err := s.Load([]ID{EmptyID}); err != InvalidID {
+		t.Errorf("got %v, expected %v", err, InvalidID)
+	}
+}
+
+func TestStage_Find(t *testing.T) {
+	s := Stage{Base: testNoteMap()}
+	if err := s.Note("n1").SetValueString("hello, world"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Note("n2").SetValue("hello there", EmptyID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Note("n0").Clear(); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		Query  Query
+		Expect []ID
+	}{
+		{Query{}, []ID{"n1", "n2"}},
+		{Query{ValuePrefix: "hello"}, []ID{"n1", "n2"}},
+		{Query{ValueContains: "world"}, []ID{"n1"}},
+		{Query{ValuePrefix: "hello", Offset: 1}, []ID{"n2"}},
+		{Query{ValuePrefix: "hello", Limit: 1}, []ID{"n1"}},
+		{Query{SubjectIdentifier: "si0"}, nil},
+		{Query{ContentOf: "n0"}, nil},
+	} {
+		ns, err := s.Find(&test.Query)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		var ids []ID
+		for _, n := range ns {
+			ids = append(ids, n.GetID())
+		}
+		if !reflect.DeepEqual(ids, test.Expect) {
+			t.Errorf("%+v: got %v, expected %v", test.Query, ids, test.Expect)
+		}
+	}
+}
+
+// knownLoader loads only the notes it contains, like a backend that rejects
+// IDs it cannot load.
+type knownLoader map[ID]TruncatedNote
+
+func (l knownLoader) Load(ids []ID) ([]GraphNote, error) {
+	ns := make([]GraphNote, len(ids))
+	for i, id := range ids {
+		tn, ok := l[id]
+		if !ok {
+			return nil, InvalidID
+		}
+		ns[i] = ExpandNote(tn, l)
+	}
+	return ns, nil
+}
+
+func TestStageNote_GetIDs_withoutLoading(t *testing.T) {
+	l := knownLoader{"n0": {ID: "n0", Contents: []ID{"c0", EmptyID}, Types: []ID{"t0"}}}
+	x := (&Stage{Base: l}).Note("n0")
+	if cids, err := x.GetContentIDs(); err != nil {
+		t.Error(err)
+	} else if expect := []ID{"c0", EmptyID}; !reflect.DeepEqual([]ID(cids), expect) {
+		t.Errorf("got content %v, expected %v", cids, expect)
+	}
+	if tids, err := x.GetTypeIDs(); err != nil {
+		t.Error(err)
+	} else if expect := []ID{"t0"}; !reflect.DeepEqual([]ID(tids), expect) {
+		t.Errorf("got types %v, expected %v", tids, expect)
+	}
+}
+
 func TestStage_Base_notNil(t *testing.T) {
 	var s Stage
 	if s.GetBase() == nil {
@@ -0,0 +1,100 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textile
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/google/note-maps/note"
+	"github.com/textileio/go-threads/db"
+)
+
+// parentIndex maps the ID of each note to the IDs of the notes that include
+// it in their content.
+//
+// The index is kept in memory rather than in ThreadsDB, since ThreadsDB
+// holds one lock for the transactions of every collection, so that no other
+// collection can be read within a transaction on the Note collection. It is
+// built from the records of every note when it is first needed, updated from
+// the write log of each write made through the Database, and discarded
+// whenever a change arrives through replication.
+//
+// The zero value is an index that has not been built.
+type parentIndex struct {
+	mu      sync.Mutex
+	parents map[note.ID]map[note.ID]bool
+}
+
+// load returns the IDs of the parents of each of ids in order, building the
+// index from the records read through r if necessary.
+func (x *parentIndex) load(r reader, ids []note.ID) ([][]note.ID, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.parents == nil {
+		recs, err := r.find(&db.Query{})
+		if err != nil {
+			return nil, err
+		}
+		x.parents = make(map[note.ID]map[note.ID]bool)
+		for i := range recs {
+			x.add(note.ID(recs[i].ID), recs[i].Contents)
+		}
+	}
+	parents := make([][]note.ID, len(ids))
+	for i, id := range ids {
+		for p := range x.parents[id] {
+			parents[i] = append(parents[i], p)
+		}
+		sort.Slice(parents[i], func(a, b int) bool { return parents[i][a] < parents[i][b] })
+	}
+	return parents, nil
+}
+
+// update applies the changes to content recorded in wl.
+func (x *parentIndex) update(wl *writeLog) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.parents == nil {
+		return
+	}
+	for id, cids := range wl.contents {
+		for _, c := range idsWithout(wl.prior[id], cids) {
+			delete(x.parents[c], id)
+			if len(x.parents[c]) == 0 {
+				delete(x.parents, c)
+			}
+		}
+		x.add(id, idsWithout(cids, wl.prior[id]))
+	}
+}
+
+// add records parent as a parent of each of cids. x.mu must be locked.
+func (x *parentIndex) add(parent note.ID, cids []note.ID) {
+	for _, c := range cids {
+		if x.parents[c] == nil {
+			x.parents[c] = make(map[note.ID]bool)
+		}
+		x.parents[c][parent] = true
+	}
+}
+
+// invalidate discards the index, so that it is built again when it is next
+// needed.
+func (x *parentIndex) invalidate() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.parents = nil
+}
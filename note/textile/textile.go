@@ -17,11 +17,15 @@
 package textile
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
+	"unicode"
 
 	"github.com/google/note-maps/note"
 	"github.com/google/note-maps/note/truncated"
@@ -64,6 +68,19 @@ type Options struct {
 
 	// SetSecret will be used to store the thread encyrption keys.
 	SetSecret
+
+	// Datatypes, if not nil, is used to normalize values as they are written
+	// and to warn about invalid values as they are read.
+	Datatypes note.DatatypeRegistry
+
+	// Warn, if not nil, is called with each problem that does not prevent a
+	// read from succeeding, such as a value that is invalid for its datatype.
+	Warn func(error)
+
+	// Validate, if true, causes writes to be refused if they would leave any
+	// changed note, or any parent of a changed note, in violation of the
+	// constraints declared by its types. See note.ValidatePatch.
+	Validate bool
 }
 
 func (o *Options) expand() error {
@@ -110,6 +127,19 @@ func WithGetSecret(f GetSecret) Option {
 func WithSetSecret(f SetSecret) Option {
 	return func(o *Options) { o.SetSecret = f }
 }
+func WithDatatypes(r note.DatatypeRegistry) Option {
+	return func(o *Options) { o.Datatypes = r }
+}
+func WithWarnings(f func(error)) Option {
+	return func(o *Options) { o.Warn = f }
+}
+
+// WithValidation sets Options.Validate, so that every patch is refused with a
+// note.ValidationError if it would leave a note, or a parent of a note, in
+// violation of the constraints of its types.
+func WithValidation() Option {
+	return func(o *Options) { o.Validate = true }
+}
 
 // DefaultNetwork is a convenience function to build a
 // github.com/textileio/core/app.Net for use with Open().
@@ -127,6 +157,20 @@ type Database struct {
 	initOnce sync.Once
 	note     *db.Collection
 	broke    error
+	dts      note.DatatypeRegistry
+	warn     func(error)
+	validate bool
+	closed   int32 // accessed atomically
+
+	watchMu  sync.Mutex
+	watchers map[*watcher]bool
+	listener db.Listener
+
+	// expected holds the encoded record most recently written through x for
+	// each note, until ThreadsDB reports a different state for that note.
+	expected map[note.ID][]byte
+
+	parents parentIndex
 }
 
 // Open creates a Database that replicates through net n.
@@ -150,7 +194,7 @@ func Open(ctx context.Context, n app.Net, opts ...Option) (*Database, error) {
 		return nil, wrapError("parsing thread key", err)
 	}
 	path := filepath.Join(o.BaseDirectory, o.Thread)
-	d, err := db.NewDB(context.Background(), n, tid,
+	d, err := db.NewDB(ctx, n, tid,
 		db.WithNewKey(key), db.WithNewRepoPath(path))
 	if err != nil {
 		return nil, wrapError("connecting to database", err)
@@ -162,86 +206,258 @@ func Open(ctx context.Context, n app.Net, opts ...Option) (*Database, error) {
 			return nil, wrapError("storing thread key", err)
 		}
 	}
-	return &Database{t: d, id: tid}, nil
+	return &Database{t: d, id: tid, dts: o.Datatypes, warn: o.Warn, validate: o.Validate}, nil
 }
 
 func (x *Database) init() error {
+	if atomic.LoadInt32(&x.closed) != 0 {
+		return note.ClosedDatabase
+	}
 	x.initOnce.Do(func() {
-		cs := x.t.ListCollections()
-		for _, c := range cs {
-			if c.GetName() == "Note" {
-				x.note = c
-			}
+		if x.broke = x.openCollection(); x.broke == nil {
+			x.broke = x.startListening()
 		}
-		if x.note != nil {
-			return
+	})
+	return x.broke
+}
+
+// openCollection sets x.note to the Note collection, creating it or updating
+// its schema as necessary.
+func (x *Database) openCollection() error {
+	config := db.CollectionConfig{
+		Name:   "Note",
+		Schema: util.SchemaFromInstance(&record{}, false),
+	}
+	cs := x.t.ListCollections()
+	for _, c := range cs {
+		if c.GetName() == "Note" {
+			x.note = c
 		}
+	}
+	if x.note == nil {
 		var err error
-		x.note, err = x.t.NewCollection(db.CollectionConfig{
-			Name:   "Note",
-			Schema: util.SchemaFromInstance(&record{}, false),
-		})
+		x.note, err = x.t.NewCollection(config)
 		if err != nil {
-			x.broke = wrapError("creating note schema in database", err)
+			return wrapError("creating note schema in database", err)
 		}
-	})
-	return x.broke
+		return nil
+	}
+	schema, err := json.Marshal(config.Schema)
+	if err != nil {
+		return wrapError("encoding note schema", err)
+	}
+	if bytes.Equal(x.note.GetSchema(), schema) {
+		return nil
+	}
+	// The database was created by an older version of this package.
+	x.note, err = x.t.UpdateCollection(config)
+	if err != nil {
+		return wrapError("updating note schema in database", err)
+	}
+	return nil
 }
 
-func (x *Database) Close() error { return x.t.Close() }
+func (x *Database) Close() error {
+	if !atomic.CompareAndSwapInt32(&x.closed, 0, 1) {
+		return note.ClosedDatabase
+	}
+	x.watchMu.Lock()
+	if x.listener != nil {
+		// ThreadsDB deadlocks if it is closed while it still has listeners.
+		x.listener.Close()
+		x.listener = nil
+	}
+	x.watchMu.Unlock()
+	return x.t.Close()
+}
 
 func (x *Database) IsolatedRead(f func(r note.FindLoader) error) error {
+	return x.IsolatedReadContext(context.Background(), f)
+}
+
+// IsolatedReadContext implements note.ContextDatabaseReader.
+//
+// Every read through the FindLoader passed to f, including reads made while
+// traversing the notes it returns, fails once ctx is done.
+func (x *Database) IsolatedReadContext(ctx context.Context, f func(r note.FindLoader) error) error {
 	if err := x.init(); err != nil {
 		return err
 	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	return x.note.ReadTxn(func(t *db.Txn) error {
-		r := reader{t}
+		r := reader{t, x.dts, x.warn, ctx, &x.parents}
 		lr := truncated.ExpandLoader(r)
 		fr := truncated.ExpandFinder(r, lr)
-		return f(findloader{fr, lr})
+		return f(findloader{fr, lr, r})
 	})
 }
 
 func (x *Database) IsolatedWrite(f func(rw note.FindLoadPatcher) error) error {
+	return x.IsolatedWriteContext(context.Background(), f)
+}
+
+// IsolatedWriteContext implements note.ContextDatabaseWriter.
+//
+// Every read and write through the FindLoadPatcher passed to f fails once ctx
+// is done, and no changes are saved if ctx is done by the time f returns.
+func (x *Database) IsolatedWriteContext(ctx context.Context, f func(rw note.FindLoadPatcher) error) error {
 	if err := x.init(); err != nil {
 		return err
 	}
-	return x.note.WriteTxn(func(t *db.Txn) error {
-		r := reader{t}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var (
+		wl      writeLog
+		prev    map[note.ID][]byte
+		indexed bool
+	)
+	err := x.note.WriteTxn(func(t *db.Txn) error {
+		r := reader{t, x.dts, x.warn, ctx, &x.parents}
 		lr := truncated.ExpandLoader(r)
 		fr := truncated.ExpandFinder(r, lr)
-		return f(findloadpatcher{fr, lr, r})
+		if err := f(findloadpatcher{fr, lr, r, &wl, x.validate}); err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		prev = x.expect(wl.written)
+		x.parents.update(&wl)
+		indexed = true
+		return nil
 	})
+	if err != nil {
+		x.expect(prev)
+		if indexed {
+			x.parents.invalidate()
+		}
+		return err
+	}
+	x.committed(wl.ops)
+	return nil
 }
 
 func (x *Database) GetThreadID() thread.ID { return x.id }
 
 type (
-	reader     struct{ Txn *db.Txn }
+	reader struct {
+		Txn  *db.Txn
+		dts  note.DatatypeRegistry
+		warn func(error)
+
+		// ctx is the context of the whole transaction. Notes loaded through
+		// the reader load their neighbours without a context of their own,
+		// so every read checks this one.
+		ctx context.Context
+
+		parents *parentIndex
+	}
 	findloader struct {
 		note.Finder
 		note.Loader
+		r reader
 	}
 	findloadpatcher struct {
 		note.Finder
 		note.Loader
-		r reader
+		r        reader
+		log      *writeLog
+		validate bool
+	}
+
+	// writeLog records the changes made within a single call to
+	// IsolatedWrite.
+	writeLog struct {
+		ops []note.Operation
+
+		// written holds the encoded record written for each note, as
+		// returned by encodeRecord.
+		written map[note.ID][]byte
+
+		// prior and contents hold the content of each note written, before
+		// and after the write.
+		prior, contents map[note.ID][]note.ID
 	}
 )
 
+func (r findloader) LoadParentIDs(ids []note.ID) ([][]note.ID, error) {
+	return r.r.LoadParentIDs(ids)
+}
+
+func (w findloadpatcher) LoadParentIDs(ids []note.ID) ([][]note.ID, error) {
+	return w.r.LoadParentIDs(ids)
+}
+
 func (r reader) FindNoteIDs(q *note.Query) ([]note.ID, error) {
-	recs, err := r.find(&db.Query{})
-	if err != nil {
-		return nil, err
+	if q == nil {
+		q = &note.Query{}
+	}
+	var recs []record
+	if q.ContentOf.Empty() {
+		var err error
+		recs, err = r.find(dbQuery(q))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var parent record
+		if err := r.loadRecord(q.ContentOf, &parent); err != nil {
+			return nil, err
+		}
+		recs = make([]record, len(parent.Contents))
+		for i, id := range parent.Contents {
+			if err := r.loadRecord(id, &recs[i]); err != nil {
+				return nil, err
+			}
+		}
 	}
-	ids := make([]note.ID, len(recs))
+	ids := make([]note.ID, 0, len(recs))
 	for i := range recs {
-		ids[i] = note.ID(recs[i].ID)
+		if q.Match(recs[i].truncate()) {
+			ids = append(ids, note.ID(recs[i].ID))
+		}
+	}
+	return q.Page(ids), nil
+}
+
+// dbQuery returns a ThreadsDB query that narrows the records to be considered
+// for q.
+//
+// Criteria that ThreadsDB cannot express, like substring matches and
+// membership in a list of types, must still be checked with q.Match.
+func dbQuery(q *note.Query) *db.Query {
+	dq := &db.Query{}
+	if !q.ValueType.Empty() {
+		dq = dq.And("value_type").Eq(string(q.ValueType))
+	}
+	if q.ValuePrefix != "" {
+		dq = dq.And("value_string").Ge(q.ValuePrefix)
+		if end, ok := prefixEnd(q.ValuePrefix); ok {
+			dq = dq.And("value_string").Lt(end)
+		}
 	}
-	return ids, nil
+	return dq
+}
+
+// prefixEnd returns the least string that is greater than every string that
+// begins with p.
+func prefixEnd(p string) (string, bool) {
+	rs := []rune(p)
+	for i := len(rs) - 1; i >= 0; i-- {
+		if rs[i] < unicode.MaxRune {
+			return string(append(rs[:i], rs[i]+1)), true
+		}
+	}
+	return "", false
 }
 
 func (r reader) find(q *db.Query) ([]record, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
 	bss, err := r.Txn.Find(q)
 	if err != nil {
 		return nil, err
@@ -255,11 +471,26 @@ func (r reader) find(q *db.Query) ([]record, error) {
 	return records, nil
 }
 
+// LoadParentIDs implements note.ParentLoader, returning the parents of each
+// note in order of their IDs.
+func (r reader) LoadParentIDs(ids []note.ID) ([][]note.ID, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.parents.load(r, ids)
+}
+
 func (r reader) has(id note.ID) (bool, error) {
+	if err := r.ctx.Err(); err != nil {
+		return false, err
+	}
 	return r.Txn.Has(core.InstanceID(id))
 }
 
 func (r reader) loadRecord(id note.ID, rec *record) error {
+	if err := r.ctx.Err(); err != nil {
+		return err
+	}
 	bs, err := r.Txn.FindByID(core.InstanceID(id))
 	if err != nil {
 		if errors.Is(err, db.ErrInstanceNotFound) {
@@ -274,66 +505,130 @@ func (r reader) loadRecord(id note.ID, rec *record) error {
 func (r reader) LoadTruncatedNotes(ids []note.ID) ([]note.TruncatedNote, error) {
 	tns := make([]note.TruncatedNote, len(ids))
 	for i, id := range ids {
+		if id.Empty() {
+			return nil, note.InvalidID
+		}
 		var rec record
 		if err := r.loadRecord(id, &rec); err != nil {
 			return nil, err
 		}
-		tns[i] = note.TruncatedNote{
-			ID:          note.ID(rec.ID),
-			ValueString: rec.ValueString,
-			ValueType:   rec.ValueType,
-			Contents:    rec.Contents,
+		tns[i] = rec.truncate()
+		if _, err := r.dts.Normalize(rec.ValueType, rec.ValueString); err != nil && r.warn != nil {
+			// Invalid values may have been written by older or more permissive
+			// clients, and they must still be readable.
+			r.warn(wrapError("loading "+string(id), err))
 		}
 	}
 	return tns, nil
 }
 
 func (w findloadpatcher) Patch(ops []note.Operation) error {
+	if err := w.r.ctx.Err(); err != nil {
+		return err
+	}
+	ops, err := w.r.dts.NormalizeOps(w, ops)
+	if err != nil {
+		return wrapError("while validating values", err)
+	}
+	if w.validate {
+		if err := note.ValidatePatch(w, ops); err != nil {
+			return wrapError("while validating notes", err)
+		}
+	}
 	stage := note.Stage{
 		Ops:  ops,
 		Base: w,
 	}
-	ids := make(map[note.ID]bool)
+	var (
+		ids     = make(map[note.ID]bool)
+		changed []note.ID
+	)
 	for _, op := range ops {
-		switch o := op.(type) {
-		case note.OpSetValue:
-			ids[o.GetID()] = true
-		case note.OpContentDelta:
-			ids[o.GetID()] = true
+		switch op.(type) {
+		case note.OpSetValue, note.OpSetValueString, note.OpValueDelta,
+			note.OpContentDelta, note.OpTypesDelta, note.OpRolesDelta,
+			note.OpSubjectIdentifiersDelta, note.OpClearNote:
+			id := op.(interface{ GetID() note.ID }).GetID()
+			if !ids[id] {
+				ids[id] = true
+				changed = append(changed, id)
+			}
 		default:
-			panic("unrecognized op type")
+			return wrapError("patching", fmt.Errorf("%T: %w", op, note.UnsupportedOperation))
 		}
 	}
+	if ids[note.EmptyID] {
+		return wrapError("patching", note.InvalidID)
+	}
 	var (
 		creates, saves [][]byte
+		deletes        []core.InstanceID
+		written        = make(map[note.ID][]byte)
+		prior          = make(map[note.ID][]note.ID)
+		contents       = make(map[note.ID][]note.ID)
 	)
-	for id := range ids {
+	for _, id := range changed {
 		n := stage.Note(id)
 		vs, vt, err := n.GetValue()
 		if err != nil {
 			return wrapError("while calculating resulting state for "+string(id), err)
 		}
-		cs, err := n.GetContents()
+		cids, err := n.GetContentIDs()
 		if err != nil {
 			return wrapError("while calculating resulting state for "+string(id), err)
 		}
-		cids := make([]note.ID, len(cs))
-		for i := range cs {
-			cids[i] = cs[i].GetID()
+		tids, err := n.GetTypeIDs()
+		if err != nil {
+			return wrapError("while calculating resulting state for "+string(id), err)
+		}
+		rs, err := n.GetRoles()
+		if err != nil {
+			return wrapError("while calculating resulting state for "+string(id), err)
 		}
-		bs, err := json.Marshal(&record{
+		sis, err := n.GetSubjectIdentifiers()
+		if err != nil {
+			return wrapError("while calculating resulting state for "+string(id), err)
+		}
+		rec := record{
 			ID:          core.InstanceID(id),
 			ValueString: vs,
 			ValueType:   vt.GetID(),
 			Contents:    cids,
-		})
-		if updating, err := w.r.has(id); err != nil {
+			Types:       tids,
+			Roles:       fromRoles(rs),
+
+			SubjectIdentifiers: sis,
+		}
+		var before record
+		if err := w.r.loadRecord(id, &before); err != nil {
+			return wrapError("while loading "+string(id), err)
+		}
+		prior[id], contents[id] = before.Contents, cids
+		updating, err := w.r.has(id)
+		if err != nil {
 			return wrapError("while checking for existence of "+string(id), err)
-		} else if updating {
+		}
+		if rec.truncate().Empty() {
+			// Empty notes are indistinguishable from missing notes, so there is
+			// no need to keep a record for them.
+			if updating {
+				deletes = append(deletes, rec.ID)
+				if written[id], err = encodeRecord(&record{ID: rec.ID}); err != nil {
+					return wrapError("while encoding "+string(id), err)
+				}
+			}
+			continue
+		}
+		bs, err := encodeRecord(&rec)
+		if err != nil {
+			return wrapError("while encoding "+string(id), err)
+		}
+		if updating {
 			saves = append(saves, bs)
 		} else {
 			creates = append(creates, bs)
 		}
+		written[id] = bs
 	}
 	if len(creates) > 0 {
 		if _, err := w.r.Txn.Create(creates...); err != nil {
@@ -345,12 +640,102 @@ func (w findloadpatcher) Patch(ops []note.Operation) error {
 			return wrapError("while saving notes", err)
 		}
 	}
+	if len(deletes) > 0 {
+		if err := w.r.Txn.Delete(deletes...); err != nil {
+			return wrapError("while deleting notes", err)
+		}
+	}
+	w.log.ops = append(w.log.ops, ops...)
+	if w.log.written == nil {
+		w.log.written = make(map[note.ID][]byte)
+	}
+	for id, bs := range written {
+		w.log.written[id] = bs
+	}
+	if w.log.prior == nil {
+		w.log.prior = make(map[note.ID][]note.ID)
+		w.log.contents = make(map[note.ID][]note.ID)
+	}
+	for id, cids := range contents {
+		if _, ok := w.log.prior[id]; !ok {
+			w.log.prior[id] = prior[id]
+		}
+		w.log.contents[id] = cids
+	}
 	return nil
 }
 
+// record is the representation of a note in ThreadsDB.
+//
+// Fields that are used in ThreadsDB queries must not be omitted when empty,
+// since ThreadsDB cannot match a record that is missing a queried field.
 type record struct {
 	ID          core.InstanceID `json:"_id"`
-	ValueString string          `json:"value_string,omitempty"`
-	ValueType   note.ID         `json:"value_type,omitempty"`
+	ValueString string          `json:"value_string"`
+	ValueType   note.ID         `json:"value_type"`
 	Contents    []note.ID       `json:"contents,omitempty"`
+	Types       []note.ID       `json:"types,omitempty"`
+	Roles       []roleRecord    `json:"roles,omitempty"`
+
+	SubjectIdentifiers []string `json:"subject_identifiers,omitempty"`
+}
+
+// encodeRecord returns the JSON encoding of rec, which is the same for any two
+// records that represent the same note.
+func encodeRecord(rec *record) ([]byte, error) { return json.Marshal(rec) }
+
+type roleRecord struct {
+	Type   note.ID `json:"type,omitempty"`
+	Player note.ID `json:"player"`
+}
+
+func fromRoles(rs []note.Role) []roleRecord {
+	if len(rs) == 0 {
+		return nil
+	}
+	rrs := make([]roleRecord, len(rs))
+	for i, r := range rs {
+		rrs[i] = roleRecord{r.Type, r.Player}
+	}
+	return rrs
+}
+
+func (rec *record) truncate() note.TruncatedNote {
+	return note.TruncatedNote{
+		ID:          note.ID(rec.ID),
+		ValueString: rec.ValueString,
+		ValueType:   rec.ValueType,
+		Contents:    rec.Contents,
+		Types:       rec.Types,
+		Roles:       rec.roles(),
+
+		SubjectIdentifiers: rec.SubjectIdentifiers,
+	}
+}
+
+// idsWithout returns the IDs in ids that are not in del, without duplicates.
+func idsWithout(ids, del []note.ID) []note.ID {
+	skip := make(map[note.ID]bool, len(del))
+	for _, id := range del {
+		skip[id] = true
+	}
+	var result []note.ID
+	for _, id := range ids {
+		if !skip[id] {
+			skip[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func (rec *record) roles() []note.Role {
+	if len(rec.Roles) == 0 {
+		return nil
+	}
+	rs := make([]note.Role, len(rec.Roles))
+	for i, r := range rec.Roles {
+		rs[i] = note.Role{Type: r.Type, Player: r.Player}
+	}
+	return rs
 }
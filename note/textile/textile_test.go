@@ -19,11 +19,18 @@ import (
 	"errors"
 	"io/ioutil"
 	"os"
+	"reflect"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/note-maps/note"
 	"github.com/google/note-maps/note/notetest"
+	"github.com/google/note-maps/otgen/runes"
 	"github.com/textileio/go-threads/core/app"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/db"
+	"github.com/textileio/go-threads/util"
 )
 
 // TestPatchLoad applies some simple operations to a note map and verifies
@@ -49,6 +56,7 @@ func TestPatchLoad(t *testing.T) {
 	var stage note.Stage
 	stage.Note("test1").SetValue("Title1", note.EmptyID)
 	stage.Note("test2").SetValue("Title2", note.EmptyID)
+	stage.Note("test3").AddRole("role", "test1")
 	if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
 		return w.Patch(stage.Ops)
 	}); err != nil {
@@ -57,13 +65,13 @@ func TestPatchLoad(t *testing.T) {
 	var ns []note.GraphNote
 	if err := nm.IsolatedRead(func(r note.FindLoader) error {
 		var e error
-		ns, e = r.Load([]note.ID{"test1", "test2"})
+		ns, e = r.Load([]note.ID{"test1", "test2", "test3"})
 		return e
 	}); err != nil {
 		t.Fatal(err)
 	}
-	if len(ns) != 2 {
-		t.Errorf("got %v notes, expected 2", len(ns))
+	if len(ns) != 3 {
+		t.Errorf("got %v notes, expected 3", len(ns))
 	}
 	if len(ns) > 0 {
 		notetest.ExpectEqual(t, ns[0], stage.Note("test1"))
@@ -71,6 +79,674 @@ func TestPatchLoad(t *testing.T) {
 	if len(ns) > 1 {
 		notetest.ExpectEqual(t, ns[1], stage.Note("test2"))
 	}
+	if len(ns) > 2 {
+		notetest.ExpectEqual(t, ns[2], stage.Note("test3"))
+	}
+}
+
+// TestFind verifies that query criteria limit the notes that are found.
+func TestFind(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that uses IO and network")
+	}
+	dir, rmdir := testDir(t)
+	defer rmdir()
+	n := defaultNetwork(t, dir)
+	defer n.Close()
+	nm := open(t, n, WithBaseDirectory(dir))
+	defer nm.Close()
+	var stage note.Stage
+	stage.Note("parent").SetValue("parent", note.EmptyID)
+	stage.Note("parent").AddContent("c1")
+	stage.Note("parent").AddContent("c0")
+	stage.Note("c0").SetValue("hello world", "vt")
+	stage.Note("c0").InsertTypes(0, "t0")
+	stage.Note("c1").SetValue("goodbye world", note.EmptyID)
+	stage.Note("c1").InsertSubjectIdentifiers(0, "https://example.com/c1")
+	if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+		return w.Patch(stage.Ops)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		Q      note.Query
+		Expect []note.ID
+	}{
+		{note.Query{ValueContains: "world", Limit: 1, Offset: 1}, nil},
+		{note.Query{ValueContains: "hello"}, []note.ID{"c0"}},
+		{note.Query{ValuePrefix: "good"}, []note.ID{"c1"}},
+		{note.Query{ValueType: "vt"}, []note.ID{"c0"}},
+		{note.Query{AllTypes: []note.ID{"t0"}}, []note.ID{"c0"}},
+		{note.Query{ContentOf: "parent"}, []note.ID{"c1", "c0"}},
+		{note.Query{ContentOf: "parent", Offset: 1}, []note.ID{"c0"}},
+	} {
+		var ids []note.ID
+		if err := nm.IsolatedRead(func(r note.FindLoader) error {
+			ns, err := r.Find(&test.Q)
+			for _, n := range ns {
+				ids = append(ids, n.GetID())
+			}
+			return err
+		}); err != nil {
+			t.Errorf("%#v: %v", test.Q, err)
+		} else if test.Expect != nil && !reflect.DeepEqual(ids, test.Expect) {
+			t.Errorf("%#v: got %v, expected %v", test.Q, ids, test.Expect)
+		} else if test.Expect == nil && len(ids) != 1 {
+			t.Errorf("%#v: got %v, expected one note", test.Q, ids)
+		}
+	}
+	if err := nm.IsolatedRead(func(r note.FindLoader) error {
+		ns, err := r.FindBySubjectIdentifier("https://example.com/c1")
+		if err != nil {
+			return err
+		}
+		if len(ns) != 1 || ns[0].GetID() != "c1" {
+			t.Errorf("got %v, expected only c1", ns)
+		} else if sis, err := ns[0].GetSubjectIdentifiers(); err != nil {
+			return err
+		} else if !reflect.DeepEqual(sis, []string{"https://example.com/c1"}) {
+			t.Errorf("got %v, expected the subject identifier of c1", sis)
+		}
+		return nil
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestClear verifies that cleared notes are no longer found.
+func TestClear(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that uses IO and network")
+	}
+	dir, rmdir := testDir(t)
+	defer rmdir()
+	n := defaultNetwork(t, dir)
+	defer n.Close()
+	nm := open(t, n, WithBaseDirectory(dir))
+	defer nm.Close()
+	var stage note.Stage
+	stage.Note("test0").SetValue("hello", note.EmptyID)
+	stage.Note("test1").SetValue("world", note.EmptyID)
+	if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+		return w.Patch(stage.Ops)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+		return w.Patch(note.OperationSlice{}.ClearNote("test0"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nm.IsolatedRead(func(r note.FindLoader) error {
+		ns, err := r.Find(&note.Query{})
+		if err != nil {
+			return err
+		}
+		if len(ns) != 1 || ns[0].GetID() != "test1" {
+			t.Errorf("got %v, expected only test1", ns)
+		}
+		ns, err = r.Load([]note.ID{"test0"})
+		if err != nil {
+			return err
+		}
+		notetest.ExpectEqual(t, ns[0], note.EmptyNote("test0"))
+		return nil
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestDatatypes verifies that values are normalized and validated when a
+// datatype registry is provided.
+func TestDatatypes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that uses IO and network")
+	}
+	dir, rmdir := testDir(t)
+	defer rmdir()
+	n := defaultNetwork(t, dir)
+	defer n.Close()
+	var warnings []error
+	nm := open(t, n, WithBaseDirectory(dir), WithDatatypes(note.NewDatatypeRegistry()),
+		WithWarnings(func(err error) { warnings = append(warnings, err) }))
+	defer nm.Close()
+	if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+		return w.Patch(note.OperationSlice{}.SetValue("test0", "2020-06-01T12:00:00+00:00", note.TextISO8601))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+		return w.Patch(note.OperationSlice{}.SetValue("test1", "noon", note.TextISO8601))
+	}); !errors.Is(err, note.InvalidValue) {
+		t.Errorf("got %v, expected %v", err, note.InvalidValue)
+	}
+	if err := nm.IsolatedRead(func(r note.FindLoader) error {
+		ns, err := r.Load([]note.ID{"test0"})
+		if err != nil {
+			return err
+		}
+		if lex, _, err := ns[0].GetValue(); err != nil {
+			return err
+		} else if lex != "2020-06-01T12:00:00Z" {
+			t.Errorf("got %#v, expected a normalized value", lex)
+		}
+		return nil
+	}); err != nil {
+		t.Error(err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("got unexpected warnings %v", warnings)
+	}
+	// Invalid values written by other clients are still readable.
+	if err := nm.note.WriteTxn(func(t *db.Txn) error {
+		_, err := t.Create(util.JSONFromInstance(record{
+			ID: "test2", ValueString: "noon", ValueType: note.TextISO8601}))
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nm.IsolatedRead(func(r note.FindLoader) error {
+		_, err := r.Load([]note.ID{"test2"})
+		return err
+	}); err != nil {
+		t.Error(err)
+	}
+	if len(warnings) != 1 || !errors.Is(warnings[0], note.InvalidValue) {
+		t.Errorf("got warnings %v, expected one about an invalid value", warnings)
+	}
+}
+
+func TestPatchValue(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that uses IO and network")
+	}
+	dir, rmdir := testDir(t)
+	defer rmdir()
+	n := defaultNetwork(t, dir)
+	defer n.Close()
+	nm := open(t, n, WithBaseDirectory(dir))
+	defer nm.Close()
+	if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+		return w.Patch(note.OperationSlice{}.SetValueString("test0", "hello"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+		return w.Patch(note.OperationSlice{}.
+			PatchValue("test0", runes.StringDiff([]rune("hello"), []rune("jello, world"))))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nm.IsolatedRead(func(r note.FindLoader) error {
+		ns, err := r.Load([]note.ID{"test0"})
+		if err != nil {
+			return err
+		}
+		if lex, _, err := ns[0].GetValue(); err != nil {
+			return err
+		} else if lex != "jello, world" {
+			t.Errorf("got %#v, expected %#v", lex, "jello, world")
+		}
+		return nil
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestContext(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that uses IO and network")
+	}
+	dir, rmdir := testDir(t)
+	defer rmdir()
+	n := defaultNetwork(t, dir)
+	defer n.Close()
+	nm := open(t, n, WithBaseDirectory(dir))
+	defer nm.Close()
+	var _ note.ContextDatabase = nm
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := nm.IsolatedWriteContext(ctx, func(w note.FindLoadPatcher) error {
+		return w.Patch(note.OperationSlice{}.
+			SetValueString("test0", "hello").
+			PatchContent("test0", note.IDSlice{}.Append("test1")))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	err := nm.IsolatedWriteContext(ctx, func(w note.FindLoadPatcher) error {
+		if err := w.Patch(note.OperationSlice{}.SetValueString("test0", "goodbye")); err != nil {
+			return err
+		}
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("write: expected %v, got %v", context.Canceled, err)
+	}
+	if err := nm.IsolatedRead(func(r note.FindLoader) error {
+		ns, err := r.Load([]note.ID{"test0"})
+		if err != nil {
+			return err
+		}
+		if lex, _, err := ns[0].GetValue(); err != nil {
+			return err
+		} else if lex != "hello" {
+			t.Errorf("got %#v, expected cancelled write to leave %#v", lex, "hello")
+		}
+		return nil
+	}); err != nil {
+		t.Error(err)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	err = nm.IsolatedReadContext(ctx, func(r note.FindLoader) error {
+		ns, err := r.Load([]note.ID{"test0"})
+		if err != nil {
+			return err
+		}
+		cancel()
+		_, err = ns[0].GetContents()
+		return err
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("read: expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that uses IO and network")
+	}
+	dir, rmdir := testDir(t)
+	defer rmdir()
+	n := defaultNetwork(t, dir)
+	defer n.Close()
+	nm := open(t, n, WithBaseDirectory(dir), WithDatatypes(note.NewDatatypeRegistry()))
+	for _, test := range []struct {
+		Title string
+		Ops   note.OperationSlice
+		Is    error
+	}{
+		{"conflicting delta",
+			note.OperationSlice{}.PatchContent("test0", note.IDSlice{}.Delete(0, 1)),
+			note.ConflictingDelta},
+		{"invalid value",
+			note.OperationSlice{}.SetValue("test0", "yesterday", note.TextISO8601),
+			note.ValidationFailed},
+		{"empty id",
+			note.OperationSlice{}.SetValueString(note.EmptyID, "value"),
+			note.InvalidID},
+	} {
+		err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+			return w.Patch(test.Ops)
+		})
+		if !errors.Is(err, test.Is) {
+			t.Errorf("%v: got %v, expected %v", test.Title, err, test.Is)
+		}
+	}
+	if err := nm.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := nm.IsolatedRead(func(note.FindLoader) error { return nil }); !errors.Is(err, note.ClosedDatabase) {
+		t.Errorf("read: got %v, expected %v", err, note.ClosedDatabase)
+	}
+	if _, _, err := nm.Watch(); !errors.Is(err, note.ClosedDatabase) {
+		t.Errorf("watch: got %v, expected %v", err, note.ClosedDatabase)
+	}
+	if err := nm.Close(); !errors.Is(err, note.ClosedDatabase) {
+		t.Errorf("close: got %v, expected %v", err, note.ClosedDatabase)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that uses IO and network")
+	}
+	dir, rmdir := testDir(t)
+	defer rmdir()
+	n := defaultNetwork(t, dir)
+	defer n.Close()
+	nm := open(t, n, WithBaseDirectory(dir), WithValidation())
+	defer nm.Close()
+	if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+		return w.Patch(note.OperationSlice{}.
+			PatchContent("person", note.IDSlice{}.Append("dt")).
+			SetValueString("dt", string(note.TextPlain)).
+			PatchTypes("dt", note.IDSlice{}.Append(note.TypeDatatype)))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	write := func(ops note.OperationSlice) error {
+		return nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+			return w.Patch(ops)
+		})
+	}
+	if err := write(note.OperationSlice{}.SetValue("alice", "Alice", note.TextPlain).
+		PatchTypes("alice", note.IDSlice{}.Append("person"))); err != nil {
+		t.Error(err)
+	}
+	err := write(note.OperationSlice{}.SetValue("bob", "2020-01-01", note.TextISO8601).
+		PatchTypes("bob", note.IDSlice{}.Append("person")))
+	var verr note.ValidationError
+	if !errors.Is(err, note.ValidationFailed) || !errors.As(err, &verr) {
+		t.Errorf("got %v, expected a validation error", err)
+	} else if len(verr) != 1 || verr[0].ID != "bob" {
+		t.Errorf("got %v, expected one violation by bob", verr)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that uses IO and network")
+	}
+	dir, rmdir := testDir(t)
+	defer rmdir()
+	n := defaultNetwork(t, dir)
+	defer n.Close()
+	nm := open(t, n, WithBaseDirectory(dir))
+	var _ note.DatabaseWatcher = nm
+	changes, stop, err := nm.Watch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+	stopped, stop2, err := nm.Watch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop2()
+	if _, ok := <-stopped; ok {
+		t.Error("expected channel to be closed by stop")
+	}
+	for _, ops := range []note.OperationSlice{
+		note.OperationSlice{}.SetValueString("test0", "hello").SetValueString("test1", "world"),
+		note.OperationSlice{}.ClearNote("test0"),
+	} {
+		if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+			return w.Patch(ops)
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+		if err := w.Patch(note.OperationSlice{}.SetValueString("test2", "discarded")); err != nil {
+			return err
+		}
+		return errors.New("abort")
+	}); err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, expect := range [][]note.ID{{"test0", "test1"}, {"test0"}} {
+		c := <-changes
+		if !reflect.DeepEqual(c.IDs, expect) {
+			t.Errorf("got %v, expected %v", c.IDs, expect)
+		}
+		if len(c.Ops) == 0 {
+			t.Errorf("got %#v, expected operations", c)
+		}
+	}
+	if err := nm.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for c := range changes {
+		t.Errorf("got unexpected change %#v", c)
+	}
+}
+
+func TestWatch_droppedNotification(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that uses IO and network")
+	}
+	dir, rmdir := testDir(t)
+	defer rmdir()
+	n := defaultNetwork(t, dir)
+	defer n.Close()
+	nm := open(t, n, WithBaseDirectory(dir))
+	defer nm.Close()
+	changes, stop, err := nm.Watch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+	// Expect a notification for a local write that ThreadsDB never delivers.
+	local, err := encodeRecord(&record{ID: "test0", ValueString: "local"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nm.expect(map[note.ID][]byte{"test0": local})
+	// A change that arrives through replication must still be delivered.
+	if err := nm.note.WriteTxn(func(t *db.Txn) error {
+		_, err := t.Create(util.JSONFromInstance(record{ID: "test0", ValueString: "remote"}))
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case c := <-changes:
+		if !reflect.DeepEqual(c.IDs, []note.ID{"test0"}) || len(c.Ops) != 0 {
+			t.Errorf("got %#v, expected a change to test0 without operations", c)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("timed out waiting for a replicated change")
+	}
+}
+
+func TestParents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that uses IO and network")
+	}
+	dir, rmdir := testDir(t)
+	defer rmdir()
+	n := defaultNetwork(t, dir)
+	defer n.Close()
+	nm := open(t, n, WithBaseDirectory(dir))
+	defer nm.Close()
+	expectParents := func(expect map[note.ID][]note.ID) {
+		t.Helper()
+		if err := nm.IsolatedRead(func(r note.FindLoader) error {
+			var ids []note.ID
+			for id := range expect {
+				ids = append(ids, id)
+			}
+			parents, err := r.(note.ParentLoader).LoadParentIDs(ids)
+			if err != nil {
+				return err
+			}
+			for i, id := range ids {
+				if !reflect.DeepEqual(parents[i], expect[id]) {
+					t.Errorf("parents of %v: got %v, expected %v", id, parents[i], expect[id])
+				}
+			}
+			return nil
+		}); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+		return w.Patch(note.OperationSlice{}.
+			PatchContent("p0", note.IDSlice{}.Append("c0", "c1")).
+			PatchContent("p1", note.IDSlice{}.Append("c1")).
+			SetValueString("c1", "hello"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expectParents(map[note.ID][]note.ID{"c0": {"p0"}, "c1": {"p0", "p1"}, "p0": nil})
+	c1, err := nm.note.FindByID("c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if has, err := nm.note.Has("c0"); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Error("expected no record for an empty note that is only in content")
+	}
+	if err := nm.IsolatedRead(func(r note.FindLoader) error {
+		ns, err := r.Find(&note.Query{})
+		if err != nil {
+			return err
+		}
+		var ids []note.ID
+		for _, n := range ns {
+			ids = append(ids, n.GetID())
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		if expect := []note.ID{"c1", "p0", "p1"}; !reflect.DeepEqual(ids, expect) {
+			t.Errorf("got %v, expected %v", ids, expect)
+		}
+		return nil
+	}); err != nil {
+		t.Error(err)
+	}
+	if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+		return w.Patch(note.OperationSlice{}.
+			PatchContent("p0", note.IDSlice{"c0", "c1"}.DeleteElements("c1")).
+			ClearNote("p1").
+			PatchContent("p1", note.IDSlice{}.Append("c0")))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expectParents(map[note.ID][]note.ID{"c0": {"p0", "p1"}, "c1": nil})
+	if again, err := nm.note.FindByID("c1"); err != nil {
+		t.Fatal(err)
+	} else if string(again) != string(c1) {
+		t.Errorf("expected changes to parents to leave the record of c1 alone, got %s, was %s", again, c1)
+	}
+}
+
+// TestParents_moved verifies that the parents of notes are still found
+// correctly after content is removed from one note and moved to another,
+// whether through the database or through replication.
+func TestParents_moved(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that uses IO and network")
+	}
+	dir, rmdir := testDir(t)
+	defer rmdir()
+	n := defaultNetwork(t, dir)
+	defer n.Close()
+	nm := open(t, n, WithBaseDirectory(dir))
+	defer nm.Close()
+	loadParents := func() [][]note.ID {
+		t.Helper()
+		var parents [][]note.ID
+		if err := nm.IsolatedRead(func(r note.FindLoader) error {
+			var err error
+			parents, err = r.(note.ParentLoader).LoadParentIDs([]note.ID{"c0", "c1", "c2"})
+			return err
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return parents
+	}
+	for _, test := range []struct {
+		Ops    note.OperationSlice
+		Expect [][]note.ID
+	}{
+		{
+			note.OperationSlice{}.PatchContent("p0", note.IDSlice{}.Append("c0", "c1", "c2")),
+			[][]note.ID{{"p0"}, {"p0"}, {"p0"}},
+		},
+		{
+			note.OperationSlice{}.
+				PatchContent("p0", note.IDSlice{"c0", "c1", "c2"}.DeleteElements("c0", "c1")).
+				PatchContent("p0", note.IDSlice{"c2"}.Insert(0, "c1")).
+				PatchContent("p1", note.IDSlice{}.Append("c0")),
+			[][]note.ID{{"p1"}, {"p0"}, {"p0"}},
+		},
+		{
+			note.OperationSlice{}.
+				PatchContent("p0", note.IDSlice{"c1", "c2"}.DeleteElements("c2")).
+				PatchContent("p1", note.IDSlice{"c0"}.Append("c2")),
+			[][]note.ID{{"p1"}, {"p0"}, {"p1"}},
+		},
+		{
+			note.OperationSlice{}.ClearNote("p1"),
+			[][]note.ID{nil, {"p0"}, nil},
+		},
+	} {
+		if err := nm.IsolatedWrite(func(w note.FindLoadPatcher) error {
+			return w.Patch(test.Ops)
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if parents := loadParents(); !reflect.DeepEqual(parents, test.Expect) {
+			t.Errorf("after %v: got %v, expected %v", test.Ops, parents, test.Expect)
+		}
+	}
+	// A change that arrives through replication is eventually reflected.
+	if err := nm.note.WriteTxn(func(t *db.Txn) error {
+		_, err := t.Create(util.JSONFromInstance(record{ID: "p2", Contents: []note.ID{"c0", "c1"}}))
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expect := [][]note.ID{{"p2"}, {"p0", "p2"}, nil}
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		parents := loadParents()
+		if reflect.DeepEqual(parents, expect) {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("after replication: got %v, expected %v", parents, expect)
+		}
+	}
+}
+
+// TestParents_migration verifies that parents are found for notes written
+// with an older schema.
+func TestParents_migration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that uses IO and network")
+	}
+	dir, rmdir := testDir(t)
+	defer rmdir()
+	n := defaultNetwork(t, dir)
+	defer n.Close()
+	secrets := make(map[string][]byte)
+	opts := []Option{
+		WithBaseDirectory(dir),
+		WithGetSecret(func(k string) ([]byte, error) { return secrets[k], nil }),
+		WithSetSecret(func(k string, s []byte) error {
+			secrets[k] = s
+			return nil
+		}),
+	}
+	type oldRecord struct {
+		ID       core.InstanceID `json:"_id"`
+		Contents []note.ID       `json:"contents,omitempty"`
+	}
+	nm0 := open(t, n, opts...)
+	c, err := nm0.t.NewCollection(db.CollectionConfig{
+		Name:   "Note",
+		Schema: util.SchemaFromInstance(&oldRecord{}, false),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.WriteTxn(func(t *db.Txn) error {
+		_, err := t.Create(
+			util.JSONFromInstance(oldRecord{ID: "p0", Contents: []note.ID{"c0"}}),
+			util.JSONFromInstance(oldRecord{ID: "c0", Contents: []note.ID{"c1"}}))
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	id := nm0.GetThreadID()
+	if err := nm0.Close(); err != nil {
+		t.Fatal(err)
+	}
+	nm1 := open(t, n, append(opts, WithThread(id.String()))...)
+	defer nm1.Close()
+	if err := nm1.IsolatedRead(func(r note.FindLoader) error {
+		parents, err := r.(note.ParentLoader).LoadParentIDs([]note.ID{"p0", "c0", "c1"})
+		if err != nil {
+			return err
+		}
+		if expect := [][]note.ID{nil, {"p0"}, {"c0"}}; !reflect.DeepEqual(parents, expect) {
+			t.Errorf("got %v, expected %v", parents, expect)
+		}
+		return nil
+	}); err != nil {
+		t.Error(err)
+	}
 }
 
 // Make sure we can open the same database more than once.
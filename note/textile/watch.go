@@ -0,0 +1,221 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textile
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/google/note-maps/note"
+	"github.com/textileio/go-threads/db"
+)
+
+// Watch implements note.DatabaseWatcher.
+//
+// Changes written through x are delivered with the operations that made them.
+// Changes that arrive through ThreadsDB replication are delivered as they are
+// reported by ThreadsDB, with the ID of one changed note in each.
+//
+// ThreadsDB drops notifications that are not received quickly enough, so a
+// change that arrives through replication in a large batch may be reported
+// only in part.
+func (x *Database) Watch() (<-chan note.Change, func(), error) {
+	if err := x.init(); err != nil {
+		return nil, nil, err
+	}
+	x.watchMu.Lock()
+	defer x.watchMu.Unlock()
+	if x.watchers == nil {
+		return nil, nil, note.ClosedDatabase
+	}
+	w := newWatcher()
+	x.watchers[w] = true
+	stop := func() {
+		x.watchMu.Lock()
+		delete(x.watchers, w)
+		x.watchMu.Unlock()
+		w.stop()
+	}
+	return w.c, stop, nil
+}
+
+// startListening starts listening for changes to the Note collection, which
+// continues until the database is closed.
+func (x *Database) startListening() error {
+	l, err := x.t.Listen(db.ListenOption{Collection: "Note"})
+	if err != nil {
+		return wrapError("listening for changes", err)
+	}
+	x.watchMu.Lock()
+	defer x.watchMu.Unlock()
+	x.listener = l
+	x.watchers = make(map[*watcher]bool)
+	x.expected = make(map[note.ID][]byte)
+	go x.listen(l)
+	return nil
+}
+
+// listen delivers changes reported by l until it is closed, which happens
+// when the database is closed.
+//
+// A notification is not delivered if the note it refers to is still in the
+// state that was last written through x, since that change has already been
+// delivered by committed. Comparing states rather than counting notifications
+// means that a dropped notification cannot cause a later one to be ignored.
+//
+// Any other notification describes a change that arrived through
+// replication, so the parent index is discarded too.
+func (x *Database) listen(l db.Listener) {
+	for a := range l.Channel() {
+		id := note.ID(a.ID)
+		// The record must be read without holding x.watchMu, since writers
+		// hold a transaction while they wait for it.
+		current, err := x.current(id)
+		x.watchMu.Lock()
+		if expected, ok := x.expected[id]; !ok || err != nil || !bytes.Equal(current, expected) {
+			delete(x.expected, id)
+			x.parents.invalidate()
+			x.deliver(note.Change{IDs: []note.ID{id}})
+		}
+		x.watchMu.Unlock()
+	}
+	x.watchMu.Lock()
+	for w := range x.watchers {
+		w.stop()
+	}
+	x.watchers = nil
+	x.watchMu.Unlock()
+}
+
+// current returns the encoded record currently stored for id.
+func (x *Database) current(id note.ID) ([]byte, error) {
+	var rec record
+	err := x.note.ReadTxn(func(t *db.Txn) error {
+		return reader{Txn: t, ctx: context.Background()}.loadRecord(id, &rec)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return encodeRecord(&rec)
+}
+
+// expect records the encoded records in written as the state most recently
+// written through x, so that ThreadsDB notifications about them are not
+// delivered twice. A nil record removes the expectation.
+//
+// expect returns the expectations it replaced, so that they can be restored
+// if the write is aborted.
+func (x *Database) expect(written map[note.ID][]byte) map[note.ID][]byte {
+	x.watchMu.Lock()
+	defer x.watchMu.Unlock()
+	if x.listener == nil {
+		return nil
+	}
+	prev := make(map[note.ID][]byte, len(written))
+	for id, bs := range written {
+		prev[id] = x.expected[id]
+		if bs == nil {
+			delete(x.expected, id)
+		} else {
+			x.expected[id] = bs
+		}
+	}
+	return prev
+}
+
+// committed delivers the changes made by ops.
+func (x *Database) committed(ops []note.Operation) {
+	if len(ops) == 0 {
+		return
+	}
+	var (
+		ids  []note.ID
+		seen = make(map[note.ID]bool)
+	)
+	for _, op := range ops {
+		if o, ok := op.(interface{ GetID() note.ID }); ok && !seen[o.GetID()] {
+			seen[o.GetID()] = true
+			ids = append(ids, o.GetID())
+		}
+	}
+	x.watchMu.Lock()
+	defer x.watchMu.Unlock()
+	x.deliver(note.Change{IDs: ids, Ops: ops})
+}
+
+// deliver must be called with x.watchMu locked.
+func (x *Database) deliver(c note.Change) {
+	for w := range x.watchers {
+		w.send(c)
+	}
+}
+
+// watcher queues changes for delivery on c so that neither writers nor the
+// ThreadsDB listener are blocked by a slow receiver.
+type watcher struct {
+	c        chan note.Change
+	mu       sync.Mutex
+	queue    []note.Change
+	wake     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newWatcher() *watcher {
+	w := &watcher{
+		c:    make(chan note.Change),
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *watcher) send(c note.Change) {
+	w.mu.Lock()
+	w.queue = append(w.queue, c)
+	w.mu.Unlock()
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watcher) stop() { w.stopOnce.Do(func() { close(w.done) }) }
+
+func (w *watcher) run() {
+	defer close(w.c)
+	for {
+		w.mu.Lock()
+		if len(w.queue) == 0 {
+			w.mu.Unlock()
+			select {
+			case <-w.wake:
+				continue
+			case <-w.done:
+				return
+			}
+		}
+		c := w.queue[0]
+		w.queue = w.queue[1:]
+		w.mu.Unlock()
+		select {
+		case w.c <- c:
+		case <-w.done:
+			return
+		}
+	}
+}
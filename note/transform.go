@@ -0,0 +1,209 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"fmt"
+
+	"github.com/google/note-maps/otgen/runes"
+	"github.com/google/note-maps/otgen/strs"
+)
+
+// Transform rebases two concurrent batches of operations onto each other.
+//
+// Both a and b are assumed to apply to the same note map. The result a2
+// applies after b, and b2 applies after a, so that applying a and then b2
+// produces the same note map as applying b and then a2.
+//
+// Conflicts are resolved according to the content of the conflicting
+// operations rather than to the order of the arguments, so that two clients
+// that each transform their own batch against the other's will converge:
+//
+//   - Clearing a note overrides every concurrent change to the same note.
+//   - Setting the value and type of a note overrides concurrently setting only
+//     its value; otherwise the greater of two concurrent values wins.
+//   - Setting a value overrides concurrent edits to it, but concurrent edits
+//     are merged.
+//   - Where both batches insert into a value, content, types, or subject
+//     identifiers at the same position, the insertion that sorts first is
+//     placed first.
+//   - Where one batch adds a role that the other removes, the role is kept.
+func Transform(a, b OperationSlice) (a2, b2 OperationSlice, err error) {
+	bs := append(OperationSlice(nil), b...)
+	for _, x := range a {
+		for j := range bs {
+			if x == nil {
+				break
+			}
+			if bs[j] == nil {
+				continue
+			}
+			if x, bs[j], err = transformOne(x, bs[j]); err != nil {
+				return nil, nil, err
+			}
+		}
+		if x != nil {
+			a2 = append(a2, x)
+		}
+	}
+	for _, y := range bs {
+		if y != nil {
+			b2 = append(b2, y)
+		}
+	}
+	return a2, b2, nil
+}
+
+// transformOne rebases x and y onto each other, returning nil in place of any
+// operation that no longer has any effect.
+func transformOne(x, y Operation) (x2, y2 Operation, err error) {
+	xid, err := operationID(x)
+	if err != nil {
+		return nil, nil, err
+	}
+	yid, err := operationID(y)
+	if err != nil {
+		return nil, nil, err
+	}
+	if xid != yid {
+		return x, y, nil
+	}
+	if _, ok := x.(OpClearNote); ok {
+		if _, ok := y.(OpClearNote); ok {
+			return x, y, nil
+		}
+		return x, nil, nil
+	} else if _, ok := y.(OpClearNote); ok {
+		return nil, y, nil
+	}
+	switch xo := x.(type) {
+	case OpSetValue, OpSetValueString:
+		switch y.(type) {
+		case OpSetValue, OpSetValueString:
+			if valueWins(x, y) {
+				return x, nil, nil
+			}
+			return nil, y, nil
+		case OpValueDelta:
+			return x, nil, nil
+		}
+	case OpValueDelta:
+		switch yo := y.(type) {
+		case OpSetValue, OpSetValueString:
+			return nil, y, nil
+		case OpValueDelta:
+			xd, yd := runes.StringDelta(xo.StringOps), runes.StringDelta(yo.StringOps)
+			if xo.StringOps, err = xd.Rebase(yd); err != nil {
+				return nil, nil, err
+			}
+			if yo.StringOps, err = yd.Rebase(xd); err != nil {
+				return nil, nil, err
+			}
+			return nonEmpty(xo, len(xo.StringOps)), nonEmpty(yo, len(yo.StringOps)), nil
+		}
+	case OpContentDelta:
+		if yo, ok := y.(OpContentDelta); ok {
+			if xo.IDSliceOps, yo.IDSliceOps, err = rebaseIDSliceOps(xo.IDSliceOps, yo.IDSliceOps); err != nil {
+				return nil, nil, err
+			}
+			return nonEmpty(xo, len(xo.IDSliceOps)), nonEmpty(yo, len(yo.IDSliceOps)), nil
+		}
+	case OpTypesDelta:
+		if yo, ok := y.(OpTypesDelta); ok {
+			if xo.IDSliceOps, yo.IDSliceOps, err = rebaseIDSliceOps(xo.IDSliceOps, yo.IDSliceOps); err != nil {
+				return nil, nil, err
+			}
+			return nonEmpty(xo, len(xo.IDSliceOps)), nonEmpty(yo, len(yo.IDSliceOps)), nil
+		}
+	case OpRolesDelta:
+		if yo, ok := y.(OpRolesDelta); ok {
+			xo.Remove, yo.Remove =
+				rolesWithout(xo.Remove, yo.Add),
+				rolesWithout(yo.Remove, xo.Add)
+			return nonEmpty(xo, len(xo.Add)+len(xo.Remove)),
+				nonEmpty(yo, len(yo.Add)+len(yo.Remove)), nil
+		}
+	case OpSubjectIdentifiersDelta:
+		if yo, ok := y.(OpSubjectIdentifiersDelta); ok {
+			xd, yd := strs.StringsDelta(xo.StringsOps), strs.StringsDelta(yo.StringsOps)
+			if xo.StringsOps, err = xd.Rebase(yd); err != nil {
+				return nil, nil, err
+			}
+			if yo.StringsOps, err = yd.Rebase(xd); err != nil {
+				return nil, nil, err
+			}
+			return nonEmpty(xo, len(xo.StringsOps)), nonEmpty(yo, len(yo.StringsOps)), nil
+		}
+	}
+	return x, y, nil
+}
+
+// nonEmpty returns op, or nil if it has no parts.
+func nonEmpty(op Operation, parts int) Operation {
+	if parts == 0 {
+		return nil
+	}
+	return op
+}
+
+func operationID(op Operation) (ID, error) {
+	if o, ok := op.(interface{ GetID() ID }); ok {
+		return o.GetID(), nil
+	}
+	return EmptyID, fmt.Errorf("cannot transform %T: %w", op, UnsupportedOperation)
+}
+
+// valueWins returns true if x should override the concurrent value operation
+// y.
+func valueWins(x, y Operation) bool {
+	key := func(op Operation) (rank int, datatype ID, lexical string) {
+		switch o := op.(type) {
+		case OpSetValue:
+			return 1, o.Datatype, o.Lexical
+		case OpSetValueString:
+			return 0, EmptyID, o.Lexical
+		}
+		return -1, EmptyID, ""
+	}
+	xr, xd, xl := key(x)
+	yr, yd, yl := key(y)
+	if xr != yr {
+		return xr > yr
+	} else if xd != yd {
+		return xd > yd
+	}
+	return xl >= yl
+}
+
+func rolesWithout(rs []Role, del []Role) []Role {
+	var result []Role
+	for _, r := range rs {
+		if !RoleSlice(del).Has(r) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// rebaseIDSliceOps rebases x and y onto each other.
+func rebaseIDSliceOps(x, y []IDSliceOp) (x2, y2 IDSliceDelta, err error) {
+	if x2, err = IDSliceDelta(x).Rebase(y); err != nil {
+		return nil, nil, err
+	}
+	if y2, err = IDSliceDelta(y).Rebase(x); err != nil {
+		return nil, nil, err
+	}
+	return x2, y2, nil
+}
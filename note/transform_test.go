@@ -0,0 +1,176 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"testing"
+
+	"github.com/google/note-maps/otgen/runes"
+	"github.com/google/note-maps/otgen/strs"
+)
+
+func TestTransform(t *testing.T) {
+	cs := IDSlice{"n1"}
+	for _, test := range []struct {
+		Title  string
+		A, B   OperationSlice
+		Expect func(n0, n1 *TruncatedNote)
+	}{
+		{
+			Title: "different notes",
+			A:     OperationSlice{}.SetValue("n0", "a", EmptyID),
+			B:     OperationSlice{}.SetValue("n1", "b", EmptyID),
+			Expect: func(n0, n1 *TruncatedNote) {
+				n0.ValueString, n0.ValueType = "a", EmptyID
+				n1.ValueString = "b"
+			},
+		},
+		{
+			Title: "concurrent values",
+			A:     OperationSlice{}.SetValueString("n0", "a"),
+			B:     OperationSlice{}.SetValueString("n0", "b"),
+			Expect: func(n0, n1 *TruncatedNote) {
+				n0.ValueString = "b"
+			},
+		},
+		{
+			Title: "set value overrides set value string",
+			A:     OperationSlice{}.SetValue("n0", "a", "vt1"),
+			B:     OperationSlice{}.SetValueString("n0", "b"),
+			Expect: func(n0, n1 *TruncatedNote) {
+				n0.ValueString, n0.ValueType = "a", "vt1"
+			},
+		},
+		{
+			Title: "concurrent value edits",
+			A:     OperationSlice{}.PatchValue("n0", runes.StringDelta{}.Insert([]rune("oh, ")...)),
+			B:     OperationSlice{}.PatchValue("n0", runes.StringDelta{}.Retain(5).Insert([]rune(" there")...)),
+			Expect: func(n0, n1 *TruncatedNote) {
+				n0.ValueString = "oh, hello there"
+			},
+		},
+		{
+			Title: "set value overrides value edits",
+			A:     OperationSlice{}.PatchValue("n0", runes.StringDelta{}.Delete(1)),
+			B:     OperationSlice{}.SetValueString("n0", "bye"),
+			Expect: func(n0, n1 *TruncatedNote) {
+				n0.ValueString = "bye"
+			},
+		},
+		{
+			Title: "concurrent inserts at the same position",
+			A:     OperationSlice{}.PatchContent("n0", cs.Append("b")),
+			B:     OperationSlice{}.PatchContent("n0", cs.Append("a", "c")),
+			Expect: func(n0, n1 *TruncatedNote) {
+				n0.Contents = []ID{"n1", "a", "c", "b"}
+			},
+		},
+		{
+			Title: "insert and delete",
+			A:     OperationSlice{}.PatchContent("n0", cs.Insert(0, "a")),
+			B:     OperationSlice{}.PatchContent("n0", cs.DeleteElements("n1")),
+			Expect: func(n0, n1 *TruncatedNote) {
+				n0.Contents = []ID{"a"}
+			},
+		},
+		{
+			Title: "concurrent deletes",
+			A:     OperationSlice{}.PatchContent("n0", cs.DeleteElements("n1")),
+			B: OperationSlice{}.
+				PatchContent("n0", cs.DeleteElements("n1")).
+				PatchTypes("n0", IDSlice{"t0"}.Append("t1")),
+			Expect: func(n0, n1 *TruncatedNote) {
+				n0.Contents = nil
+				n0.Types = []ID{"t0", "t1"}
+			},
+		},
+		{
+			Title: "concurrent types",
+			A:     OperationSlice{}.PatchTypes("n0", IDSlice{"t0"}.Insert(0, "t2")),
+			B:     OperationSlice{}.PatchTypes("n0", IDSlice{"t0"}.Append("t1")),
+			Expect: func(n0, n1 *TruncatedNote) {
+				n0.Types = []ID{"t2", "t0", "t1"}
+			},
+		},
+		{
+			Title: "add wins over remove",
+			A:     OperationSlice{}.PatchRoles("n1", nil, []Role{{"r0", "n0"}}),
+			B:     OperationSlice{}.PatchRoles("n1", []Role{{"r0", "n0"}, {"r1", "n0"}}, nil),
+			Expect: func(n0, n1 *TruncatedNote) {
+				n1.Roles = []Role{{"r0", "n0"}, {"r1", "n0"}}
+			},
+		},
+		{
+			Title: "concurrent subject identifiers",
+			A:     OperationSlice{}.PatchSubjectIdentifiers("n0", strs.Strings{"si0"}.Append("si2")),
+			B:     OperationSlice{}.PatchSubjectIdentifiers("n0", strs.Strings{"si0"}.Append("si1")),
+			Expect: func(n0, n1 *TruncatedNote) {
+				n0.SubjectIdentifiers = []string{"si0", "si1", "si2"}
+			},
+		},
+		{
+			Title: "clear wins",
+			A: OperationSlice{}.
+				PatchContent("n0", cs.Append("n2")).
+				SetValue("n0", "a", EmptyID),
+			B: OperationSlice{}.ClearNote("n0"),
+			Expect: func(n0, n1 *TruncatedNote) {
+				*n0 = TruncatedNote{ID: "n0"}
+			},
+		},
+	} {
+		t.Run(test.Title, func(t *testing.T) {
+			a2, b2, err := Transform(test.A, test.B)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b3, a3, err := Transform(test.B, test.A)
+			if err != nil {
+				t.Fatal(err)
+			}
+			results := make([]mapFindLoader, 4)
+			for i, ops := range []OperationSlice{
+				append(append(OperationSlice{}, test.A...), b2...),
+				append(append(OperationSlice{}, test.B...), a2...),
+				append(append(OperationSlice{}, test.A...), b3...),
+				append(append(OperationSlice{}, test.B...), a3...),
+			} {
+				results[i] = testNoteMap()
+				if err := results[i].Patch(ops); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for id := range results[0] {
+				for i := 1; i < len(results); i++ {
+					if !results[i][id].Equals(results[0][id]) {
+						t.Errorf("result %v diverged: got %#v, expected %#v",
+							i, results[i][id], results[0][id])
+					}
+				}
+			}
+			expect := testNoteMap()
+			if test.Expect != nil {
+				n0, n1 := expect["n0"], expect["n1"]
+				test.Expect(&n0, &n1)
+				expect["n0"], expect["n1"] = n0, n1
+			}
+			for id, tn := range expect {
+				if got := results[0][id]; !got.Equals(tn) {
+					t.Errorf("got %#v, expected %#v", got, tn)
+				}
+			}
+		})
+	}
+}
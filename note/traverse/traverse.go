@@ -0,0 +1,431 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package traverse walks the graph formed by the references between notes in
+// a note map.
+//
+// Every walk reaches each note at most once, so walks terminate even when
+// the note map contains cycles. Notes are loaded one level at a time, with a
+// single call to note.Loader.Load for each level of a breadth-first walk and
+// for the neighbours of each note in a depth-first walk.
+package traverse
+
+import (
+	"errors"
+
+	"github.com/google/note-maps/note"
+)
+
+// Edge identifies a kind of reference from one note to another.
+type Edge int
+
+const (
+	// Content follows references from a note to each note in its content.
+	Content Edge = 1 << iota
+
+	// Type follows references from a note to each of its types.
+	Type
+
+	// Parent follows references from a note to each note that includes it in
+	// its content. Following parents requires a Loader that also implements
+	// note.ParentLoader.
+	Parent
+
+	// ValueType follows references from a note to the datatype of its value.
+	ValueType
+
+	// Role follows references from a note to the type and the player of each
+	// of its roles.
+	Role
+)
+
+var (
+	// SkipChildren can be returned by a Visitor to avoid following the
+	// references from the note it was called with. It is not returned by any
+	// walk.
+	SkipChildren = errors.New("skip children")
+
+	// Stop can be returned by a Visitor to end a walk early. It is not
+	// returned by any walk.
+	Stop = errors.New("stop walk")
+
+	// ParentsUnsupported indicates a walk that should follow parents with a
+	// Loader that does not implement note.ParentLoader.
+	ParentsUnsupported = errors.New("loader cannot load parents")
+)
+
+// Visitor is called with each note reached in a walk, and with the number of
+// references that were followed to reach it from a root.
+//
+// If a Visitor returns an error other than SkipChildren or Stop, the walk
+// ends and returns that error.
+type Visitor func(n note.GraphNote, depth int) error
+
+// Walker walks a note map.
+type Walker struct {
+	// Loader is used to load notes as they are reached.
+	Loader note.Loader
+
+	// Edges is the set of references to follow, or zero to follow only
+	// Content.
+	Edges Edge
+
+	// MaxDepth is the maximum number of references to follow from a root, or
+	// zero for no limit.
+	MaxDepth int
+}
+
+func (w Walker) edges() Edge {
+	if w.Edges == 0 {
+		return Content
+	}
+	return w.Edges
+}
+
+func (w Walker) follows(depth int) bool {
+	return w.MaxDepth <= 0 || depth < w.MaxDepth
+}
+
+// BFS walks breadth-first from roots, calling visit with each note in order of
+// its distance from the nearest root.
+func (w Walker) BFS(roots []note.ID, visit Visitor) error {
+	seen := make(map[note.ID]bool)
+	level := unseen(seen, roots)
+	for depth := 0; len(level) > 0; depth++ {
+		ns, err := w.Loader.Load(level)
+		if err != nil {
+			return err
+		}
+		var expand []note.GraphNote
+		for _, n := range ns {
+			switch err := visit(n, depth); err {
+			case nil:
+				expand = append(expand, n)
+			case SkipChildren:
+			case Stop:
+				return nil
+			default:
+				return err
+			}
+		}
+		if !w.follows(depth) {
+			break
+		}
+		nexts, err := w.neighbours(expand)
+		if err != nil {
+			return err
+		}
+		level = nil
+		for _, next := range nexts {
+			level = append(level, unseen(seen, next)...)
+		}
+	}
+	return nil
+}
+
+// DFS walks depth-first from roots, calling visit with each note before any
+// of the notes it refers to.
+func (w Walker) DFS(roots []note.ID, visit Visitor) error {
+	seen := make(map[note.ID]bool)
+	err := w.dfs(seen, unseen(seen, roots), 0, visit)
+	if err == Stop {
+		return nil
+	}
+	return err
+}
+
+func (w Walker) dfs(seen map[note.ID]bool, ids []note.ID, depth int, visit Visitor) error {
+	ns, err := w.Loader.Load(ids)
+	if err != nil {
+		return err
+	}
+	for _, n := range ns {
+		switch err := visit(n, depth); err {
+		case nil:
+		case SkipChildren:
+			continue
+		default:
+			return err
+		}
+		if !w.follows(depth) {
+			continue
+		}
+		nexts, err := w.neighbours([]note.GraphNote{n})
+		if err != nil {
+			return err
+		}
+		if next := unseen(seen, nexts[0]); len(next) > 0 {
+			if err := w.dfs(seen, next, depth+1, visit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Descendants returns the IDs of the notes that can be reached from id, in
+// breadth-first order, not including id itself unless it is part of a cycle.
+func (w Walker) Descendants(id note.ID) ([]note.ID, error) {
+	ns, err := w.Loader.Load([]note.ID{id})
+	if err != nil {
+		return nil, err
+	}
+	nexts, err := w.neighbours(ns)
+	if err != nil {
+		return nil, err
+	}
+	if w.MaxDepth == 1 {
+		return unseen(make(map[note.ID]bool), nexts[0]), nil
+	} else if w.MaxDepth > 1 {
+		w.MaxDepth--
+	}
+	var ids []note.ID
+	err = w.BFS(nexts[0], func(n note.GraphNote, depth int) error {
+		ids = append(ids, n.GetID())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Ancestors returns the IDs of the notes that include id in their content,
+// directly or indirectly, in breadth-first order.
+//
+// Ancestors follows only parents, whatever the value of w.Edges.
+func (w Walker) Ancestors(id note.ID) ([]note.ID, error) {
+	w.Edges = Parent
+	return w.Descendants(id)
+}
+
+// ShortestPath returns the IDs of the notes along a shortest path from one
+// note to another, including both, or nil if there is no such path.
+//
+// The shortest path from a note to itself is the shortest cycle that includes
+// it, if there is one.
+func (w Walker) ShortestPath(from, to note.ID) ([]note.ID, error) {
+	var (
+		prev  = make(map[note.ID]note.ID)
+		seen  = make(map[note.ID]bool)
+		level = []note.ID{from}
+	)
+	if from != to {
+		seen[from] = true
+	}
+	for depth := 0; len(level) > 0 && w.follows(depth); depth++ {
+		ns, err := w.Loader.Load(level)
+		if err != nil {
+			return nil, err
+		}
+		nexts, err := w.neighbours(ns)
+		if err != nil {
+			return nil, err
+		}
+		level = nil
+		for i, n := range ns {
+			for _, id := range nexts[i] {
+				if id.Empty() || seen[id] {
+					continue
+				}
+				seen[id], prev[id] = true, n.GetID()
+				if id == to {
+					return pathTo(prev, from, to), nil
+				}
+				level = append(level, id)
+			}
+		}
+	}
+	return nil, nil
+}
+
+func pathTo(prev map[note.ID]note.ID, from, to note.ID) []note.ID {
+	path := []note.ID{to}
+	for id := to; ; {
+		id = prev[id]
+		path = append(path, id)
+		if id == from {
+			break
+		}
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// FindCycle returns the IDs of the notes along a cycle that can be reached
+// from roots, with the first note repeated at the end, or nil if there is no
+// such cycle.
+func (w Walker) FindCycle(roots []note.ID) ([]note.ID, error) {
+	var (
+		done    = make(map[note.ID]bool)
+		stack   []note.ID
+		onStack = make(map[note.ID]int)
+	)
+	var visit func(ids []note.ID, depth int) ([]note.ID, error)
+	visit = func(ids []note.ID, depth int) ([]note.ID, error) {
+		for _, id := range ids {
+			if i, ok := onStack[id]; ok {
+				return append(append([]note.ID(nil), stack[i:]...), id), nil
+			}
+		}
+		ns, err := w.Loader.Load(ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range ns {
+			id := n.GetID()
+			if done[id] {
+				continue
+			}
+			done[id] = true
+			if !w.follows(depth) {
+				continue
+			}
+			nexts, err := w.neighbours([]note.GraphNote{n})
+			if err != nil {
+				return nil, err
+			}
+			onStack[id] = len(stack)
+			stack = append(stack, id)
+			var next []note.ID
+			for _, c := range nexts[0] {
+				if _, ok := onStack[c]; ok || !c.Empty() && !done[c] {
+					next = append(next, c)
+				}
+			}
+			if len(next) > 0 {
+				if cycle, err := visit(next, depth+1); err != nil || cycle != nil {
+					return cycle, err
+				}
+			}
+			stack = stack[:len(stack)-1]
+			delete(onStack, id)
+		}
+		return nil, nil
+	}
+	return visit(unseen(make(map[note.ID]bool), roots), 0)
+}
+
+// neighbours returns, for each of ns, the IDs of the notes it refers to along
+// the edges followed by w.
+func (w Walker) neighbours(ns []note.GraphNote) ([][]note.ID, error) {
+	edges := w.edges()
+	result := make([][]note.ID, len(ns))
+	for i, n := range ns {
+		if edges&Content != 0 {
+			ids, err := contentIDs(n)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = append(result[i], ids...)
+		}
+		if edges&Type != 0 {
+			ids, err := typeIDs(n)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = append(result[i], ids...)
+		}
+		if edges&ValueType != 0 {
+			id, err := valueTypeID(n)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = append(result[i], id)
+		}
+		if edges&Role != 0 {
+			rs, err := n.GetRoles()
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range rs {
+				result[i] = append(result[i], r.Type, r.Player)
+			}
+		}
+	}
+	if edges&Parent != 0 && len(ns) > 0 {
+		pl, ok := w.Loader.(note.ParentLoader)
+		if !ok {
+			return nil, ParentsUnsupported
+		}
+		ids := make([]note.ID, len(ns))
+		for i, n := range ns {
+			ids[i] = n.GetID()
+		}
+		parents, err := pl.LoadParentIDs(ids)
+		if err != nil {
+			return nil, err
+		}
+		for i := range ns {
+			result[i] = append(result[i], parents[i]...)
+		}
+	}
+	return result, nil
+}
+
+// contentIDs returns the IDs of the content of n without loading the content
+// if n makes that possible.
+func contentIDs(n note.GraphNote) ([]note.ID, error) {
+	if x, ok := n.(interface{ GetContentIDs() ([]note.ID, error) }); ok {
+		return x.GetContentIDs()
+	}
+	cs, err := n.GetContents()
+	return ids(cs), err
+}
+
+// typeIDs returns the IDs of the types of n without loading the types if n
+// makes that possible.
+func typeIDs(n note.GraphNote) ([]note.ID, error) {
+	if x, ok := n.(interface{ GetTypeIDs() ([]note.ID, error) }); ok {
+		return x.GetTypeIDs()
+	}
+	ts, err := n.GetTypes()
+	return ids(ts), err
+}
+
+// valueTypeID returns the ID of the datatype of the value of n without
+// loading the datatype if n makes that possible.
+func valueTypeID(n note.GraphNote) (note.ID, error) {
+	if x, ok := n.(interface{ GetValueTypeID() (note.ID, error) }); ok {
+		return x.GetValueTypeID()
+	}
+	_, vt, err := n.GetValue()
+	if err != nil || vt == nil {
+		return note.EmptyID, err
+	}
+	return vt.GetID(), nil
+}
+
+func ids(ns []note.GraphNote) []note.ID {
+	ids := make([]note.ID, len(ns))
+	for i, n := range ns {
+		ids[i] = n.GetID()
+	}
+	return ids
+}
+
+// unseen returns the IDs in ids that are not in seen, without duplicates, and
+// adds them to seen.
+func unseen(seen map[note.ID]bool, ids []note.ID) []note.ID {
+	var result []note.ID
+	for _, id := range ids {
+		if !id.Empty() && !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
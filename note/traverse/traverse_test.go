@@ -0,0 +1,261 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traverse
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/note-maps/note"
+)
+
+// mapLoader loads notes from a map and counts the calls to Load.
+type mapLoader struct {
+	notes map[note.ID]note.TruncatedNote
+	loads int
+}
+
+func (l *mapLoader) Load(ids []note.ID) ([]note.GraphNote, error) {
+	l.loads++
+	ns := make([]note.GraphNote, len(ids))
+	for i, id := range ids {
+		if id.Empty() {
+			return nil, note.InvalidID
+		}
+		tn, ok := l.notes[id]
+		if !ok {
+			tn.ID = id
+		}
+		ns[i] = note.ExpandNote(tn, l)
+	}
+	return ns, nil
+}
+
+// parentLoader adds a simple implementation of note.ParentLoader.
+type parentLoader struct{ *mapLoader }
+
+func (l parentLoader) LoadParentIDs(ids []note.ID) ([][]note.ID, error) {
+	parents := make([][]note.ID, len(ids))
+	for i, id := range ids {
+		for _, pid := range []note.ID{"a", "b", "c", "d", "e", "t"} {
+			for _, c := range l.notes[pid].Contents {
+				if c == id {
+					parents[i] = append(parents[i], pid)
+					break
+				}
+			}
+		}
+	}
+	return parents, nil
+}
+
+// graph is the note map walked by most of these tests, which must not modify
+// it.
+var graph = map[note.ID]note.TruncatedNote{
+	"a": {ID: "a", Contents: []note.ID{"b", "c"}, Types: []note.ID{"t"}},
+	"b": {ID: "b", Contents: []note.ID{"d"}, Roles: []note.Role{{Type: "r", Player: "p"}}},
+	"c": {ID: "c", Contents: []note.ID{"d", "e"}},
+	"d": {ID: "d", Contents: []note.ID{"a"}},
+	"e": {ID: "e", ValueString: "e", ValueType: "dt"},
+	"t": {ID: "t"},
+}
+
+func TestWalker_BFS(t *testing.T) {
+	for _, test := range []struct {
+		Title  string
+		Walker Walker
+		Visit  func(n note.GraphNote) error
+		Expect []note.ID
+		Depths []int
+	}{
+		{
+			Title:  "content",
+			Expect: []note.ID{"a", "b", "c", "d", "e"},
+			Depths: []int{0, 1, 1, 2, 2},
+		},
+		{
+			Title:  "max depth",
+			Walker: Walker{MaxDepth: 1},
+			Expect: []note.ID{"a", "b", "c"},
+			Depths: []int{0, 1, 1},
+		},
+		{
+			Title:  "content and types",
+			Walker: Walker{Edges: Content | Type},
+			Expect: []note.ID{"a", "b", "c", "t", "d", "e"},
+			Depths: []int{0, 1, 1, 1, 2, 2},
+		},
+		{
+			Title:  "content, value types, and roles",
+			Walker: Walker{Edges: Content | ValueType | Role},
+			Expect: []note.ID{"a", "b", "c", "d", "r", "p", "e", "dt"},
+			Depths: []int{0, 1, 1, 2, 2, 2, 2, 3},
+		},
+		{
+			Title: "skip children",
+			Visit: func(n note.GraphNote) error {
+				if n.GetID() == "b" {
+					return SkipChildren
+				}
+				return nil
+			},
+			Expect: []note.ID{"a", "b", "c", "d", "e"},
+			Depths: []int{0, 1, 1, 2, 2},
+		},
+		{
+			Title: "stop",
+			Visit: func(n note.GraphNote) error {
+				if n.GetID() == "b" {
+					return Stop
+				}
+				return nil
+			},
+			Expect: []note.ID{"a", "b"},
+			Depths: []int{0, 1},
+		},
+	} {
+		t.Run(test.Title, func(t *testing.T) {
+			l := &mapLoader{notes: graph}
+			w := test.Walker
+			w.Loader = l
+			var (
+				ids    []note.ID
+				depths []int
+			)
+			err := w.BFS([]note.ID{"a"}, func(n note.GraphNote, depth int) error {
+				ids = append(ids, n.GetID())
+				depths = append(depths, depth)
+				if test.Visit != nil {
+					return test.Visit(n)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(ids, test.Expect) {
+				t.Errorf("got %v, expected %v", ids, test.Expect)
+			}
+			if !reflect.DeepEqual(depths, test.Depths) {
+				t.Errorf("got depths %v, expected %v", depths, test.Depths)
+			}
+			if max := test.Depths[len(test.Depths)-1] + 1; l.loads > max {
+				t.Errorf("got %v calls to Load, expected at most %v", l.loads, max)
+			}
+		})
+	}
+}
+
+func TestWalker_DFS(t *testing.T) {
+	var ids []note.ID
+	err := Walker{Loader: &mapLoader{notes: graph}}.DFS([]note.ID{"a"}, func(n note.GraphNote, depth int) error {
+		ids = append(ids, n.GetID())
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := []note.ID{"a", "b", "d", "c", "e"}; !reflect.DeepEqual(ids, expect) {
+		t.Errorf("got %v, expected %v", ids, expect)
+	}
+}
+
+func TestWalker_Descendants(t *testing.T) {
+	for _, test := range []struct {
+		Walker Walker
+		ID     note.ID
+		Expect []note.ID
+	}{
+		{Walker{}, "a", []note.ID{"b", "c", "d", "e", "a"}},
+		{Walker{}, "e", nil},
+		{Walker{MaxDepth: 1}, "a", []note.ID{"b", "c"}},
+		{Walker{MaxDepth: 2}, "b", []note.ID{"d", "a"}},
+	} {
+		w := test.Walker
+		w.Loader = &mapLoader{notes: graph}
+		ids, err := w.Descendants(test.ID)
+		if err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(ids, test.Expect) {
+			t.Errorf("%+v descendants of %v: got %v, expected %v",
+				test.Walker, test.ID, ids, test.Expect)
+		}
+	}
+}
+
+func TestWalker_Ancestors(t *testing.T) {
+	ids, err := Walker{Loader: parentLoader{&mapLoader{notes: graph}}}.Ancestors("d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := []note.ID{"b", "c", "a", "d"}; !reflect.DeepEqual(ids, expect) {
+		t.Errorf("got %v, expected %v", ids, expect)
+	}
+	if _, err := (Walker{Loader: &mapLoader{notes: graph}}).Ancestors("d"); err != ParentsUnsupported {
+		t.Errorf("got %v, expected %v", err, ParentsUnsupported)
+	}
+}
+
+func TestWalker_ShortestPath(t *testing.T) {
+	for _, test := range []struct {
+		Walker   Walker
+		From, To note.ID
+		Expect   []note.ID
+	}{
+		{Walker{}, "a", "e", []note.ID{"a", "c", "e"}},
+		{Walker{}, "a", "d", []note.ID{"a", "b", "d"}},
+		{Walker{}, "a", "a", []note.ID{"a", "b", "d", "a"}},
+		{Walker{}, "e", "a", nil},
+		{Walker{}, "a", "t", nil},
+		{Walker{Edges: Type}, "a", "t", []note.ID{"a", "t"}},
+		{Walker{MaxDepth: 1}, "a", "d", nil},
+	} {
+		w := test.Walker
+		w.Loader = &mapLoader{notes: graph}
+		path, err := w.ShortestPath(test.From, test.To)
+		if err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(path, test.Expect) {
+			t.Errorf("%+v path from %v to %v: got %v, expected %v",
+				test.Walker, test.From, test.To, path, test.Expect)
+		}
+	}
+}
+
+func TestWalker_FindCycle(t *testing.T) {
+	for _, test := range []struct {
+		Roots  []note.ID
+		Expect []note.ID
+	}{
+		{[]note.ID{"a"}, []note.ID{"a", "b", "d", "a"}},
+		{[]note.ID{"c"}, []note.ID{"c", "d", "a", "c"}},
+		{[]note.ID{"e", "t"}, nil},
+	} {
+		cycle, err := Walker{Loader: &mapLoader{notes: graph}}.FindCycle(test.Roots)
+		if err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(cycle, test.Expect) {
+			t.Errorf("cycle from %v: got %v, expected %v", test.Roots, cycle, test.Expect)
+		}
+	}
+	l := &mapLoader{notes: map[note.ID]note.TruncatedNote{
+		"e": {ID: "e", Contents: []note.ID{"e"}},
+	}}
+	if cycle, err := (Walker{Loader: l}).FindCycle([]note.ID{"e"}); err != nil {
+		t.Error(err)
+	} else if expect := []note.ID{"e", "e"}; !reflect.DeepEqual(cycle, expect) {
+		t.Errorf("got %v, expected %v", cycle, expect)
+	}
+}
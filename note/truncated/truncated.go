@@ -15,6 +15,7 @@
 package truncated
 
 import (
+	"context"
 	"sync"
 
 	"github.com/google/note-maps/note"
@@ -29,6 +30,9 @@ type IDFinder interface {
 }
 
 // ExpandFinder combines tf and l to provide a note.Finder implementation.
+//
+// The result is also a note.ContextFinder, which checks its context before
+// calling tf and passes it on to l if it is a note.ContextLoader.
 func ExpandFinder(tf IDFinder, l note.Loader) note.Finder {
 	return &finder{tf, l}
 }
@@ -39,11 +43,22 @@ type finder struct {
 }
 
 func (f *finder) Find(q *note.Query) ([]note.GraphNote, error) {
+	return f.FindContext(context.Background(), q)
+}
+
+func (f *finder) FindContext(ctx context.Context, q *note.Query) ([]note.GraphNote, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	ids, err := f.FindNoteIDs(q)
 	if err != nil {
 		return nil, err
 	}
-	return f.l.Load(ids)
+	return note.LoadContext(ctx, f.l, ids)
+}
+
+func (f *finder) FindBySubjectIdentifier(si string) ([]note.GraphNote, error) {
+	return f.Find(&note.Query{SubjectIdentifier: si})
 }
 
 // TruncatedLoader can be implemented in order to provide a Loader through
@@ -62,6 +77,10 @@ type TruncatedLoader interface {
 
 // ExpandLoader expands tl into a Loader implementation with a simple built-in
 // cache that is suitable for short-lived loaders.
+//
+// The result is also a note.ContextLoader, which checks its context before
+// calling tl. Implementations of tl that must stop part way through a long
+// load can hold a context of their own.
 func ExpandLoader(tl TruncatedLoader) note.Loader {
 	return &loader{tl: tl}
 }
@@ -72,6 +91,10 @@ type loader struct {
 }
 
 func (l *loader) Load(ids []note.ID) ([]note.GraphNote, error) {
+	return l.LoadContext(context.Background(), ids)
+}
+
+func (l *loader) LoadContext(ctx context.Context, ids []note.ID) ([]note.GraphNote, error) {
 	var (
 		ns    = make([]note.GraphNote, len(ids))
 		q     = ids
@@ -93,6 +116,9 @@ func (l *loader) Load(ids []note.ID) ([]note.GraphNote, error) {
 			q = append(q, ids[i])
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	tns, err := l.tl.LoadTruncatedNotes(q)
 	if err != nil {
 		return nil, err
@@ -15,6 +15,8 @@
 package truncated
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/google/note-maps/note"
@@ -102,3 +104,22 @@ func TestExpandLoader(t *testing.T) {
 		t.Errorf("expected %v, got %v", four, cs[1])
 	}
 }
+
+func TestExpand_context(t *testing.T) {
+	fl := findloader{"one": {ID: "one", ValueString: "value1"}}
+	l := ExpandLoader(fl)
+	f := ExpandFinder(fl, l)
+	ctx, cancel := context.WithCancel(context.Background())
+	if ns, err := note.FindContext(ctx, f, &note.Query{}); err != nil {
+		t.Fatal(err)
+	} else if len(ns) != 1 {
+		t.Fatal("expected one note, got", len(ns))
+	}
+	cancel()
+	if _, err := note.LoadContext(ctx, l, []note.ID{"two"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("load: expected %v, got %v", context.Canceled, err)
+	}
+	if _, err := note.FindContext(ctx, f, &note.Query{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("find: expected %v, got %v", context.Canceled, err)
+	}
+}
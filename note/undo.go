@@ -0,0 +1,142 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import "fmt"
+
+// UndoStack applies batches of operations to a database and records them so
+// that they can be undone and redone.
+//
+// Each batch is applied within its own call to IsolatedWrite. The inverse of
+// each batch restores every note it affects to the state read from the
+// database just before it is applied, so it does not depend on how the
+// database might rewrite the batch, but changes made to the same notes by
+// other writers may be overwritten by Undo and Redo.
+type UndoStack struct {
+	DB DatabaseWriter
+
+	done, undone []undoEntry
+}
+
+type undoEntry struct {
+	ops, inverse []Operation
+}
+
+// Apply applies ops to x.DB and records them as the most recent batch that
+// can be undone. Any batches that could have been redone are forgotten.
+func (x *UndoStack) Apply(ops []Operation) error {
+	e := undoEntry{ops: ops}
+	if err := x.DB.IsolatedWrite(func(w FindLoadPatcher) error {
+		var err error
+		if e.inverse, err = restore(w, ops); err != nil {
+			return err
+		}
+		return w.Patch(ops)
+	}); err != nil {
+		return err
+	}
+	x.done = append(x.done, e)
+	x.undone = nil
+	return nil
+}
+
+// Commit applies the operations staged in s, as with Apply, and then clears
+// them from s.
+func (x *UndoStack) Commit(s *Stage) error {
+	if err := x.Apply(s.Ops); err != nil {
+		return err
+	}
+	s.Ops = nil
+	return nil
+}
+
+// CanUndo returns true if and only if there is a batch that can be undone.
+func (x *UndoStack) CanUndo() bool { return len(x.done) > 0 }
+
+// CanRedo returns true if and only if there is a batch that can be redone.
+func (x *UndoStack) CanRedo() bool { return len(x.undone) > 0 }
+
+// Undo rolls back the most recent batch that has not already been undone.
+//
+// Undo does nothing if CanUndo() is false.
+func (x *UndoStack) Undo() error {
+	if !x.CanUndo() {
+		return nil
+	}
+	e := x.done[len(x.done)-1]
+	if err := x.DB.IsolatedWrite(func(w FindLoadPatcher) error {
+		return w.Patch(e.inverse)
+	}); err != nil {
+		return err
+	}
+	x.done = x.done[:len(x.done)-1]
+	x.undone = append(x.undone, e)
+	return nil
+}
+
+// Redo rolls forward the batch that was most recently undone.
+//
+// Redo does nothing if CanRedo() is false.
+func (x *UndoStack) Redo() error {
+	if !x.CanRedo() {
+		return nil
+	}
+	e := x.undone[len(x.undone)-1]
+	if err := x.DB.IsolatedWrite(func(w FindLoadPatcher) error {
+		var err error
+		if e.inverse, err = restore(w, e.ops); err != nil {
+			return err
+		}
+		return w.Patch(e.ops)
+	}); err != nil {
+		return err
+	}
+	x.undone = x.undone[:len(x.undone)-1]
+	x.done = append(x.done, e)
+	return nil
+}
+
+// restore returns operations that return each note affected by ops to its
+// state in base.
+func restore(base Loader, ops []Operation) ([]Operation, error) {
+	var (
+		ids  []ID
+		seen = make(map[ID]bool)
+	)
+	for _, op := range ops {
+		o, ok := op.(interface{ GetID() ID })
+		if !ok {
+			return nil, fmt.Errorf("cannot invert %T: %w", op, UnsupportedOperation)
+		}
+		if id := o.GetID(); !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	ns, err := base.Load(ids)
+	if err != nil {
+		return nil, err
+	}
+	var inv OperationSlice
+	for _, n := range ns {
+		tn, err := TruncateNote(n)
+		if err != nil {
+			return nil, err
+		}
+		inv = inv.ClearNote(tn.ID)
+		inv = append(inv, Diff(TruncatedNote{ID: tn.ID}, tn)...)
+	}
+	return inv, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package note
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/note-maps/otgen/runes"
+)
+
+func (m mapFindLoader) IsolatedWrite(f func(rw FindLoadPatcher) error) error {
+	return f(m)
+}
+
+func TestUndoStack(t *testing.T) {
+	m := mapFindLoader{}
+	u := UndoStack{DB: m}
+	if u.CanUndo() || u.CanRedo() {
+		t.Fatal("expected nothing to undo or redo")
+	}
+	stage := Stage{Base: m}
+	stage.Note("n0").SetValue("first", EmptyID)
+	if err := u.Commit(&stage); err != nil {
+		t.Fatal(err)
+	}
+	if len(stage.Ops) != 0 {
+		t.Error("expected committed operations to be cleared from the stage")
+	}
+	stage.Note("n0").SetValue("second", EmptyID)
+	stage.Note("n0").AddContent("n1")
+	if err := u.Commit(&stage); err != nil {
+		t.Fatal(err)
+	}
+	expectValue := func(expect string, expectContents int) {
+		t.Helper()
+		if got := m["n0"]; got.ValueString != expect || len(got.Contents) != expectContents {
+			t.Errorf("got %#v, expected value %#v and %v contents",
+				got, expect, expectContents)
+		}
+	}
+	expectValue("second", 1)
+	if err := u.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	expectValue("first", 0)
+	if err := u.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	expectValue("", 0)
+	if u.CanUndo() || !u.CanRedo() {
+		t.Error("expected only redo to be possible")
+	}
+	if err := u.Undo(); err != nil {
+		t.Error("expected undo with nothing to undo to do nothing, got", err)
+	}
+	if err := u.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	expectValue("first", 0)
+	if err := u.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	expectValue("second", 1)
+	if err := u.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if err := u.Apply(OperationSlice{}.SetValue("n0", "third", EmptyID)); err != nil {
+		t.Fatal(err)
+	}
+	if u.CanRedo() {
+		t.Error("expected a new batch to forget undone batches")
+	}
+	expectValue("third", 0)
+}
+
+// trimmingDB trims the value of every note after each patch, as a database
+// that normalizes values might.
+type trimmingDB struct{ mapFindLoader }
+
+func (db trimmingDB) IsolatedWrite(f func(rw FindLoadPatcher) error) error {
+	return f(db)
+}
+
+func (db trimmingDB) Patch(ops []Operation) error {
+	if err := db.mapFindLoader.Patch(ops); err != nil {
+		return err
+	}
+	for id, tn := range db.mapFindLoader {
+		tn.ValueString = strings.TrimSpace(tn.ValueString)
+		db.mapFindLoader[id] = tn
+	}
+	return nil
+}
+
+func TestUndoStack_normalized(t *testing.T) {
+	m := mapFindLoader{"n0": {ID: "n0", ValueString: "hello", Contents: []ID{"n1"}}}
+	u := UndoStack{DB: trimmingDB{m}}
+	if err := u.Apply(OperationSlice{}.PatchValue("n0",
+		runes.StringDiff([]rune("hello"), []rune("  hello, world  ")))); err != nil {
+		t.Fatal(err)
+	}
+	if got := m["n0"].ValueString; got != "hello, world" {
+		t.Fatalf("got value %#v, expected %#v", got, "hello, world")
+	}
+	if err := u.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	expect := TruncatedNote{ID: "n0", ValueString: "hello", Contents: []ID{"n1"}}
+	if got := m["n0"]; !got.Equals(expect) {
+		t.Errorf("got %#v, expected %#v", got, expect)
+	}
+	if err := u.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	if got := m["n0"].ValueString; got != "hello, world" {
+		t.Errorf("got value %#v after redo, expected %#v", got, "hello, world")
+	}
+}
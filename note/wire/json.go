@@ -0,0 +1,45 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wire
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/note-maps/note"
+)
+
+// MarshalJSON encodes ops as a JSON object like this:
+//
+//	{"version":1,"ops":[
+//	  {"id":"n0","set_value":{"lexical":"hello","datatype":""}},
+//	  {"id":"n0","content_delta":{"ops":[{"retain":1},{"insert":["n1"]}]}}
+//	]}
+func MarshalJSON(ops []note.Operation) ([]byte, error) {
+	l, err := fromOperations(ops)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(l)
+}
+
+// UnmarshalJSON decodes operations encoded by MarshalJSON.
+func UnmarshalJSON(data []byte) ([]note.Operation, error) {
+	var l operationLog
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("%v: %w", err, Malformed)
+	}
+	return l.operations()
+}
@@ -0,0 +1,216 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wire
+
+import (
+	"fmt"
+
+	"github.com/google/note-maps/note"
+	"google.golang.org/protobuf/proto"
+)
+
+//go:generate protoc --go_out=paths=source_relative:. wire.proto
+
+// MarshalProto encodes ops as an OperationLog message as described in
+// wire.proto.
+func MarshalProto(ops []note.Operation) ([]byte, error) {
+	l, err := fromOperations(ops)
+	if err != nil {
+		return nil, err
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(l.proto())
+}
+
+// UnmarshalProto decodes operations encoded by MarshalProto.
+func UnmarshalProto(data []byte) ([]note.Operation, error) {
+	var m OperationLog
+	if err := proto.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%v: %w", err, Malformed)
+	}
+	return fromProto(&m).operations()
+}
+
+// proto returns the OperationLog message that l mirrors.
+func (l *operationLog) proto() *OperationLog {
+	m := &OperationLog{Version: l.Version, Ops: make([]*Operation, len(l.Ops))}
+	for i, o := range l.Ops {
+		p := &Operation{Id: string(o.ID)}
+		switch {
+		case o.SetValue != nil:
+			p.Op = &Operation_SetValue{&SetValue{
+				Lexical:  o.SetValue.Lexical,
+				Datatype: string(o.SetValue.Datatype),
+			}}
+		case o.SetValueString != nil:
+			p.Op = &Operation_SetValueString{&SetValueString{Lexical: o.SetValueString.Lexical}}
+		case o.ValueDelta != nil:
+			d := &StringDelta{Ops: make([]*StringOp, len(o.ValueDelta.Ops))}
+			for j, op := range o.ValueDelta.Ops {
+				d.Ops[j] = &StringOp{}
+				switch {
+				case op.Retain != nil:
+					d.Ops[j].Op = &StringOp_Retain{*op.Retain}
+				case op.Delete != nil:
+					d.Ops[j].Op = &StringOp_Delete{*op.Delete}
+				default:
+					d.Ops[j].Op = &StringOp_Insert{op.Insert}
+				}
+			}
+			p.Op = &Operation_ValueDelta{d}
+		case o.ContentDelta != nil:
+			p.Op = &Operation_ContentDelta{o.ContentDelta.proto()}
+		case o.TypesDelta != nil:
+			p.Op = &Operation_TypesDelta{o.TypesDelta.proto()}
+		case o.RolesDelta != nil:
+			p.Op = &Operation_RolesDelta{&RolesDelta{
+				Add:    rolesProto(o.RolesDelta.Add),
+				Remove: rolesProto(o.RolesDelta.Remove),
+			}}
+		case o.SubjectIdentifiersDelta != nil:
+			d := &StringsDelta{Ops: make([]*StringsOp, len(o.SubjectIdentifiersDelta.Ops))}
+			for j, op := range o.SubjectIdentifiersDelta.Ops {
+				d.Ops[j] = &StringsOp{}
+				switch {
+				case op.Retain != nil:
+					d.Ops[j].Op = &StringsOp_Retain{*op.Retain}
+				case op.Delete != nil:
+					d.Ops[j].Op = &StringsOp_Delete{*op.Delete}
+				default:
+					d.Ops[j].Op = &StringsOp_Insert{&Strings{Values: op.Insert}}
+				}
+			}
+			p.Op = &Operation_SubjectIdentifiersDelta{d}
+		case o.ClearNote != nil:
+			p.Op = &Operation_ClearNote{&ClearNote{}}
+		}
+		m.Ops[i] = p
+	}
+	return m
+}
+
+func (d *idSliceDelta) proto() *IDSliceDelta {
+	m := &IDSliceDelta{Ops: make([]*IDSliceOp, len(d.Ops))}
+	for i, op := range d.Ops {
+		m.Ops[i] = &IDSliceOp{}
+		switch {
+		case op.Retain != nil:
+			m.Ops[i].Op = &IDSliceOp_Retain{*op.Retain}
+		case op.Delete != nil:
+			m.Ops[i].Op = &IDSliceOp_Delete{*op.Delete}
+		default:
+			ids := make([]string, len(op.Insert))
+			for j, id := range op.Insert {
+				ids[j] = string(id)
+			}
+			m.Ops[i].Op = &IDSliceOp_Insert{&IDs{Ids: ids}}
+		}
+	}
+	return m
+}
+
+func rolesProto(rs []role) []*Role {
+	if len(rs) == 0 {
+		return nil
+	}
+	ms := make([]*Role, len(rs))
+	for i, r := range rs {
+		ms[i] = &Role{Type: string(r.Type), Player: string(r.Player)}
+	}
+	return ms
+}
+
+// fromProto returns the operationLog that mirrors m. Operations that are
+// missing from m are left empty, to be reported by operations.
+func fromProto(m *OperationLog) *operationLog {
+	l := &operationLog{Version: m.GetVersion(), Ops: make([]operation, len(m.GetOps()))}
+	for i, p := range m.GetOps() {
+		o := &l.Ops[i]
+		o.ID = note.ID(p.GetId())
+		switch op := p.GetOp().(type) {
+		case *Operation_SetValue:
+			o.SetValue = &setValue{
+				Lexical:  op.SetValue.GetLexical(),
+				Datatype: note.ID(op.SetValue.GetDatatype()),
+			}
+		case *Operation_SetValueString:
+			o.SetValueString = &setValueString{op.SetValueString.GetLexical()}
+		case *Operation_ValueDelta:
+			o.ValueDelta = &stringDelta{Ops: make([]stringOp, len(op.ValueDelta.GetOps()))}
+			for j, sop := range op.ValueDelta.GetOps() {
+				switch x := sop.GetOp().(type) {
+				case *StringOp_Insert:
+					o.ValueDelta.Ops[j].Insert = x.Insert
+				case *StringOp_Retain:
+					o.ValueDelta.Ops[j].Retain = &x.Retain
+				case *StringOp_Delete:
+					o.ValueDelta.Ops[j].Delete = &x.Delete
+				}
+			}
+		case *Operation_ContentDelta:
+			o.ContentDelta = idSliceDeltaFromProto(op.ContentDelta)
+		case *Operation_TypesDelta:
+			o.TypesDelta = idSliceDeltaFromProto(op.TypesDelta)
+		case *Operation_RolesDelta:
+			o.RolesDelta = &rolesDelta{
+				Add:    rolesFromProto(op.RolesDelta.GetAdd()),
+				Remove: rolesFromProto(op.RolesDelta.GetRemove()),
+			}
+		case *Operation_SubjectIdentifiersDelta:
+			d := op.SubjectIdentifiersDelta
+			o.SubjectIdentifiersDelta = &stringsDelta{Ops: make([]stringsOp, len(d.GetOps()))}
+			for j, sop := range d.GetOps() {
+				switch x := sop.GetOp().(type) {
+				case *StringsOp_Insert:
+					o.SubjectIdentifiersDelta.Ops[j].Insert = x.Insert.GetValues()
+				case *StringsOp_Retain:
+					o.SubjectIdentifiersDelta.Ops[j].Retain = &x.Retain
+				case *StringsOp_Delete:
+					o.SubjectIdentifiersDelta.Ops[j].Delete = &x.Delete
+				}
+			}
+		case *Operation_ClearNote:
+			o.ClearNote = &clearNote{}
+		}
+	}
+	return l
+}
+
+func idSliceDeltaFromProto(m *IDSliceDelta) *idSliceDelta {
+	d := &idSliceDelta{Ops: make([]idSliceOp, len(m.GetOps()))}
+	for i, op := range m.GetOps() {
+		switch x := op.GetOp().(type) {
+		case *IDSliceOp_Insert:
+			for _, id := range x.Insert.GetIds() {
+				d.Ops[i].Insert = append(d.Ops[i].Insert, note.ID(id))
+			}
+		case *IDSliceOp_Retain:
+			d.Ops[i].Retain = &x.Retain
+		case *IDSliceOp_Delete:
+			d.Ops[i].Delete = &x.Delete
+		}
+	}
+	return d
+}
+
+func rolesFromProto(ms []*Role) []role {
+	if len(ms) == 0 {
+		return nil
+	}
+	rs := make([]role, len(ms))
+	for i, m := range ms {
+		rs[i] = role{note.ID(m.GetType()), note.ID(m.GetPlayer())}
+	}
+	return rs
+}
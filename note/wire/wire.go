@@ -0,0 +1,352 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wire encodes and decodes slices of note.Operation so that they can
+// be stored or sent to other processes.
+//
+// Two encodings are supported: JSON, and the protocol buffer messages
+// described in wire.proto. Both encodings start with a version number, and
+// the field names of the JSON encoding match those of the protocol buffer
+// messages.
+package wire
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/note-maps/note"
+	"github.com/google/note-maps/otgen/runes"
+	"github.com/google/note-maps/otgen/strs"
+)
+
+// Version is the version of the wire format produced by this package.
+const Version = 1
+
+var (
+	// UnsupportedVersion indicates encoded operations with a version that
+	// this package cannot decode.
+	UnsupportedVersion = errors.New("unsupported wire format version")
+
+	// UnsupportedOperation indicates an operation that has no encoding. It is
+	// the same error as note.UnsupportedOperation.
+	UnsupportedOperation = note.UnsupportedOperation
+
+	// Malformed indicates encoded data that does not describe valid
+	// operations.
+	Malformed = errors.New("malformed operation")
+)
+
+// operationLog, and the types it contains, mirror the messages in wire.proto.
+type operationLog struct {
+	Version uint32      `json:"version"`
+	Ops     []operation `json:"ops"`
+}
+
+type operation struct {
+	ID                      note.ID         `json:"id"`
+	SetValue                *setValue       `json:"set_value,omitempty"`
+	SetValueString          *setValueString `json:"set_value_string,omitempty"`
+	ContentDelta            *idSliceDelta   `json:"content_delta,omitempty"`
+	TypesDelta              *idSliceDelta   `json:"types_delta,omitempty"`
+	RolesDelta              *rolesDelta     `json:"roles_delta,omitempty"`
+	SubjectIdentifiersDelta *stringsDelta   `json:"subject_identifiers_delta,omitempty"`
+	ClearNote               *clearNote      `json:"clear_note,omitempty"`
+	ValueDelta              *stringDelta    `json:"value_delta,omitempty"`
+}
+
+type setValue struct {
+	Lexical  string  `json:"lexical"`
+	Datatype note.ID `json:"datatype"`
+}
+
+type setValueString struct {
+	Lexical string `json:"lexical"`
+}
+
+type stringDelta struct {
+	Ops []stringOp `json:"ops"`
+}
+
+type stringOp struct {
+	Insert string  `json:"insert,omitempty"`
+	Retain *uint64 `json:"retain,omitempty"`
+	Delete *uint64 `json:"delete,omitempty"`
+}
+
+type idSliceDelta struct {
+	Ops []idSliceOp `json:"ops"`
+}
+
+type idSliceOp struct {
+	Insert []note.ID `json:"insert,omitempty"`
+	Retain *uint64   `json:"retain,omitempty"`
+	Delete *uint64   `json:"delete,omitempty"`
+}
+
+type rolesDelta struct {
+	Add    []role `json:"add,omitempty"`
+	Remove []role `json:"remove,omitempty"`
+}
+
+type role struct {
+	Type   note.ID `json:"type"`
+	Player note.ID `json:"player"`
+}
+
+type stringsDelta struct {
+	Ops []stringsOp `json:"ops"`
+}
+
+type stringsOp struct {
+	Insert []string `json:"insert,omitempty"`
+	Retain *uint64  `json:"retain,omitempty"`
+	Delete *uint64  `json:"delete,omitempty"`
+}
+
+type clearNote struct{}
+
+func fromOperations(ops []note.Operation) (*operationLog, error) {
+	l := operationLog{Version: Version, Ops: make([]operation, len(ops))}
+	for i, op := range ops {
+		w := &l.Ops[i]
+		switch o := op.(type) {
+		case note.OpSetValue:
+			w.ID = o.GetID()
+			w.SetValue = &setValue{o.Lexical, o.Datatype}
+		case note.OpSetValueString:
+			w.ID = o.GetID()
+			w.SetValueString = &setValueString{o.Lexical}
+		case note.OpValueDelta:
+			w.ID = o.GetID()
+			w.ValueDelta = fromStringOps(o.StringOps)
+		case note.OpContentDelta:
+			w.ID = o.GetID()
+			w.ContentDelta = fromIDSliceOps(o.IDSliceOps)
+		case note.OpTypesDelta:
+			w.ID = o.GetID()
+			w.TypesDelta = fromIDSliceOps(o.IDSliceOps)
+		case note.OpRolesDelta:
+			w.ID = o.GetID()
+			w.RolesDelta = &rolesDelta{fromRoles(o.Add), fromRoles(o.Remove)}
+		case note.OpSubjectIdentifiersDelta:
+			w.ID = o.GetID()
+			w.SubjectIdentifiersDelta = fromStringsOps(o.StringsOps)
+		case note.OpClearNote:
+			w.ID = o.GetID()
+			w.ClearNote = &clearNote{}
+		default:
+			return nil, fmt.Errorf("%T: %w", op, UnsupportedOperation)
+		}
+	}
+	return &l, nil
+}
+
+func (l *operationLog) operations() ([]note.Operation, error) {
+	if l.Version != Version {
+		return nil, fmt.Errorf("%v: %w", l.Version, UnsupportedVersion)
+	}
+	ops := make([]note.Operation, len(l.Ops))
+	for i, w := range l.Ops {
+		if w.ID.Empty() {
+			return nil, fmt.Errorf("operation %v has no note id: %w", i, Malformed)
+		}
+		op := note.Op(w.ID)
+		var err error
+		switch {
+		case w.SetValue != nil:
+			ops[i] = note.OpSetValue{Op: op, Lexical: w.SetValue.Lexical, Datatype: w.SetValue.Datatype}
+		case w.SetValueString != nil:
+			ops[i] = note.OpSetValueString{Op: op, Lexical: w.SetValueString.Lexical}
+		case w.ValueDelta != nil:
+			var o note.OpValueDelta
+			o.Op = op
+			o.StringOps, err = w.ValueDelta.stringOps()
+			ops[i] = o
+		case w.ContentDelta != nil:
+			var o note.OpContentDelta
+			o.Op = op
+			o.IDSliceOps, err = w.ContentDelta.idSliceOps()
+			ops[i] = o
+		case w.TypesDelta != nil:
+			var o note.OpTypesDelta
+			o.Op = op
+			o.IDSliceOps, err = w.TypesDelta.idSliceOps()
+			ops[i] = o
+		case w.RolesDelta != nil:
+			ops[i] = note.OpRolesDelta{
+				Op:     op,
+				Add:    toRoles(w.RolesDelta.Add),
+				Remove: toRoles(w.RolesDelta.Remove),
+			}
+		case w.SubjectIdentifiersDelta != nil:
+			var o note.OpSubjectIdentifiersDelta
+			o.Op = op
+			o.StringsOps, err = w.SubjectIdentifiersDelta.stringsOps()
+			ops[i] = o
+		case w.ClearNote != nil:
+			ops[i] = note.OpClearNote{Op: op}
+		default:
+			err = fmt.Errorf("unrecognized or missing op: %w", Malformed)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("operation %v: %w", i, err)
+		}
+	}
+	return ops, nil
+}
+
+func fromStringOps(ops []runes.StringOp) *stringDelta {
+	d := stringDelta{Ops: make([]stringOp, len(ops))}
+	for i, op := range ops {
+		switch o := op.(type) {
+		case runes.StringOpInsert:
+			d.Ops[i].Insert = string(o)
+		case runes.StringOpRetain:
+			d.Ops[i].Retain = count(int(o))
+		case runes.StringOpDelete:
+			d.Ops[i].Delete = count(int(o))
+		}
+	}
+	return &d
+}
+
+func (d *stringDelta) stringOps() ([]runes.StringOp, error) {
+	ops := make([]runes.StringOp, len(d.Ops))
+	for i, o := range d.Ops {
+		if err := checkCounts(o.Retain, o.Delete); err != nil {
+			return nil, err
+		}
+		switch {
+		case len(o.Insert) > 0:
+			ops[i] = runes.StringOpInsert(o.Insert)
+		case o.Retain != nil:
+			ops[i] = runes.StringOpRetain(*o.Retain)
+		case o.Delete != nil:
+			ops[i] = runes.StringOpDelete(*o.Delete)
+		default:
+			return nil, fmt.Errorf("empty string op: %w", Malformed)
+		}
+	}
+	return ops, nil
+}
+
+func fromIDSliceOps(ops []note.IDSliceOp) *idSliceDelta {
+	d := idSliceDelta{Ops: make([]idSliceOp, len(ops))}
+	for i, op := range ops {
+		switch o := op.(type) {
+		case note.IDSliceOpInsert:
+			d.Ops[i].Insert = []note.ID(o)
+		case note.IDSliceOpRetain:
+			d.Ops[i].Retain = count(int(o))
+		case note.IDSliceOpDelete:
+			d.Ops[i].Delete = count(int(o))
+		}
+	}
+	return &d
+}
+
+func (d *idSliceDelta) idSliceOps() ([]note.IDSliceOp, error) {
+	ops := make([]note.IDSliceOp, len(d.Ops))
+	for i, o := range d.Ops {
+		if err := checkCounts(o.Retain, o.Delete); err != nil {
+			return nil, err
+		}
+		switch {
+		case len(o.Insert) > 0:
+			ops[i] = note.IDSliceOpInsert(o.Insert)
+		case o.Retain != nil:
+			ops[i] = note.IDSliceOpRetain(*o.Retain)
+		case o.Delete != nil:
+			ops[i] = note.IDSliceOpDelete(*o.Delete)
+		default:
+			return nil, fmt.Errorf("empty id slice op: %w", Malformed)
+		}
+	}
+	return ops, nil
+}
+
+func fromStringsOps(ops []strs.StringsOp) *stringsDelta {
+	d := stringsDelta{Ops: make([]stringsOp, len(ops))}
+	for i, op := range ops {
+		switch o := op.(type) {
+		case strs.StringsOpInsert:
+			d.Ops[i].Insert = []string(o)
+		case strs.StringsOpRetain:
+			d.Ops[i].Retain = count(int(o))
+		case strs.StringsOpDelete:
+			d.Ops[i].Delete = count(int(o))
+		}
+	}
+	return &d
+}
+
+func (d *stringsDelta) stringsOps() ([]strs.StringsOp, error) {
+	ops := make([]strs.StringsOp, len(d.Ops))
+	for i, o := range d.Ops {
+		if err := checkCounts(o.Retain, o.Delete); err != nil {
+			return nil, err
+		}
+		switch {
+		case len(o.Insert) > 0:
+			ops[i] = strs.StringsOpInsert(o.Insert)
+		case o.Retain != nil:
+			ops[i] = strs.StringsOpRetain(*o.Retain)
+		case o.Delete != nil:
+			ops[i] = strs.StringsOpDelete(*o.Delete)
+		default:
+			return nil, fmt.Errorf("empty strings op: %w", Malformed)
+		}
+	}
+	return ops, nil
+}
+
+func fromRoles(rs []note.Role) []role {
+	if len(rs) == 0 {
+		return nil
+	}
+	ws := make([]role, len(rs))
+	for i, r := range rs {
+		ws[i] = role{r.Type, r.Player}
+	}
+	return ws
+}
+
+func toRoles(ws []role) []note.Role {
+	if len(ws) == 0 {
+		return nil
+	}
+	rs := make([]note.Role, len(ws))
+	for i, w := range ws {
+		rs[i] = note.Role{Type: w.Type, Player: w.Player}
+	}
+	return rs
+}
+
+func count(n int) *uint64 {
+	c := uint64(n)
+	return &c
+}
+
+// maxInt is the largest value of an int.
+const maxInt = int(^uint(0) >> 1)
+
+// checkCounts returns an error if any of cs is too large to be an int.
+func checkCounts(cs ...*uint64) error {
+	for _, c := range cs {
+		if c != nil && *c > uint64(maxInt) {
+			return fmt.Errorf("count %v is too large: %w", *c, Malformed)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,1436 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The wire format of note.Operation slices.
+//
+// The Go code for these messages is generated by protoc; see the go:generate
+// directive in proto.go.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: wire.proto
+
+package wire
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OperationLog is a sequence of operations to be applied in order.
+type OperationLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Version of the wire format, currently 1.
+	Version uint32       `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Ops     []*Operation `protobuf:"bytes,2,rep,name=ops,proto3" json:"ops,omitempty"`
+}
+
+func (x *OperationLog) Reset() {
+	*x = OperationLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperationLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationLog) ProtoMessage() {}
+
+func (x *OperationLog) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationLog.ProtoReflect.Descriptor instead.
+func (*OperationLog) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OperationLog) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *OperationLog) GetOps() []*Operation {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+// Operation is a change to exactly one note.
+type Operation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the note that is changed.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are assignable to Op:
+	//	*Operation_SetValue
+	//	*Operation_SetValueString
+	//	*Operation_ContentDelta
+	//	*Operation_TypesDelta
+	//	*Operation_RolesDelta
+	//	*Operation_SubjectIdentifiersDelta
+	//	*Operation_ClearNote
+	//	*Operation_ValueDelta
+	Op isOperation_Op `protobuf_oneof:"op"`
+}
+
+func (x *Operation) Reset() {
+	*x = Operation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Operation) ProtoMessage() {}
+
+func (x *Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Operation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (m *Operation) GetOp() isOperation_Op {
+	if m != nil {
+		return m.Op
+	}
+	return nil
+}
+
+func (x *Operation) GetSetValue() *SetValue {
+	if x, ok := x.GetOp().(*Operation_SetValue); ok {
+		return x.SetValue
+	}
+	return nil
+}
+
+func (x *Operation) GetSetValueString() *SetValueString {
+	if x, ok := x.GetOp().(*Operation_SetValueString); ok {
+		return x.SetValueString
+	}
+	return nil
+}
+
+func (x *Operation) GetContentDelta() *IDSliceDelta {
+	if x, ok := x.GetOp().(*Operation_ContentDelta); ok {
+		return x.ContentDelta
+	}
+	return nil
+}
+
+func (x *Operation) GetTypesDelta() *IDSliceDelta {
+	if x, ok := x.GetOp().(*Operation_TypesDelta); ok {
+		return x.TypesDelta
+	}
+	return nil
+}
+
+func (x *Operation) GetRolesDelta() *RolesDelta {
+	if x, ok := x.GetOp().(*Operation_RolesDelta); ok {
+		return x.RolesDelta
+	}
+	return nil
+}
+
+func (x *Operation) GetSubjectIdentifiersDelta() *StringsDelta {
+	if x, ok := x.GetOp().(*Operation_SubjectIdentifiersDelta); ok {
+		return x.SubjectIdentifiersDelta
+	}
+	return nil
+}
+
+func (x *Operation) GetClearNote() *ClearNote {
+	if x, ok := x.GetOp().(*Operation_ClearNote); ok {
+		return x.ClearNote
+	}
+	return nil
+}
+
+func (x *Operation) GetValueDelta() *StringDelta {
+	if x, ok := x.GetOp().(*Operation_ValueDelta); ok {
+		return x.ValueDelta
+	}
+	return nil
+}
+
+type isOperation_Op interface {
+	isOperation_Op()
+}
+
+type Operation_SetValue struct {
+	SetValue *SetValue `protobuf:"bytes,2,opt,name=set_value,json=setValue,proto3,oneof"`
+}
+
+type Operation_SetValueString struct {
+	SetValueString *SetValueString `protobuf:"bytes,3,opt,name=set_value_string,json=setValueString,proto3,oneof"`
+}
+
+type Operation_ContentDelta struct {
+	ContentDelta *IDSliceDelta `protobuf:"bytes,4,opt,name=content_delta,json=contentDelta,proto3,oneof"`
+}
+
+type Operation_TypesDelta struct {
+	TypesDelta *IDSliceDelta `protobuf:"bytes,5,opt,name=types_delta,json=typesDelta,proto3,oneof"`
+}
+
+type Operation_RolesDelta struct {
+	RolesDelta *RolesDelta `protobuf:"bytes,6,opt,name=roles_delta,json=rolesDelta,proto3,oneof"`
+}
+
+type Operation_SubjectIdentifiersDelta struct {
+	SubjectIdentifiersDelta *StringsDelta `protobuf:"bytes,7,opt,name=subject_identifiers_delta,json=subjectIdentifiersDelta,proto3,oneof"`
+}
+
+type Operation_ClearNote struct {
+	ClearNote *ClearNote `protobuf:"bytes,8,opt,name=clear_note,json=clearNote,proto3,oneof"`
+}
+
+type Operation_ValueDelta struct {
+	ValueDelta *StringDelta `protobuf:"bytes,9,opt,name=value_delta,json=valueDelta,proto3,oneof"`
+}
+
+func (*Operation_SetValue) isOperation_Op() {}
+
+func (*Operation_SetValueString) isOperation_Op() {}
+
+func (*Operation_ContentDelta) isOperation_Op() {}
+
+func (*Operation_TypesDelta) isOperation_Op() {}
+
+func (*Operation_RolesDelta) isOperation_Op() {}
+
+func (*Operation_SubjectIdentifiersDelta) isOperation_Op() {}
+
+func (*Operation_ClearNote) isOperation_Op() {}
+
+func (*Operation_ValueDelta) isOperation_Op() {}
+
+type SetValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lexical  string `protobuf:"bytes,1,opt,name=lexical,proto3" json:"lexical,omitempty"`
+	Datatype string `protobuf:"bytes,2,opt,name=datatype,proto3" json:"datatype,omitempty"`
+}
+
+func (x *SetValue) Reset() {
+	*x = SetValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetValue) ProtoMessage() {}
+
+func (x *SetValue) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetValue.ProtoReflect.Descriptor instead.
+func (*SetValue) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetValue) GetLexical() string {
+	if x != nil {
+		return x.Lexical
+	}
+	return ""
+}
+
+func (x *SetValue) GetDatatype() string {
+	if x != nil {
+		return x.Datatype
+	}
+	return ""
+}
+
+type SetValueString struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lexical string `protobuf:"bytes,1,opt,name=lexical,proto3" json:"lexical,omitempty"`
+}
+
+func (x *SetValueString) Reset() {
+	*x = SetValueString{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetValueString) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetValueString) ProtoMessage() {}
+
+func (x *SetValueString) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetValueString.ProtoReflect.Descriptor instead.
+func (*SetValueString) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetValueString) GetLexical() string {
+	if x != nil {
+		return x.Lexical
+	}
+	return ""
+}
+
+// StringDelta edits a string rune by rune. Retain and delete counts are in
+// runes, not bytes.
+type StringDelta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ops []*StringOp `protobuf:"bytes,1,rep,name=ops,proto3" json:"ops,omitempty"`
+}
+
+func (x *StringDelta) Reset() {
+	*x = StringDelta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StringDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringDelta) ProtoMessage() {}
+
+func (x *StringDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringDelta.ProtoReflect.Descriptor instead.
+func (*StringDelta) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StringDelta) GetOps() []*StringOp {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+type StringOp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Op:
+	//	*StringOp_Insert
+	//	*StringOp_Retain
+	//	*StringOp_Delete
+	Op isStringOp_Op `protobuf_oneof:"op"`
+}
+
+func (x *StringOp) Reset() {
+	*x = StringOp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StringOp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringOp) ProtoMessage() {}
+
+func (x *StringOp) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringOp.ProtoReflect.Descriptor instead.
+func (*StringOp) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{5}
+}
+
+func (m *StringOp) GetOp() isStringOp_Op {
+	if m != nil {
+		return m.Op
+	}
+	return nil
+}
+
+func (x *StringOp) GetInsert() string {
+	if x, ok := x.GetOp().(*StringOp_Insert); ok {
+		return x.Insert
+	}
+	return ""
+}
+
+func (x *StringOp) GetRetain() uint64 {
+	if x, ok := x.GetOp().(*StringOp_Retain); ok {
+		return x.Retain
+	}
+	return 0
+}
+
+func (x *StringOp) GetDelete() uint64 {
+	if x, ok := x.GetOp().(*StringOp_Delete); ok {
+		return x.Delete
+	}
+	return 0
+}
+
+type isStringOp_Op interface {
+	isStringOp_Op()
+}
+
+type StringOp_Insert struct {
+	Insert string `protobuf:"bytes,1,opt,name=insert,proto3,oneof"`
+}
+
+type StringOp_Retain struct {
+	Retain uint64 `protobuf:"varint,2,opt,name=retain,proto3,oneof"`
+}
+
+type StringOp_Delete struct {
+	Delete uint64 `protobuf:"varint,3,opt,name=delete,proto3,oneof"`
+}
+
+func (*StringOp_Insert) isStringOp_Op() {}
+
+func (*StringOp_Retain) isStringOp_Op() {}
+
+func (*StringOp_Delete) isStringOp_Op() {}
+
+type IDSliceDelta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ops []*IDSliceOp `protobuf:"bytes,1,rep,name=ops,proto3" json:"ops,omitempty"`
+}
+
+func (x *IDSliceDelta) Reset() {
+	*x = IDSliceDelta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IDSliceDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IDSliceDelta) ProtoMessage() {}
+
+func (x *IDSliceDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IDSliceDelta.ProtoReflect.Descriptor instead.
+func (*IDSliceDelta) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *IDSliceDelta) GetOps() []*IDSliceOp {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+type IDSliceOp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Op:
+	//	*IDSliceOp_Insert
+	//	*IDSliceOp_Retain
+	//	*IDSliceOp_Delete
+	Op isIDSliceOp_Op `protobuf_oneof:"op"`
+}
+
+func (x *IDSliceOp) Reset() {
+	*x = IDSliceOp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IDSliceOp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IDSliceOp) ProtoMessage() {}
+
+func (x *IDSliceOp) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IDSliceOp.ProtoReflect.Descriptor instead.
+func (*IDSliceOp) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{7}
+}
+
+func (m *IDSliceOp) GetOp() isIDSliceOp_Op {
+	if m != nil {
+		return m.Op
+	}
+	return nil
+}
+
+func (x *IDSliceOp) GetInsert() *IDs {
+	if x, ok := x.GetOp().(*IDSliceOp_Insert); ok {
+		return x.Insert
+	}
+	return nil
+}
+
+func (x *IDSliceOp) GetRetain() uint64 {
+	if x, ok := x.GetOp().(*IDSliceOp_Retain); ok {
+		return x.Retain
+	}
+	return 0
+}
+
+func (x *IDSliceOp) GetDelete() uint64 {
+	if x, ok := x.GetOp().(*IDSliceOp_Delete); ok {
+		return x.Delete
+	}
+	return 0
+}
+
+type isIDSliceOp_Op interface {
+	isIDSliceOp_Op()
+}
+
+type IDSliceOp_Insert struct {
+	Insert *IDs `protobuf:"bytes,1,opt,name=insert,proto3,oneof"`
+}
+
+type IDSliceOp_Retain struct {
+	Retain uint64 `protobuf:"varint,2,opt,name=retain,proto3,oneof"`
+}
+
+type IDSliceOp_Delete struct {
+	Delete uint64 `protobuf:"varint,3,opt,name=delete,proto3,oneof"`
+}
+
+func (*IDSliceOp_Insert) isIDSliceOp_Op() {}
+
+func (*IDSliceOp_Retain) isIDSliceOp_Op() {}
+
+func (*IDSliceOp_Delete) isIDSliceOp_Op() {}
+
+type IDs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *IDs) Reset() {
+	*x = IDs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IDs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IDs) ProtoMessage() {}
+
+func (x *IDs) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IDs.ProtoReflect.Descriptor instead.
+func (*IDs) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *IDs) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type RolesDelta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Add    []*Role `protobuf:"bytes,1,rep,name=add,proto3" json:"add,omitempty"`
+	Remove []*Role `protobuf:"bytes,2,rep,name=remove,proto3" json:"remove,omitempty"`
+}
+
+func (x *RolesDelta) Reset() {
+	*x = RolesDelta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RolesDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RolesDelta) ProtoMessage() {}
+
+func (x *RolesDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RolesDelta.ProtoReflect.Descriptor instead.
+func (*RolesDelta) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RolesDelta) GetAdd() []*Role {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *RolesDelta) GetRemove() []*Role {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+type Role struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type   string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Player string `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+}
+
+func (x *Role) Reset() {
+	*x = Role{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Role) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Role) ProtoMessage() {}
+
+func (x *Role) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Role.ProtoReflect.Descriptor instead.
+func (*Role) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Role) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Role) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+type StringsDelta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ops []*StringsOp `protobuf:"bytes,1,rep,name=ops,proto3" json:"ops,omitempty"`
+}
+
+func (x *StringsDelta) Reset() {
+	*x = StringsDelta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StringsDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringsDelta) ProtoMessage() {}
+
+func (x *StringsDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringsDelta.ProtoReflect.Descriptor instead.
+func (*StringsDelta) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StringsDelta) GetOps() []*StringsOp {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+type StringsOp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Op:
+	//	*StringsOp_Insert
+	//	*StringsOp_Retain
+	//	*StringsOp_Delete
+	Op isStringsOp_Op `protobuf_oneof:"op"`
+}
+
+func (x *StringsOp) Reset() {
+	*x = StringsOp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StringsOp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringsOp) ProtoMessage() {}
+
+func (x *StringsOp) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringsOp.ProtoReflect.Descriptor instead.
+func (*StringsOp) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{12}
+}
+
+func (m *StringsOp) GetOp() isStringsOp_Op {
+	if m != nil {
+		return m.Op
+	}
+	return nil
+}
+
+func (x *StringsOp) GetInsert() *Strings {
+	if x, ok := x.GetOp().(*StringsOp_Insert); ok {
+		return x.Insert
+	}
+	return nil
+}
+
+func (x *StringsOp) GetRetain() uint64 {
+	if x, ok := x.GetOp().(*StringsOp_Retain); ok {
+		return x.Retain
+	}
+	return 0
+}
+
+func (x *StringsOp) GetDelete() uint64 {
+	if x, ok := x.GetOp().(*StringsOp_Delete); ok {
+		return x.Delete
+	}
+	return 0
+}
+
+type isStringsOp_Op interface {
+	isStringsOp_Op()
+}
+
+type StringsOp_Insert struct {
+	Insert *Strings `protobuf:"bytes,1,opt,name=insert,proto3,oneof"`
+}
+
+type StringsOp_Retain struct {
+	Retain uint64 `protobuf:"varint,2,opt,name=retain,proto3,oneof"`
+}
+
+type StringsOp_Delete struct {
+	Delete uint64 `protobuf:"varint,3,opt,name=delete,proto3,oneof"`
+}
+
+func (*StringsOp_Insert) isStringsOp_Op() {}
+
+func (*StringsOp_Retain) isStringsOp_Op() {}
+
+func (*StringsOp_Delete) isStringsOp_Op() {}
+
+type Strings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *Strings) Reset() {
+	*x = Strings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Strings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Strings) ProtoMessage() {}
+
+func (x *Strings) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Strings.ProtoReflect.Descriptor instead.
+func (*Strings) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Strings) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type ClearNote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ClearNote) Reset() {
+	*x = ClearNote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wire_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearNote) ProtoMessage() {}
+
+func (x *ClearNote) ProtoReflect() protoreflect.Message {
+	mi := &file_wire_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearNote.ProtoReflect.Descriptor instead.
+func (*ClearNote) Descriptor() ([]byte, []int) {
+	return file_wire_proto_rawDescGZIP(), []int{14}
+}
+
+var File_wire_proto protoreflect.FileDescriptor
+
+var file_wire_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x6e, 0x6f,
+	0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69, 0x72, 0x65,
+	0x22, 0x59, 0x0a, 0x0c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x67,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x03, 0x6f, 0x70,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x6d, 0x61,
+	0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x6f, 0x70, 0x73, 0x22, 0xe3, 0x04, 0x0a, 0x09,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3b, 0x0a, 0x09, 0x73, 0x65, 0x74,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e,
+	0x6f, 0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69, 0x72,
+	0x65, 0x2e, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x08, 0x73, 0x65,
+	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x4e, 0x0a, 0x10, 0x73, 0x65, 0x74, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
+	0x2e, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x47, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x6e, 0x6f, 0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69,
+	0x72, 0x65, 0x2e, 0x49, 0x44, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x48,
+	0x00, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12,
+	0x43, 0x0a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x73, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e,
+	0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x49, 0x44, 0x53, 0x6c, 0x69, 0x63,
+	0x65, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x73, 0x44,
+	0x65, 0x6c, 0x74, 0x61, 0x12, 0x41, 0x0a, 0x0b, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x5f, 0x64, 0x65,
+	0x6c, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
+	0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x52,
+	0x6f, 0x6c, 0x65, 0x73, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x6f, 0x6c,
+	0x65, 0x73, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x5e, 0x0a, 0x19, 0x73, 0x75, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x5f, 0x64,
+	0x65, 0x6c, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6e, 0x6f, 0x74,
+	0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69, 0x72, 0x65, 0x2e,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x48, 0x00, 0x52, 0x17,
+	0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x73, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x3e, 0x0a, 0x0a, 0x63, 0x6c, 0x65, 0x61, 0x72,
+	0x5f, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6e, 0x6f,
+	0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69, 0x72, 0x65,
+	0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x4e, 0x6f, 0x74, 0x65, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6c,
+	0x65, 0x61, 0x72, 0x4e, 0x6f, 0x74, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e,
+	0x6f, 0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69, 0x72,
+	0x65, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x48, 0x00, 0x52,
+	0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x42, 0x04, 0x0a, 0x02, 0x6f,
+	0x70, 0x22, 0x40, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x74,
+	0x79, 0x70, 0x65, 0x22, 0x2a, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c, 0x22,
+	0x3d, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x2e,
+	0x0a, 0x03, 0x6f, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x6f,
+	0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69, 0x72, 0x65,
+	0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4f, 0x70, 0x52, 0x03, 0x6f, 0x70, 0x73, 0x22, 0x5e,
+	0x0a, 0x08, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4f, 0x70, 0x12, 0x18, 0x0a, 0x06, 0x69, 0x6e,
+	0x73, 0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x69, 0x6e,
+	0x73, 0x65, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x12, 0x18,
+	0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00,
+	0x52, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x04, 0x0a, 0x02, 0x6f, 0x70, 0x22, 0x3f,
+	0x0a, 0x0c, 0x49, 0x44, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x2f,
+	0x0a, 0x03, 0x6f, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6e, 0x6f,
+	0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69, 0x72, 0x65,
+	0x2e, 0x49, 0x44, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x52, 0x03, 0x6f, 0x70, 0x73, 0x22,
+	0x78, 0x0a, 0x09, 0x49, 0x44, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x12, 0x31, 0x0a, 0x06,
+	0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6e,
+	0x6f, 0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69, 0x72,
+	0x65, 0x2e, 0x49, 0x44, 0x73, 0x48, 0x00, 0x52, 0x06, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x12,
+	0x18, 0x0a, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48,
+	0x00, 0x52, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x06, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x06, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x42, 0x04, 0x0a, 0x02, 0x6f, 0x70, 0x22, 0x17, 0x0a, 0x03, 0x49, 0x44, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69,
+	0x64, 0x73, 0x22, 0x6a, 0x0a, 0x0a, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x44, 0x65, 0x6c, 0x74, 0x61,
+	0x12, 0x2a, 0x0a, 0x03, 0x61, 0x64, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x6e, 0x6f, 0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69,
+	0x72, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x03, 0x61, 0x64, 0x64, 0x12, 0x30, 0x0a, 0x06,
+	0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6e,
+	0x6f, 0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x69, 0x72,
+	0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0x32,
+	0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x22, 0x3f, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x44, 0x65, 0x6c,
+	0x74, 0x61, 0x12, 0x2f, 0x0a, 0x03, 0x6f, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x2e,
+	0x77, 0x69, 0x72, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x4f, 0x70, 0x52, 0x03,
+	0x6f, 0x70, 0x73, 0x22, 0x7c, 0x0a, 0x09, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x4f, 0x70,
+	0x12, 0x35, 0x0a, 0x06, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x6e, 0x6f, 0x74, 0x65, 0x6d, 0x61, 0x70, 0x73, 0x2e, 0x6e, 0x6f, 0x74, 0x65,
+	0x2e, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x48, 0x00, 0x52,
+	0x06, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69,
+	0x6e, 0x12, 0x18, 0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x48, 0x00, 0x52, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x04, 0x0a, 0x02, 0x6f,
+	0x70, 0x22, 0x21, 0x0a, 0x07, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x22, 0x0b, 0x0a, 0x09, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x4e, 0x6f, 0x74,
+	0x65, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x6e, 0x6f, 0x74, 0x65, 0x2d, 0x6d, 0x61, 0x70, 0x73,
+	0x2f, 0x6e, 0x6f, 0x74, 0x65, 0x2f, 0x77, 0x69, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_wire_proto_rawDescOnce sync.Once
+	file_wire_proto_rawDescData = file_wire_proto_rawDesc
+)
+
+func file_wire_proto_rawDescGZIP() []byte {
+	file_wire_proto_rawDescOnce.Do(func() {
+		file_wire_proto_rawDescData = protoimpl.X.CompressGZIP(file_wire_proto_rawDescData)
+	})
+	return file_wire_proto_rawDescData
+}
+
+var file_wire_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_wire_proto_goTypes = []interface{}{
+	(*OperationLog)(nil),   // 0: notemaps.note.wire.OperationLog
+	(*Operation)(nil),      // 1: notemaps.note.wire.Operation
+	(*SetValue)(nil),       // 2: notemaps.note.wire.SetValue
+	(*SetValueString)(nil), // 3: notemaps.note.wire.SetValueString
+	(*StringDelta)(nil),    // 4: notemaps.note.wire.StringDelta
+	(*StringOp)(nil),       // 5: notemaps.note.wire.StringOp
+	(*IDSliceDelta)(nil),   // 6: notemaps.note.wire.IDSliceDelta
+	(*IDSliceOp)(nil),      // 7: notemaps.note.wire.IDSliceOp
+	(*IDs)(nil),            // 8: notemaps.note.wire.IDs
+	(*RolesDelta)(nil),     // 9: notemaps.note.wire.RolesDelta
+	(*Role)(nil),           // 10: notemaps.note.wire.Role
+	(*StringsDelta)(nil),   // 11: notemaps.note.wire.StringsDelta
+	(*StringsOp)(nil),      // 12: notemaps.note.wire.StringsOp
+	(*Strings)(nil),        // 13: notemaps.note.wire.Strings
+	(*ClearNote)(nil),      // 14: notemaps.note.wire.ClearNote
+}
+var file_wire_proto_depIdxs = []int32{
+	1,  // 0: notemaps.note.wire.OperationLog.ops:type_name -> notemaps.note.wire.Operation
+	2,  // 1: notemaps.note.wire.Operation.set_value:type_name -> notemaps.note.wire.SetValue
+	3,  // 2: notemaps.note.wire.Operation.set_value_string:type_name -> notemaps.note.wire.SetValueString
+	6,  // 3: notemaps.note.wire.Operation.content_delta:type_name -> notemaps.note.wire.IDSliceDelta
+	6,  // 4: notemaps.note.wire.Operation.types_delta:type_name -> notemaps.note.wire.IDSliceDelta
+	9,  // 5: notemaps.note.wire.Operation.roles_delta:type_name -> notemaps.note.wire.RolesDelta
+	11, // 6: notemaps.note.wire.Operation.subject_identifiers_delta:type_name -> notemaps.note.wire.StringsDelta
+	14, // 7: notemaps.note.wire.Operation.clear_note:type_name -> notemaps.note.wire.ClearNote
+	4,  // 8: notemaps.note.wire.Operation.value_delta:type_name -> notemaps.note.wire.StringDelta
+	5,  // 9: notemaps.note.wire.StringDelta.ops:type_name -> notemaps.note.wire.StringOp
+	7,  // 10: notemaps.note.wire.IDSliceDelta.ops:type_name -> notemaps.note.wire.IDSliceOp
+	8,  // 11: notemaps.note.wire.IDSliceOp.insert:type_name -> notemaps.note.wire.IDs
+	10, // 12: notemaps.note.wire.RolesDelta.add:type_name -> notemaps.note.wire.Role
+	10, // 13: notemaps.note.wire.RolesDelta.remove:type_name -> notemaps.note.wire.Role
+	12, // 14: notemaps.note.wire.StringsDelta.ops:type_name -> notemaps.note.wire.StringsOp
+	13, // 15: notemaps.note.wire.StringsOp.insert:type_name -> notemaps.note.wire.Strings
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_wire_proto_init() }
+func file_wire_proto_init() {
+	if File_wire_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_wire_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Operation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetValueString); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StringDelta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StringOp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IDSliceDelta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IDSliceOp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IDs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RolesDelta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Role); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StringsDelta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StringsOp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Strings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wire_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClearNote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_wire_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*Operation_SetValue)(nil),
+		(*Operation_SetValueString)(nil),
+		(*Operation_ContentDelta)(nil),
+		(*Operation_TypesDelta)(nil),
+		(*Operation_RolesDelta)(nil),
+		(*Operation_SubjectIdentifiersDelta)(nil),
+		(*Operation_ClearNote)(nil),
+		(*Operation_ValueDelta)(nil),
+	}
+	file_wire_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*StringOp_Insert)(nil),
+		(*StringOp_Retain)(nil),
+		(*StringOp_Delete)(nil),
+	}
+	file_wire_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*IDSliceOp_Insert)(nil),
+		(*IDSliceOp_Retain)(nil),
+		(*IDSliceOp_Delete)(nil),
+	}
+	file_wire_proto_msgTypes[12].OneofWrappers = []interface{}{
+		(*StringsOp_Insert)(nil),
+		(*StringsOp_Retain)(nil),
+		(*StringsOp_Delete)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_wire_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_wire_proto_goTypes,
+		DependencyIndexes: file_wire_proto_depIdxs,
+		MessageInfos:      file_wire_proto_msgTypes,
+	}.Build()
+	File_wire_proto = out.File
+	file_wire_proto_rawDesc = nil
+	file_wire_proto_goTypes = nil
+	file_wire_proto_depIdxs = nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wire
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/google/note-maps/note"
+	"github.com/google/note-maps/otgen/runes"
+	"github.com/google/note-maps/otgen/strs"
+	"google.golang.org/protobuf/proto"
+)
+
+func testOperations() note.OperationSlice {
+	var ops note.OperationSlice
+	ops = ops.SetValue("n0", "hello", "vt0")
+	ops = ops.SetValue("n0", "", note.EmptyID)
+	ops = ops.SetValueString("n0", "world")
+	ops = ops.PatchValue("n0", runes.StringDiff([]rune("world"), []rune("wörld!")))
+	ops = ops.PatchContent("n0", note.IDSlice{"c0", "c1"}.Append("c2"))
+	ops = ops.PatchContent("n0", note.IDSlice{"c0", "c1"}.DeleteElements("c0"))
+	ops = ops.PatchTypes("n0", note.IDSlice{}.Append("t0", ""))
+	ops = ops.PatchRoles("n1", []note.Role{{Type: "r0", Player: "n0"}, {Player: "n2"}}, []note.Role{{Type: "r1", Player: "n3"}})
+	ops = ops.PatchSubjectIdentifiers("n1", strs.Strings{"a", "b"}.Insert(1, "", "c"))
+	ops = ops.ClearNote("n2")
+	return ops
+}
+
+func TestJSON(t *testing.T) {
+	ops := testOperations()
+	data, err := MarshalJSON(ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(note.OperationSlice(got), ops) {
+		t.Errorf("got %v, expected %v", got, ops)
+	}
+}
+
+func TestMarshalJSON_format(t *testing.T) {
+	var ops note.OperationSlice
+	ops = ops.SetValue("n0", "hello", "")
+	ops = ops.PatchContent("n0", note.IDSlice{"n1"}.Append("n2"))
+	data, err := MarshalJSON(ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `{"version":1,"ops":[` +
+		`{"id":"n0","set_value":{"lexical":"hello","datatype":""}},` +
+		`{"id":"n0","content_delta":{"ops":[{"retain":1},{"insert":["n2"]}]}}]}`
+	if string(data) != expect {
+		t.Errorf("got %s, expected %s", data, expect)
+	}
+}
+
+func TestProto(t *testing.T) {
+	ops := testOperations()
+	data, err := MarshalProto(ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalProto(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(note.OperationSlice(got), ops) {
+		t.Errorf("got %v, expected %v", got, ops)
+	}
+}
+
+func TestMarshalProto_format(t *testing.T) {
+	data, err := MarshalProto(note.OperationSlice{}.ClearNote("n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// version=1, ops={id="n", clear_note={}}
+	expect := []byte{0x08, 0x01, 0x12, 0x05, 0x0a, 0x01, 'n', 0x42, 0x00}
+	if !reflect.DeepEqual(data, expect) {
+		t.Errorf("got %#v, expected %#v", data, expect)
+	}
+}
+
+func TestUnmarshal_unsupportedVersion(t *testing.T) {
+	if _, err := UnmarshalJSON([]byte(`{"version":2,"ops":[]}`)); !errors.Is(err, UnsupportedVersion) {
+		t.Errorf("got %v, expected %v", err, UnsupportedVersion)
+	}
+	if _, err := UnmarshalProto([]byte{0x08, 0x02}); !errors.Is(err, UnsupportedVersion) {
+		t.Errorf("got %v, expected %v", err, UnsupportedVersion)
+	}
+}
+
+func TestUnmarshal_malformed(t *testing.T) {
+	for _, in := range []string{
+		`{"version":1,"ops":[{"id":"n0"}]}`,
+		`{"version":1,"ops":[{"set_value":{}}]}`,
+		`{"version":1,"ops":[{"id":"n0","content_delta":{"ops":[{}]}}]}`,
+		`{"version":1,"ops":[{"id":"n0","content_delta":{"ops":[{"retain":18446744073709551615}]}}]}`,
+		`[]`,
+	} {
+		if _, err := UnmarshalJSON([]byte(in)); !errors.Is(err, Malformed) {
+			t.Errorf("%s: got %v, expected %v", in, err, Malformed)
+		}
+	}
+	if _, err := UnmarshalProto([]byte{0x12, 0x05}); !errors.Is(err, Malformed) {
+		t.Errorf("got %v, expected %v", err, Malformed)
+	}
+	overflow, err := proto.Marshal(&OperationLog{Version: 1, Ops: []*Operation{{
+		Id: "n0",
+		Op: &Operation_ContentDelta{&IDSliceDelta{Ops: []*IDSliceOp{
+			{Op: &IDSliceOp_Delete{math.MaxUint64}},
+		}}},
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UnmarshalProto(overflow); !errors.Is(err, Malformed) {
+		t.Errorf("got %v, expected %v", err, Malformed)
+	}
+}
+
+type unknownOp struct{ note.Op }
+
+func TestMarshal_unsupportedOperation(t *testing.T) {
+	ops := []note.Operation{unknownOp{"n0"}}
+	if _, err := MarshalJSON(ops); !errors.Is(err, UnsupportedOperation) {
+		t.Errorf("got %v, expected %v", err, UnsupportedOperation)
+	}
+	if _, err := MarshalProto(ops); !errors.Is(err, UnsupportedOperation) {
+		t.Errorf("got %v, expected %v", err, UnsupportedOperation)
+	}
+}
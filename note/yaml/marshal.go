@@ -15,110 +15,267 @@
 package yaml
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/google/note-maps/note"
 	"gopkg.in/yaml.v3"
 )
 
+// MarshalNote returns the YAML representation of src, with display names in
+// the default locale.
 func MarshalNote(src note.GraphNote) ([]byte, error) {
+	return Marshaler{}.MarshalNote(src)
+}
+
+// Marshaler marshals notes to YAML.
+//
+// Identifiers of notes appear as anchors and as the keys of mappings, so
+// wherever one of those notes has a display name as described by
+// note.DisplayName, the name is added as a comment.
+type Marshaler struct {
+	// Locales are the preferred locales of display names, most preferred
+	// first.
+	Locales []string
+}
+
+// MarshalNote returns the YAML representation of src.
+func (m Marshaler) MarshalNote(src note.GraphNote) ([]byte, error) {
 	if src == nil {
 		return nil, nil
 	}
-	var dst yaml.Node
-	if err := noteToYaml(src, &dst, 0); err != nil {
+	e := encoder{m, make(map[note.ID]*yaml.Node)}
+	dst, err := e.document(src)
+	if err != nil {
 		return nil, err
 	}
-	return yaml.Marshal(&dst)
+	return yaml.Marshal(dst)
 }
 
-type done int
+// comment returns the display name of n, or "" if it has none.
+func (m Marshaler) comment(n note.GraphNote) (string, error) {
+	name, ok, err := note.DisplayName(n, m.Locales...)
+	if err != nil || !ok {
+		return "", err
+	}
+	return name, nil
+}
 
-const (
-	doneID done = 1 << iota
-	doneType0
-	doneParent
-)
+// encoder writes one YAML document, keeping track of the anchor of each note
+// written so far so that any later reference to the same note is an alias.
+type encoder struct {
+	Marshaler
+	anchors map[note.ID]*yaml.Node
+}
 
-func noteToYaml(src note.GraphNote, dst *yaml.Node, done done) error {
-	if dst.Kind == 0 {
-		dst.Kind = yaml.DocumentNode
-	}
-	cs, err := src.GetContents()
+func (e encoder) document(src note.GraphNote) (*yaml.Node, error) {
+	key, value, err := e.entry(src, false)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	dst := &yaml.Node{
+		Kind:    yaml.DocumentNode,
+		Content: []*yaml.Node{{Kind: yaml.MappingNode, Content: []*yaml.Node{key, value}}},
 	}
+	if dst.HeadComment, err = e.comment(src); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// entry returns the key and value of a mapping that describes src. The key is
+// the ID of the primary type of src, or "note" if it has none or if that ID is
+// a reserved word.
+func (e encoder) entry(src note.GraphNote, scalar bool) (key, value *yaml.Node, err error) {
 	ts, err := src.GetTypes()
 	if err != nil {
-		return err
-	}
-	switch dst.Kind {
-	case yaml.DocumentNode:
-		dst.Content = []*yaml.Node{{Kind: yaml.MappingNode}}
-		return noteToYaml(src, dst.Content[0], done)
-	case yaml.MappingNode:
-		var typ string
-		if len(ts) == 0 {
-			typ = "note"
-		} else {
-			typ = ts[0].GetID().String()
+		return nil, nil, err
+	}
+	key = str("note")
+	var name string
+	if len(ts) > 0 && !reserved[ts[0].GetID().String()] {
+		key.Value = ts[0].GetID().String()
+		if name, err = e.comment(ts[0]); err != nil {
+			return nil, nil, err
 		}
-		dst.Content = []*yaml.Node{
-			{Kind: yaml.ScalarNode, Value: typ},
-			{Kind: yaml.SequenceNode},
+		ts = ts[1:]
+	}
+	if value, err = e.note(src, ts, scalar); err != nil {
+		return nil, nil, err
+	}
+	// A comment after a key would separate it from the anchor of a
+	// sequence, so names of types of sequences go above their keys.
+	if value.Kind == yaml.ScalarNode {
+		value.LineComment = name
+	} else {
+		key.HeadComment = name
+	}
+	return key, value, nil
+}
+
+// content returns the sequence item that describes src as an element of the
+// content of another note.
+func (e encoder) content(src note.GraphNote) (*yaml.Node, error) {
+	if a, ok := e.anchors[src.GetID()]; ok {
+		return &yaml.Node{Kind: yaml.AliasNode, Value: a.Anchor, Alias: a}, nil
+	}
+	key, value, err := e.entry(src, true)
+	if err != nil {
+		return nil, err
+	}
+	if key.Value == "note" {
+		return value, nil
+	}
+	return &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{key, value}}, nil
+}
+
+// note returns a node that describes src, except for its primary type. The
+// node is a scalar only if scalar is true and nothing but the value of src
+// remains to be described.
+func (e encoder) note(src note.GraphNote, ts []note.GraphNote, scalar bool) (*yaml.Node, error) {
+	tn, err := note.TruncateNote(src)
+	if err != nil {
+		return nil, err
+	}
+	dst := &yaml.Node{Kind: yaml.SequenceNode}
+	if !tn.ID.Empty() {
+		dst.Anchor = anchor(tn.ID)
+		e.anchors[tn.ID] = dst
+	}
+	if scalar && len(tn.Contents) == 0 && len(ts) == 0 &&
+		len(tn.Roles) == 0 && len(tn.SubjectIdentifiers) == 0 {
+		dst.Kind, dst.Value, dst.Tag = yaml.ScalarNode, tn.ValueString, valueTag(tn.ValueType)
+		return dst, nil
+	}
+	if tn.ValueString != "" || !tn.ValueType.Empty() {
+		v := str(tn.ValueString)
+		v.Tag = valueTag(tn.ValueType)
+		dst.Content = append(dst.Content, item("is", v))
+	}
+	if len(ts) > 0 {
+		types := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, t := range ts {
+			id := str(t.GetID().String())
+			if id.LineComment, err = e.comment(t); err != nil {
+				return nil, err
+			}
+			types.Content = append(types.Content, id)
 		}
-		return noteToYaml(src, dst.Content[1], done|doneType0)
-	case yaml.ScalarNode:
-		if len(cs) > 0 ||
-			len(ts) > 1 || (len(ts) == 1 && (done&doneType0 == 0)) {
-			dst.Kind = yaml.MappingNode
-			return noteToYaml(src, dst, done)
+		dst.Content = append(dst.Content, item("types", types))
+	}
+	if len(tn.Roles) > 0 {
+		roles := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, r := range tn.Roles {
+			roles.Content = append(roles.Content, item(r.Type.String(), str(r.Player.String())))
 		}
-		vs, vt, err := src.GetValue()
-		if err != nil {
-			return err
+		dst.Content = append(dst.Content, item("roles", roles))
+	}
+	if len(tn.SubjectIdentifiers) > 0 {
+		sis := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, si := range tn.SubjectIdentifiers {
+			sis.Content = append(sis.Content, str(si))
 		}
-		dst.Value = vs
-		dst.Tag = vt.GetID().String()
-		dst.Anchor = src.GetID().String()
-		return nil
-	case yaml.SequenceNode:
-		cs, err := src.GetContents()
+		dst.Content = append(dst.Content, item("identifiers", sis))
+	}
+	cs, err := src.GetContents()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cs {
+		d, err := e.content(c)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		ts, err := src.GetTypes()
-		if err != nil {
-			return err
+		dst.Content = append(dst.Content, d)
+	}
+	return dst, nil
+}
+
+// reserved are the keys that cannot be the ID of the primary type of a note,
+// because they have other meanings in this YAML dialect.
+var reserved = map[string]bool{
+	"note":        true,
+	"is":          true,
+	"types":       true,
+	"roles":       true,
+	"identifiers": true,
+	"ref":         true,
+}
+
+// anchor returns id encoded as a YAML anchor, which may contain only ASCII
+// letters, digits, '_', and '-'. Each other byte of id, and each '_', is
+// written as '_' followed by two lowercase hexadecimal digits.
+func anchor(id note.ID) string {
+	var b strings.Builder
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c == '-' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "_%02x", c)
 		}
-		if len(cs) == 0 && (len(ts) == 0 || (len(ts) == 1 && (done&doneType0 == doneType0))) && (done&doneParent == doneParent) {
-			dst.Kind = yaml.ScalarNode
-			return noteToYaml(src, dst, done)
+	}
+	return b.String()
+}
+
+// anchorID returns the ID encoded in a, reversing anchor.
+func anchorID(a string) (note.ID, error) {
+	var b strings.Builder
+	for i := 0; i < len(a); i++ {
+		if a[i] != '_' {
+			b.WriteByte(a[i])
+			continue
 		}
-		dst.Anchor = src.GetID().String()
-		if vs, vt, err := src.GetValue(); err != nil {
-			return err
-		} else if vs != "" {
-			dst.Content = append(dst.Content, &yaml.Node{
-				Kind: yaml.MappingNode,
-				Content: []*yaml.Node{
-					{Kind: yaml.ScalarNode, Value: "is"},
-					{Kind: yaml.ScalarNode, Value: vs, Tag: string(vt.GetID())},
-				},
-			})
+		if i+2 >= len(a) {
+			return note.EmptyID, fmt.Errorf("anchor %#v ends with a partial escape: %w", a, note.InvalidID)
 		}
-		if ss, err := src.GetContents(); err != nil {
-			return err
-		} else {
-			for _, s := range ss {
-				d := yaml.Node{Kind: yaml.ScalarNode}
-				if err := noteToYaml(s, &d, doneParent); err != nil {
-					return err
-				}
-				dst.Content = append(dst.Content, &d)
-			}
+		c, err := strconv.ParseUint(a[i+1:i+3], 16, 8)
+		if err != nil {
+			return note.EmptyID, fmt.Errorf("anchor %#v has an invalid escape: %w", a, note.InvalidID)
 		}
-		return nil
-	default:
-		dst.Kind = yaml.MappingNode
-		return noteToYaml(src, dst, done)
+		b.WriteByte(byte(c))
+		i += 2
+	}
+	return note.ID(b.String()), nil
+}
+
+// coreTagPrefix is the prefix of the long form of the tags that YAML defines
+// for itself, which are written in short form with the prefix "!!".
+const coreTagPrefix = "tag:yaml.org,2002:"
+
+// valueTag returns the tag of a scalar with a value of type vt. An untyped
+// value is tagged explicitly as a string so that values like "42" or "" are
+// quoted rather than read back as numbers or nulls.
+//
+// An ID that would be read back as another tag, because it begins with '!'
+// or with coreTagPrefix, is escaped as in an anchor and written as a local
+// tag.
+func valueTag(vt note.ID) string {
+	if vt.Empty() {
+		return "!!str"
+	}
+	if s := vt.String(); strings.HasPrefix(s, "!") || strings.HasPrefix(s, coreTagPrefix) {
+		return "!" + anchor(vt)
+	}
+	return vt.String()
+}
+
+// valueTypeID returns the ID encoded in the short form of tag, reversing
+// valueTag.
+func valueTypeID(tag string) (note.ID, error) {
+	if strings.HasPrefix(tag, "!") && !strings.HasPrefix(tag, "!!") {
+		return anchorID(tag[1:])
 	}
+	return note.ID(tag), nil
+}
+
+// str returns a scalar node with the string value s.
+func str(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+// item returns a mapping node with a single key and value.
+func item(key string, value *yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{str(key), value}}
 }
@@ -15,101 +15,309 @@
 package yaml
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 
 	"github.com/google/note-maps/note"
 	"gopkg.in/yaml.v3"
 )
 
+// UnmarshalNote reads the note in the first YAML document in src into dst.
 func UnmarshalNote(src []byte, dst *note.Plain) error {
-	n := yaml.Node{Kind: yaml.DocumentNode}
-	if err := yaml.Unmarshal(src, &n); err != nil {
+	n, err := NewDecoder(bytes.NewReader(src)).Decode()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
 		return err
 	}
-	return yamlDocumentToNote(&n, dst)
+	*dst = *n
+	return nil
+}
+
+// UnmarshalNotes reads the note in each YAML document in src, such as the
+// output of MarshalNote for several notes separated by "---".
+func UnmarshalNotes(src []byte) ([]*note.Plain, error) {
+	var (
+		d  = NewDecoder(bytes.NewReader(src))
+		ns []*note.Plain
+	)
+	for {
+		n, err := d.Decode()
+		if err == io.EOF {
+			return ns, nil
+		} else if err != nil {
+			return nil, err
+		}
+		ns = append(ns, n)
+	}
+}
+
+// Decoder reads notes from a stream of YAML documents.
+//
+// Each note is identified by the ID encoded in its anchor, or by a new random
+// ID if it has none. As in any YAML, an alias refers to an anchor earlier in the same
+// document; a ref item refers to a note by ID wherever it is described,
+// including in other documents. Where the same anchor appears more than once,
+// the last appearance defines the note, so that each note in the output of
+// several calls to MarshalNote is read only once.
+type Decoder struct {
+	y       *yaml.Decoder
+	notes   map[note.ID]*note.Plain
+	defined map[note.ID]bool
+	order   []*note.Plain
+
+	// anchors are the anchors defined so far in the current document.
+	anchors map[string]bool
+}
+
+// NewDecoder returns a Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		y:       yaml.NewDecoder(r),
+		notes:   make(map[note.ID]*note.Plain),
+		defined: make(map[note.ID]bool),
+	}
+}
+
+// Decode returns the note described by the next non-empty document in the
+// stream, or io.EOF if there are no more.
+func (d *Decoder) Decode() (*note.Plain, error) {
+	for {
+		var n yaml.Node
+		if err := d.y.Decode(&n); err != nil {
+			return nil, err
+		}
+		if empty(&n) {
+			continue
+		}
+		return d.DecodeNode(&n)
+	}
+}
+
+// DecodeNode returns the note described by a YAML document that has already
+// been parsed, such as one of many documents in a larger file.
+//
+// Notes described by src are added to the notes decoded so far, and ref
+// items in src may refer to them.
+func (d *Decoder) DecodeNode(src *yaml.Node) (*note.Plain, error) {
+	d.anchors = make(map[string]bool)
+	return d.document(src)
+}
+
+// empty returns true if n is a document with no content, like the one that
+// follows a "---" at the end of a stream.
+func empty(n *yaml.Node) bool {
+	if n.Kind == yaml.DocumentNode && len(n.Content) == 1 {
+		n = n.Content[0]
+	}
+	return n.Kind == 0 ||
+		n.Kind == yaml.DocumentNode && len(n.Content) == 0 ||
+		n.Kind == yaml.ScalarNode && n.Tag == "!!null" && n.Value == ""
+}
+
+// Defined returns every note defined in the documents decoded so far,
+// including the notes nested within them, in the order each was first
+// defined.
+//
+// Notes that are only referred to, as types or by aliases to anchors that
+// have not been defined, are not included.
+func (d *Decoder) Defined() []*note.Plain {
+	return append([]*note.Plain(nil), d.order...)
+}
+
+// ref returns the note with id, creating it if necessary.
+func (d *Decoder) ref(id note.ID) *note.Plain {
+	p, ok := d.notes[id]
+	if !ok {
+		p = &note.Plain{ID: id}
+		d.notes[id] = p
+	}
+	return p
 }
 
-func yamlDocumentToNote(src *yaml.Node, dst *note.Plain) error {
+// define returns the note with id, cleared so that it can be filled in from
+// a new definition.
+func (d *Decoder) define(id note.ID) *note.Plain {
+	p := d.ref(id)
+	*p = note.Plain{ID: id}
+	if !d.defined[id] {
+		d.defined[id] = true
+		d.order = append(d.order, p)
+	}
+	return p
+}
+
+func (d *Decoder) document(src *yaml.Node) (*note.Plain, error) {
 	// Unwrap the outer YAML document structure.
 	if src.Kind == yaml.DocumentNode {
-		if len(src.Content) == 0 {
-			return nil
-		}
 		if len(src.Content) > 1 {
-			return fmt.Errorf("expected document to contain just one yaml node")
+			return nil, fmt.Errorf("expected document to contain just one yaml node")
 		}
 		src = src.Content[0]
-		if src.Kind != yaml.MappingNode {
-			return fmt.Errorf("expected document to contain a map (%v), found %v in %#v", yaml.MappingNode, src.Kind, src)
-		}
-		if len(src.Content) != 2 {
-			return fmt.Errorf("expected document to contain a map with exactly one key")
-		}
-		src = src.Content[1]
 	}
-	return yamlNodeToNote(src, dst)
+	if src.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("expected document to contain a map (%v), found %v in %#v", yaml.MappingNode, src.Kind, src)
+	}
+	if len(src.Content) != 2 {
+		return nil, fmt.Errorf("expected document to contain a map with exactly one key")
+	}
+	return d.typed(src.Content[0], src.Content[1])
 }
 
-func yamlNodeToNote(src *yaml.Node, dst *note.Plain) error {
-	if src.Anchor != "" {
-		dst.ID = note.ID(src.Anchor)
+// typed returns the note described by src, with the type named by key unless
+// key is "note".
+func (d *Decoder) typed(key, src *yaml.Node) (*note.Plain, error) {
+	if key.Value == "note" {
+		return d.node(src)
+	}
+	if src.Kind == yaml.AliasNode {
+		return nil, fmt.Errorf("line %v: an alias cannot be given a type", src.Line)
 	}
-	// A note without an ID might not be usable, but some ops may work. Let's go
-	// ahead either way.
+	n, err := d.node(src)
+	if err != nil {
+		return nil, err
+	}
+	n.Types = append([]*note.Plain{d.ref(note.ID(key.Value))}, n.Types...)
+	return n, nil
+}
+
+func (d *Decoder) node(src *yaml.Node) (*note.Plain, error) {
+	switch src.Kind {
+	case yaml.AliasNode:
+		// Some parsers, including yaml.v3, keep anchors from one document to
+		// the next, but YAML does not allow an alias to refer to them.
+		if !d.anchors[src.Value] {
+			return nil, fmt.Errorf("line %v: alias *%v refers to no anchor in the same document: %w",
+				src.Line, src.Value, note.InvalidID)
+		}
+		id, err := anchorID(src.Value)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", src.Line, err)
+		}
+		return d.ref(id), nil
+	case yaml.MappingNode:
+		return d.mapping(src)
+	}
+	id, err := anchorID(src.Anchor)
+	if err != nil {
+		return nil, fmt.Errorf("line %v: %w", src.Line, err)
+	}
+	if id.Empty() {
+		id = note.RandomID()
+	} else {
+		d.anchors[src.Anchor] = true
+	}
+	dst := d.define(id)
 	switch src.Kind {
 	case yaml.SequenceNode:
 		for _, s := range src.Content {
-			if s.Kind == yaml.MappingNode && len(s.Content) == 2 &&
-				s.Content[0].Kind == yaml.ScalarNode && s.Content[0].Value == "is" {
-				// A note expressed as a sequence may set its own value with a sequence
-				// item that is a map, mapping "is" to the value of the note.
-				switch s.Content[1].Kind {
-				case yaml.ScalarNode:
-					var vt note.ID
-					if s.Content[1].LongTag() != "tag:yaml.org,2002:str" {
-						vt = note.ID(s.Content[1].ShortTag())
-						dst.ValueType = &note.Plain{ID: vt}
-					}
-					dst.ValueString = s.Content[1].Value
-				default:
-					return fmt.Errorf("unsupported YAML type %v (%#v) for subject value",
-						s.Kind, s.Value)
+			if key, value, ok := reservedItem(s); ok && key == "ref" {
+				if value.Kind != yaml.ScalarNode || value.Value == "" {
+					return nil, fmt.Errorf("line %v: expected the id of a note: %w", value.Line, note.InvalidID)
 				}
-			} else {
-				// TODO: use a different id when notes are cached and shared? random id
-				// to start?
-				var c note.Plain
-				if err := yamlNodeToNote(s, &c); err != nil {
-					return err
+				dst.Contents = append(dst.Contents, d.ref(note.ID(value.Value)))
+				continue
+			} else if ok {
+				if err := d.item(dst, key, value); err != nil {
+					return nil, err
 				}
-				dst.Contents = append(dst.Contents, &c)
+				continue
 			}
+			c, err := d.node(s)
+			if err != nil {
+				return nil, err
+			}
+			dst.Contents = append(dst.Contents, c)
 		}
 	case yaml.ScalarNode:
-		if src.LongTag() != "tag:yaml.org,2002:str" {
-			dst.ValueType = &note.Plain{ID: note.ID(src.ShortTag())}
+		if err := d.value(dst, src); err != nil {
+			return nil, err
 		}
-		dst.ValueString = src.Value
-	case yaml.MappingNode:
-		if len(src.Content) >= 1 {
-			typ := src.Content[0]
-			dst.Types = append(dst.Types, &note.Plain{ID: note.ID(typ.Value)})
+	default:
+		return nil, fmt.Errorf("unsupported content in note, kind=%#v", src.Kind)
+	}
+	return dst, nil
+}
+
+// reservedItem returns the key and value of s if it is a sequence item that
+// describes part of a note other than its content.
+func reservedItem(s *yaml.Node) (key string, value *yaml.Node, ok bool) {
+	if s.Kind != yaml.MappingNode || len(s.Content) != 2 ||
+		s.Content[0].Kind != yaml.ScalarNode || !reserved[s.Content[0].Value] ||
+		s.Content[0].Value == "note" {
+		return "", nil, false
+	}
+	return s.Content[0].Value, s.Content[1], true
+}
+
+// item sets the part of dst named by key from src.
+func (d *Decoder) item(dst *note.Plain, key string, src *yaml.Node) error {
+	if key == "is" {
+		if src.Kind != yaml.ScalarNode {
+			return fmt.Errorf("line %v: unsupported YAML type %v for the value of a note", src.Line, src.Kind)
 		}
-		if len(src.Content) >= 2 {
-			yamlNodeToNote(src.Content[1], dst)
+		return d.value(dst, src)
+	}
+	if src.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %v: expected a sequence of %v", src.Line, key)
+	}
+	for _, s := range src.Content {
+		switch {
+		case key == "roles" && s.Kind == yaml.MappingNode && len(s.Content) == 2 &&
+			s.Content[0].Kind == yaml.ScalarNode && s.Content[1].Kind == yaml.ScalarNode:
+			dst.Roles = append(dst.Roles, note.PlainRole{
+				Type:   d.ref(note.ID(s.Content[0].Value)),
+				Player: d.ref(note.ID(s.Content[1].Value)),
+			})
+		case key == "types" && s.Kind == yaml.ScalarNode:
+			dst.Types = append(dst.Types, d.ref(note.ID(s.Value)))
+		case key == "identifiers" && s.Kind == yaml.ScalarNode:
+			dst.SubjectIdentifiers = append(dst.SubjectIdentifiers, s.Value)
+		default:
+			return fmt.Errorf("line %v: unsupported item in %v", s.Line, key)
 		}
-		for i := 2; i < len(src.Content); i += 2 {
-			key, val := src.Content[i], src.Content[i+1]
-			c := &note.Plain{
-				Types: []*note.Plain{&note.Plain{ID: note.ID(key.Value)}},
-			}
-			if err := yamlNodeToNote(val, c); err != nil {
-				return err
-			}
-			dst.Contents = append(dst.Contents, c)
+	}
+	return nil
+}
+
+// value sets the value of dst from the scalar src, using the tag of src as
+// the ID of the type of the value unless it is a string.
+func (d *Decoder) value(dst *note.Plain, src *yaml.Node) error {
+	if src.LongTag() != coreTagPrefix+"str" {
+		vt, err := valueTypeID(src.ShortTag())
+		if err != nil {
+			return fmt.Errorf("line %v: %w", src.Line, err)
 		}
-	default:
-		return fmt.Errorf("unsupported content in note, kind=%#v", src.Kind)
+		dst.ValueType = d.ref(vt)
 	}
+	dst.ValueString = src.Value
 	return nil
 }
+
+// mapping returns the note described by the value of the first key of src,
+// with that key as its type. Each further key and value describes a note in
+// its content, with the key as the type of that note.
+func (d *Decoder) mapping(src *yaml.Node) (*note.Plain, error) {
+	if len(src.Content) < 2 {
+		return nil, fmt.Errorf("line %v: expected a map with at least one key", src.Line)
+	}
+	val := src.Content[1]
+	if src.Anchor != "" && val.Anchor == "" {
+		v := *val
+		v.Anchor = src.Anchor
+		val = &v
+	}
+	dst, err := d.typed(src.Content[0], val)
+	if err != nil {
+		return nil, err
+	}
+	for i := 2; i+1 < len(src.Content); i += 2 {
+		c, err := d.typed(src.Content[i], src.Content[i+1])
+		if err != nil {
+			return nil, err
+		}
+		dst.Contents = append(dst.Contents, c)
+	}
+	return dst, nil
+}
@@ -13,4 +13,66 @@
 // limitations under the License.
 
 // Package yaml marshals notes to and from YAML format.
+//
+// Each YAML document describes one note as a map with a single key, which is
+// the ID of the first type of the note, or "note" if it has no types:
+//
+//	type12: &10
+//	    - is: !<type11> value10
+//	    - types:
+//	        - type13
+//	    - roles:
+//	        - role14: "15"
+//	        - "": "15"
+//	    - identifiers:
+//	        - https://example.com
+//	    - name: &16 a name
+//	    - &17 "42"
+//	    - &18
+//	      - types:
+//	            - is
+//	      - *10
+//	    - *18
+//
+// The value is a sequence anchored with the ID of the note. Each item in the
+// sequence is either one of the following maps with a single reserved key, or
+// a note in its content:
+//
+//	is          - the value of the note, tagged with the ID of its type
+//	types       - the IDs of the remaining types of the note
+//	roles       - maps from the ID of the type of each role to its player
+//	identifiers - the subject identifiers of the note
+//	ref         - the ID of a note in its content that is described elsewhere
+//
+// A note in content is written as a map from the ID of its first type to the
+// rest of the note, or just as the rest of the note if it has no types. Where
+// nothing remains but the value, the rest of the note is a scalar anchored
+// with its ID rather than a sequence. A note that appears again within the
+// same document, including one that contains itself, is written as an alias.
+//
+// As YAML requires, an alias can only refer to an anchor earlier in the same
+// document. A note in content that is described in another document, or only
+// in the note map that the notes are read into, is written as a ref item:
+//
+//	note: &19
+//	    - ref: "10"
+//
+// Where the first type of a note is one of the reserved words note, is,
+// types, roles, identifiers, or ref, every type of the note is listed in its
+// types item instead.
+//
+// A value without a type is tagged as a string, so it is quoted wherever it
+// would otherwise be read as another kind of scalar.
+//
+// YAML anchors may contain only ASCII letters, digits, '_', and '-', so each
+// other byte of an ID, and each '_', is escaped in an anchor as '_' followed
+// by two hexadecimal digits. The note with ID org.note-maps.name, for
+// example, has the anchor &org_2enote-maps_2ename. IDs are written as they
+// are everywhere else, as in types, roles, and ref items. When unmarshaling,
+// a note without an anchor is given a new random ID.
+//
+// The ID of the type of a value is written as its tag, except that an ID that
+// begins with '!' or with tag:yaml.org,2002:, and so would be read back as a
+// different tag, is escaped as in an anchor and written as a local tag. The
+// type with ID !!str, for example, is written as the tag !_21_21str.
 package yaml
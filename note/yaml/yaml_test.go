@@ -15,12 +15,18 @@
 package yaml
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/google/note-maps/note"
 	"github.com/google/note-maps/note/notetest"
+	"gopkg.in/yaml.v3"
 )
 
 type N struct {
@@ -39,8 +45,10 @@ func (n N) GetValue() (string, note.GraphNote, error) {
 	}
 	return n.valuestring, vt, nil
 }
-func (n N) GetContents() ([]note.GraphNote, error) { return n.contents, nil }
-func (n N) GetTypes() ([]note.GraphNote, error)    { return n.types, nil }
+func (n N) GetContents() ([]note.GraphNote, error)   { return n.contents, nil }
+func (n N) GetTypes() ([]note.GraphNote, error)      { return n.types, nil }
+func (n N) GetRoles() ([]note.Role, error)           { return nil, nil }
+func (n N) GetSubjectIdentifiers() ([]string, error) { return nil, nil }
 
 func yamlString(lines ...string) string { return strings.Join(lines, "\n") + "\n" }
 
@@ -235,3 +243,363 @@ func TestUnmarshalMarshal_canonical(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshaler_names(t *testing.T) {
+	gn := N{
+		id: "10",
+		contents: []note.GraphNote{
+			&N{
+				id:          "11",
+				valuestring: "Topic",
+				types:       []note.GraphNote{&N{id: note.Name}},
+			},
+			&N{
+				id:          "12",
+				valuestring: "value12",
+				types:       []note.GraphNote{&N{id: note.TextPlain}},
+				contents:    []note.GraphNote{&N{id: "13", valuestring: "value13"}},
+			},
+		},
+	}
+	expect := yamlString(
+		"# Topic",
+		"",
+		"note: &10",
+		"    - org.note-maps.name: &11 Topic # name",
+		"    - # text",
+		"      org.note-maps.text.plain: &12",
+		"        - is: value12",
+		"        - &13 value13",
+	)
+	bs, err := Marshaler{Locales: []string{"en-US"}}.MarshalNote(gn)
+	if err != nil {
+		t.Fatal(err)
+	} else if string(bs) != expect {
+		t.Fatalf("expected yaml:\n%vactual yaml:\n%v", expect, string(bs))
+	}
+	var actual note.Plain
+	if err = UnmarshalNote(bs, &actual); err != nil {
+		t.Error(err)
+	} else {
+		notetest.ExpectEqual(t, actual.GraphNote(), gn)
+	}
+}
+
+func TestUnmarshalNotes(t *testing.T) {
+	src := yamlString(
+		"---",
+		"note: &10",
+		"    - is: value10",
+		"    - &11 value11",
+		"    - untitled",
+		"---",
+		"# A comment",
+		"",
+		"name: &11",
+		"    - is: value11",
+		"    - ref: 10",
+		"---",
+		"note:",
+		"    - is: no anchor",
+		"    - ref: 11",
+		"---",
+	)
+	ns, err := UnmarshalNotes([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ns) != 3 {
+		t.Fatalf("got %v notes, expected 3", len(ns))
+	}
+	n10, n11, n2 := ns[0], ns[1], ns[2]
+	if n10.ID != "10" || n11.ID != "11" || n2.ID.Empty() {
+		t.Fatalf("got ids %#v, %#v, %#v", n10.ID, n11.ID, n2.ID)
+	}
+	if len(n10.Contents) != 2 || n10.Contents[0] != n11 {
+		t.Fatalf("expected 10 to contain 11, got %#v", n10.Contents)
+	}
+	if untitled := n10.Contents[1]; untitled.ID.Empty() || untitled.ValueString != "untitled" {
+		t.Errorf("got %#v, expected a note with a random id", untitled)
+	}
+	if len(n11.Types) != 1 || n11.Types[0].ID != "name" {
+		t.Errorf("expected 11 to be redefined with type name, got %#v", n11.Types)
+	}
+	if len(n11.Contents) != 1 || n11.Contents[0] != n10 {
+		t.Errorf("expected a ref to 10 in the content of 11, got %#v", n11.Contents)
+	}
+	if len(n2.Contents) != 1 || n2.Contents[0] != n11 {
+		t.Errorf("expected a ref to 11 in an other document, got %#v", n2.Contents)
+	}
+
+	// A parser that scopes anchors to each document reads the same notes.
+	var (
+		d     = NewDecoder(strings.NewReader(""))
+		split []*note.Plain
+	)
+	for _, doc := range strings.Split(src, "---\n")[1:4] {
+		var y yaml.Node
+		if err := yaml.Unmarshal([]byte(doc), &y); err != nil {
+			t.Fatal(err)
+		}
+		n, err := d.DecodeNode(&y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		split = append(split, n)
+	}
+	if split[0].ID != "10" || split[1].ID != "11" ||
+		split[0].Contents[0] != split[1] || split[1].Contents[0] != split[0] ||
+		split[2].Contents[0] != split[1] {
+		t.Errorf("got different notes from documents parsed one at a time: %#v", split)
+	}
+
+	var defined []note.ID
+	d = NewDecoder(strings.NewReader(src))
+	for {
+		if _, err := d.Decode(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, n := range d.Defined() {
+		defined = append(defined, n.ID)
+	}
+	if len(defined) != 4 || defined[0] != "10" || defined[1] != "11" {
+		t.Errorf("got defined notes %v", defined)
+	}
+}
+
+func TestUnmarshalNotes_marshaled(t *testing.T) {
+	var src []byte
+	for _, test := range TestCases {
+		bs, err := MarshalNote(test.GN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		src = append(append(src, "---\n"...), bs...)
+	}
+	ns, err := UnmarshalNotes(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every test case uses note 10, so it is defined by the last one.
+	last := TestCases[len(TestCases)-1].GN
+	if len(ns) != len(TestCases) {
+		t.Fatalf("got %v notes, expected %v", len(ns), len(TestCases))
+	}
+	notetest.ExpectEqual(t, ns[0].GraphNote(), last)
+}
+
+func TestUnmarshalNote_errors(t *testing.T) {
+	for _, src := range []string{
+		"- a list",
+		"note: &10\n    - *missing\n",
+		"note: &10\n    - ref: [10]\n",
+		"name: *10\n",
+		"note: &10\n    - is: [a, b]\n",
+	} {
+		var n note.Plain
+		if err := UnmarshalNote([]byte(src), &n); err == nil {
+			t.Errorf("%#v: expected an error", src)
+		}
+	}
+	// An alias cannot refer to an anchor in another document, even though
+	// yaml.v3 keeps anchors from one document to the next.
+	src := "note: &10\n    - is: value10\n---\nnote: &11\n    - *10\n"
+	if _, err := UnmarshalNotes([]byte(src)); !errors.Is(err, note.InvalidID) {
+		t.Errorf("got %v, expected %v", err, note.InvalidID)
+	}
+}
+
+func TestMarshal_allFields(t *testing.T) {
+	src := yamlString(
+		"type12: &10",
+		"    - is: !<type11> value10",
+		"    - types:",
+		"        - type13",
+		"    - roles:",
+		"        - role14: \"15\"",
+		"        - \"\": \"15\"",
+		"    - identifiers:",
+		"        - https://example.com",
+		"    - name: &16 a name",
+		"    - &17 \"42\"",
+		"    - &18",
+		"      - types:",
+		"            - is",
+		"      - *10",
+		"    - *18",
+	)
+	var n note.Plain
+	if err := UnmarshalNote([]byte(src), &n); err != nil {
+		t.Fatal(err)
+	}
+	expect := note.TruncatedNote{
+		ID:                 "10",
+		ValueString:        "value10",
+		ValueType:          "type11",
+		Types:              []note.ID{"type12", "type13"},
+		Roles:              []note.Role{{Type: "role14", Player: "15"}, {Player: "15"}},
+		SubjectIdentifiers: []string{"https://example.com"},
+		Contents:           []note.ID{"16", "17", "18", "18"},
+	}
+	if actual, err := note.TruncateNote(n.GraphNote()); err != nil {
+		t.Fatal(err)
+	} else if !actual.Equals(expect) {
+		t.Errorf("got %#v, expected %#v", actual, expect)
+	}
+	if c := n.Contents[1]; c.ValueString != "42" || c.ValueType != nil {
+		t.Errorf("got %#v, expected an untyped string", c)
+	}
+	if c := n.Contents[2]; len(c.Types) != 1 || c.Types[0].ID != "is" ||
+		len(c.Contents) != 1 || c.Contents[0].ID != "10" {
+		t.Errorf("got %#v, expected a note of type is that contains 10", c)
+	}
+	bs, err := MarshalNote(n.GraphNote())
+	if err != nil {
+		t.Fatal(err)
+	} else if string(bs) != src {
+		t.Errorf("expected yaml:\n%vactual yaml:\n%v", src, string(bs))
+	}
+}
+
+func TestMarshal_escapedAnchor(t *testing.T) {
+	for id, expect := range map[note.ID]string{
+		"10":                 "10",
+		"org.note-maps.name": "org_2enote-maps_2ename",
+		"snake_case":         "snake_5fcase",
+		"ü":                  "_c3_bc",
+	} {
+		if a := anchor(id); a != expect {
+			t.Errorf("%#v: got anchor %#v, expected %#v", id, a, expect)
+		}
+		if actual, err := anchorID(expect); err != nil || actual != id {
+			t.Errorf("%#v: got %#v, %v, expected %#v", expect, actual, err, id)
+		}
+	}
+	for _, a := range []string{"a_", "a_2", "a_zz", "a_+f"} {
+		if _, err := anchorID(a); !errors.Is(err, note.InvalidID) {
+			t.Errorf("%#v: got %v, expected %v", a, err, note.InvalidID)
+		}
+	}
+	n := &note.Plain{ID: "org.example", ValueString: "value"}
+	n.Contents = []*note.Plain{n}
+	bs, err := MarshalNote(n.GraphNote())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actual note.Plain
+	if err := UnmarshalNote(bs, &actual); err != nil {
+		t.Fatal(err)
+	}
+	notetest.ExpectEqual(t, actual.GraphNote(), n.GraphNote())
+}
+
+// randomNotes returns a graph of n notes with random values, types, roles,
+// subject identifiers, and content, including shared content and cycles. Some
+// of the notes have IDs that are reserved words, some have IDs that must be
+// escaped in anchors, and some have IDs that must be escaped in tags.
+func randomNotes(r *rand.Rand, n int) []*note.Plain {
+	var (
+		ps    = make([]*note.Plain, n)
+		tags  = []string{"!!str", "tag:yaml.org,2002:str", "!local"}
+		words = []string{"note", "is", "types", "roles", "identifiers", "ref"}
+	)
+	for i := range ps {
+		ps[i] = &note.Plain{ID: note.ID(strconv.FormatUint(r.Uint64(), 10))}
+		switch {
+		case i < len(tags) && r.Intn(3) == 0:
+			ps[i].ID = note.ID(tags[i])
+		case i < len(words) && r.Intn(2) == 0:
+			ps[i].ID = note.ID(words[i])
+		case r.Intn(4) == 0:
+			ps[i].ID = note.ID(words[r.Intn(len(words))] + "-" + ps[i].ID.String())
+		case r.Intn(3) == 0:
+			ps[i].ID = note.ID([]string{"org.note-maps.", "snake_", "ü ", "a/b:", "!!", "tag:yaml.org,2002:"}[r.Intn(6)] + ps[i].ID.String())
+		}
+	}
+	var (
+		pick   = func() *note.Plain { return ps[r.Intn(n)] }
+		values = []string{
+			"", "value", "42", "3.14", "true", "null", "~", "- item",
+			"key: value", "# not a comment", " padded ", "multiple\nlines",
+			"\ttab", "ünïcödé", "*alias", "&anchor", "!tag", "'quoted'",
+			`"quoted"`, "[a, b]", "{a: b}", "---",
+		}
+	)
+	for _, p := range ps {
+		p.ValueString = values[r.Intn(len(values))]
+		if r.Intn(3) == 0 {
+			p.ValueType = pick()
+		}
+		for i := r.Intn(4); i > 0; i-- {
+			p.Types = append(p.Types, pick())
+		}
+		for i := r.Intn(3); i > 0; i-- {
+			role := note.PlainRole{Type: &note.Plain{}, Player: pick()}
+			if r.Intn(3) > 0 {
+				role.Type = pick()
+			}
+			p.Roles = append(p.Roles, role)
+		}
+		for i := r.Intn(3); i > 0; i-- {
+			p.SubjectIdentifiers = append(p.SubjectIdentifiers,
+				"https://example.com/"+strconv.Itoa(r.Intn(100)))
+		}
+		for i := r.Intn(4); i > 0; i-- {
+			p.Contents = append(p.Contents, pick())
+		}
+	}
+	return ps
+}
+
+func TestUnmarshalMarshal_random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		ps := randomNotes(r, 1+r.Intn(8))
+		byID := make(map[note.ID]*note.Plain)
+		for _, p := range ps {
+			byID[p.ID] = p
+		}
+		for _, p := range ps {
+			bs, err := MarshalNote(p.GraphNote())
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := NewDecoder(bytes.NewReader(bs))
+			actual, err := d.Decode()
+			if err != nil {
+				t.Fatalf("%v\n%s", err, bs)
+			}
+			if !notetest.ExpectEqual(t, actual.GraphNote(), p.GraphNote()) {
+				t.Fatalf("from yaml:\n%s", bs)
+			}
+			// Every note in the content of p, directly or indirectly, is
+			// described in full in the same document.
+			reachable := map[note.ID]bool{}
+			var walk func(*note.Plain)
+			walk = func(p *note.Plain) {
+				if !reachable[p.ID] {
+					reachable[p.ID] = true
+					for _, c := range p.Contents {
+						walk(c)
+					}
+				}
+			}
+			walk(p)
+			defined := d.Defined()
+			if len(defined) != len(reachable) {
+				t.Errorf("got %v notes, expected %v, from yaml:\n%s", len(defined), len(reachable), bs)
+			}
+			for _, n := range defined {
+				if !reachable[n.ID] {
+					t.Fatalf("unexpected note %#v from yaml:\n%s", n.ID, bs)
+				}
+				if !notetest.ExpectEqual(t, n.GraphNote(), byID[n.ID].GraphNote()) {
+					t.Fatalf("from yaml:\n%s", bs)
+				}
+			}
+		}
+	}
+}
@@ -19,21 +19,21 @@ var tmpls = func() http.FileSystem {
 	fs := vfsgen۰FS{
 		"/": &vfsgen۰DirInfo{
 			name:    "/",
-			modTime: time.Date(2020, 8, 1, 22, 9, 22, 317133520, time.UTC),
+			modTime: time.Date(2025, 4, 26, 15, 47, 39, 0, time.UTC),
 		},
 		"/ot.go.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "ot.go.tmpl",
-			modTime:          time.Date(2020, 8, 1, 22, 9, 22, 266454516, time.UTC),
-			uncompressedSize: 9487,
+			modTime:          time.Date(2026, 10, 17, 0, 41, 41, 226081130, time.UTC),
+			uncompressedSize: 13087,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x59\x6d\x6f\xe3\x36\xf2\x7f\x2d\x7d\x8a\x69\x5e\xf4\x2f\x35\x8a\x9c\xdd\x97\xd9\x7a\x81\xfc\xb3\x7b\xbd\xe0\xd2\xa4\xd8\x6c\xaf\x28\x0c\xe3\x40\x49\x94\x4d\x44\x26\x55\x92\x4e\x64\xb8\xfe\xee\x87\xe1\x83\x24\x4b\x8e\x93\xf4\x16\x38\x9c\x81\xcd\x4a\xe2\x70\x9e\x38\xf3\x9b\x21\xb9\xdd\x9e\xc1\xe4\x87\x10\x00\xe0\x4a\xd4\x1b\xc9\x16\x4b\x0d\xef\xcf\xdf\x9f\xc3\x4f\x42\x2c\x2a\x0a\x37\x37\x57\xa1\x19\xbe\x61\x39\xe5\x8a\x16\xb0\xe6\x05\x95\xa0\x97\x14\x2e\x6b\x92\x2f\xa9\x1f\x49\xe0\x9f\x54\x2a\x26\x38\xbc\x4f\xcf\x21\x42\x82\x13\x37\x74\x12\x7f\x30\x3c\x36\x62\x0d\x2b\xb2\x01\x2e\x34\xac\x15\x05\xbd\x64\x0a\x4a\x56\x51\xa0\x4d\x4e\x6b\x0d\x8c\x43\x2e\x56\x75\xc5\x08\xcf\x29\x3c\x31\xbd\x34\x82\x1c\x9b\xd4\x30\xf9\xdd\x31\x11\x99\x26\x8c\x03\x81\x5c\xd4\x1b\x10\x65\x9f\x12\x88\xb6\x5a\xe3\x6f\xa9\x75\xad\x2e\x26\x93\xa7\xa7\xa7\x94\x18\x95\x53\x21\x17\x93\xca\x92\xaa\xc9\xcd\xf5\xd5\xe7\xdb\xfb\xcf\x67\xef\xd3\x73\x3b\xe9\x57\x5e\x51\xa5\x40\xd2\x3f\xd6\x4c\xd2\x02\xb2\x0d\x90\xba\xae\x58\x4e\xb2\x8a\x42\x45\x9e\x40\x48\x20\x0b\x49\x69\x01\x5a\xa0\xd2\x4f\x92\x69\xc6\x17\x09\x28\x51\xea\x27\x22\xa9\xe1\x53\x30\xa5\x25\xcb\xd6\x7a\xcf\x6b\x5e\x45\xa6\xf6\x08\x04\x07\xc2\xe1\xe4\xf2\x1e\xae\xef\x4f\xe0\xff\x2f\xef\xaf\xef\x13\xc3\xe5\xb7\xeb\xaf\x7f\xbf\xfb\xf5\x2b\xfc\x76\xf9\xe5\xcb\xe5\xed\xd7\xeb\xcf\xf7\x70\xf7\x05\xae\xee\x6e\x3f\x5d\x7f\xbd\xbe\xbb\xbd\x87\xbb\xbf\xc1\xe5\xed\xef\xf0\x8f\xeb\xdb\x4f\x09\x50\xa6\x97\x54\x02\x6d\x6a\x89\x36\x08\x09\x0c\xfd\x49\x0b\xeb\xbc\x7b\x4a\xf7\x94\x28\x85\x55\x4a\xd5\x34\x67\x25\xcb\xa1\x22\x7c\xb1\x26\x0b\x0a\x0b\xf1\x48\x25\x67\x7c\x01\x35\x95\x2b\xa6\x70\x65\x15\x10\x5e\x18\x3e\x15\x5b\x31\x4d\xb4\xf9\x36\x32\x2d\x0d\x7f\x98\xc0\xd9\x6e\x17\x86\x93\x09\x6c\xb7\xe9\x4f\x94\x53\x49\x34\x2d\xae\x44\x41\x7f\x23\x86\x2b\x8e\xd6\x24\x7f\x40\x51\xdb\x6d\xfa\x8b\x7d\xbc\x25\x2b\x8a\x23\xdb\x2d\x2b\x21\xbd\xab\xef\xb5\x64\x7c\x41\xa5\xe1\xc6\x56\xb5\x90\x1a\x4e\x94\x96\xb9\xe0\x8f\x27\x21\x06\x2f\xe5\x05\x4e\x28\xd7\x3c\x87\xa8\x51\xc8\xeb\x1e\x57\xf6\xeb\xa6\xa6\xbb\x5d\x0c\x97\x75\x4d\x79\x11\x91\xa2\x80\x34\x4d\xb7\xdb\xf4\x73\x45\x57\x94\x6b\x3f\xbe\xdd\xa6\x9f\x68\xa5\x89\x7d\x87\x6d\x18\x48\xaa\xd7\x92\x43\xa3\xd2\x6b\xae\xa8\xd4\x51\x45\x79\xd4\xa8\x38\x01\x52\x14\x69\x9a\xc6\xe1\x31\x79\x5f\x28\x46\x65\x24\x81\x71\x7d\x84\xfb\xfe\xc0\x76\x97\xfa\x79\xc7\xb9\x3b\x8d\x18\x72\x37\xfa\xbc\xdd\x28\x27\x88\xc5\xde\xbc\xd7\x58\xf5\x89\x56\x54\xd3\x88\x25\xc0\xd7\xab\x17\x4c\xdb\x93\xe1\x26\xf2\xf5\xea\x35\x02\x9c\x19\x2a\x2a\x68\xf5\x5a\xcb\x98\x82\x8b\x29\xac\xc8\x03\x8d\x56\xa4\x9e\x31\xae\xe7\x99\x10\x55\x1c\x06\x18\xdb\xff\x4a\x40\xe2\xb8\x24\x7c\x41\x01\xd9\x6e\xc3\xc0\x8c\xb0\x04\x9a\x6e\x04\x55\x0a\x83\x20\x60\x25\x34\x30\x9d\x82\xb4\xaf\x01\x53\x33\x36\x87\x29\x68\xb9\xa6\xf8\x61\x17\x9a\x7f\xbb\x30\x78\x24\x12\xf9\x69\x32\xd0\x29\x0c\x4a\x29\x56\xc8\xf9\x3c\x0c\x0a\x34\x0b\x73\xe8\x62\x0a\x25\xa9\x14\xb5\x5a\xb1\x91\x60\x56\x42\x4b\xeb\x15\xf9\xce\x0a\xb7\x8a\x58\x51\x53\x2b\xd2\xbb\x95\xc1\x19\xa0\xb4\xd8\x93\x58\x06\xad\xac\x20\xb0\xca\x4c\x81\xb5\xca\x03\xad\x14\x6d\x65\x3c\x2f\xc2\xaf\xe1\x73\x22\xbc\x47\xc6\x12\x8c\x7b\x86\x06\x1d\xd4\xdf\x65\x56\x27\x62\xd7\x46\x91\xa1\x0b\x2d\x84\xfc\x22\x69\xc9\x9a\x9f\x89\xce\x97\x60\x87\x95\x81\x1b\xbe\x5e\x65\x54\x22\xf4\x53\x17\x38\x40\xb4\x19\xc9\xe8\x82\x71\x03\x5e\xa2\x44\x17\xeb\x25\xd1\xb0\x32\x0c\xf4\x92\x22\xcf\xa3\x33\x36\x2a\x7d\x3e\x54\x7b\xda\x44\x1b\x05\xb3\xf9\x38\x4a\x19\xd7\x26\x34\x5d\x14\xe0\x92\x7f\x00\x06\x3f\x82\x37\xf8\xfb\xef\xdb\xd7\x8d\x8a\x3f\x00\x3b\x3d\xf5\x61\xd0\x98\x15\xf9\x6e\x0a\x9b\x6e\x69\x32\x49\xc9\x43\xeb\x5a\xe7\x21\x86\xde\xd1\x9b\x9a\x0e\x93\xc2\x68\x74\x57\xbb\x70\xf4\x86\x0c\xa8\x5e\x46\x2a\x56\x82\xc4\x54\x38\x37\x3a\xf8\xe4\xee\x6b\x40\x2c\xb6\x36\x09\xf4\x04\xb6\x40\x86\x09\xff\x9c\xec\x0e\x7a\xde\x08\x60\x07\x45\x76\xdc\x8e\x0a\x75\x41\x57\xbc\x80\x5f\x07\x45\xf8\xb9\x47\x05\x7c\xa1\x19\x51\x34\xc2\x3f\xa3\xb1\x68\xff\x43\x02\x54\x4a\x21\x63\x94\x8b\x48\x22\xa9\x1a\xe1\x48\xc3\x12\xc8\x6c\x0c\x25\x18\x46\x86\x2e\x81\x46\xd4\x09\x64\xa2\xee\xab\x67\x63\xcc\x47\x90\xa8\x71\xdd\x38\xab\x3a\x4c\x63\xf0\x71\x6a\xa3\x2f\x76\xd9\xee\x63\xca\xe4\x6c\x60\xe6\x40\x33\x6b\xd8\xdc\xbc\xb2\xd3\x53\x1b\x6f\x38\x3b\x1b\x33\xcc\x5a\x86\x68\xed\x61\x9e\x66\x1a\xe0\xf8\x2c\xb3\x6c\xb3\x8e\x6d\xdf\x90\x29\x3e\xa6\xde\x7b\xa2\x8e\xad\x58\x89\x59\xd0\x0a\x45\x0f\x4d\xfd\xe2\x48\xaa\x12\x90\x71\x1f\x6d\xd0\x82\x1e\xfd\x98\xbc\x31\x8c\x4d\xf4\x8e\x86\x66\x0d\xbb\x98\x9b\x2a\x68\xbc\x9c\x1f\x5a\x8e\x71\x39\x31\x54\x56\x57\xf4\x04\xce\x8a\xbb\x8c\x31\x26\xa4\x37\x94\x47\x31\x7c\xf4\xdf\x82\x7c\x4f\x7a\xde\x19\x32\x42\xe7\x3c\x01\xf1\x80\xc2\x90\x68\xd6\x0a\x38\x7b\x37\x4f\xaf\xc4\xaa\x26\xb9\x8e\xec\x44\x56\x22\x61\xc7\x7e\x8f\x16\xa6\x90\x1b\xee\x96\x33\x96\x15\xf1\x80\xf8\xf3\x66\xd5\xfa\x99\x6f\x07\x39\xab\xfa\x18\xe4\x83\x11\xf3\x8b\xca\x92\xe4\xc6\x90\xc9\x04\x6e\x28\x79\xa4\xaa\x85\xee\xa5\x78\x82\x15\xe1\x9b\x0e\x83\x45\x09\x04\x14\x82\x2c\x3e\x56\x94\x2f\xf4\x12\x38\x3c\x89\x75\x55\x80\xa4\x2b\xc2\xb8\x61\xa4\x05\x64\x14\xb4\x24\x5c\x95\x42\xae\x5c\x6b\x5e\x14\x0c\x1b\x51\x52\x81\xa8\x15\x90\x52\x53\x69\xfa\xf5\x0d\xa2\xb9\xd9\x62\x88\x3a\x45\xb8\x43\xe9\x86\x0f\x01\x4e\x17\x44\xb3\xc7\xb6\x86\xb0\x12\x5b\x5b\x10\xbc\xda\xe0\xb3\x9b\x05\x39\xe1\xb8\x57\xc9\x28\xe4\x62\x49\x25\xe5\xba\xda\x58\x0e\xb5\x69\xaa\x51\xa3\x03\x9a\xa7\x61\x60\x6d\x8e\xb8\x05\x1b\xc6\xb5\xf3\x04\x7f\xa9\x82\x31\x03\x66\xb4\x48\x90\x90\x30\x8e\x4f\x42\xda\x62\x6a\x0c\xb6\x9e\x70\x66\xa1\x20\x5c\x45\x2f\xe0\xfe\x81\xd5\xad\x04\xc2\x01\xb7\x30\x8f\xa4\xa2\x5c\xa3\x39\xa6\x0c\x12\xa5\xd6\x2b\xaa\x80\x19\x61\x1a\x87\x98\x02\x52\x49\x4a\x8a\x0d\xe4\x44\x4a\x46\x0b\xc3\x4c\xac\x75\xbb\x41\x28\x99\x54\x1a\x78\xab\x67\x0a\x3f\x93\x0d\xd4\x84\xb3\x1c\x1d\xc6\xe1\x23\x18\x4d\xd2\x30\x40\x1d\xbc\xe1\x7b\x18\x35\x99\x38\x88\xec\x96\xd0\x78\x99\x71\xe3\x46\x69\xc6\x0a\xfc\x22\x21\x12\x12\xe3\x0b\xbb\x6e\x50\xeb\x4c\xd1\x3f\xd6\xce\x88\x52\x48\xc3\xca\x90\xe3\x12\x37\xbc\x4f\xcd\x8b\xfd\x19\x46\x5e\xd6\x91\xa4\x61\x30\xc0\x69\xaf\x60\x0c\x91\xec\xbf\x27\xc8\x79\xef\x3d\xdb\x7b\x8f\x8d\x1a\x2e\x17\x71\xc7\x45\x78\xa1\xda\xd0\x31\x1b\xc3\xbc\x5a\x17\x14\x04\x7a\xa8\x16\x4a\xb1\xac\xa2\x89\x5b\x1e\x13\x9c\x72\x8d\x29\x69\xf8\xa8\x75\x9e\x53\xa5\xca\x75\x95\x86\x81\x4f\x70\x31\xd0\x6f\x5f\x1b\xdb\xe6\x5e\x62\xa8\x47\xc3\x2e\x25\xf2\xc2\xf7\x07\x12\x97\x4e\xb8\x63\x1b\x4c\x31\xdb\xa9\xfd\x6d\x17\x2e\x9a\x7d\x8e\x62\x50\xe6\xc1\x6f\xba\x60\xb7\x3b\x94\xfb\xb6\x14\x1f\x68\x8a\x46\x94\xb6\x4f\x30\x81\x3b\x1c\xb2\xc5\xd6\x0c\xf5\x6b\xed\xbe\x8c\xd8\xc1\x4a\xc4\xba\x1c\x83\x2d\xf8\xe6\x88\xc3\xee\xf8\x5c\x97\x35\xd0\xfe\xda\xb9\xae\x4c\xee\x8e\x0a\x7f\x26\xc8\x3b\x2e\xcd\x8c\x5f\xcc\x8f\x2b\xf1\x7c\x1c\xee\xad\xf3\x73\x2f\xa6\xea\xaa\x27\x86\x5d\x6d\x26\xb0\x52\x20\xa7\x34\x42\x77\x9a\xb1\x7c\xc0\xd8\xca\xbd\xe8\xba\xb9\x71\xdb\x96\x09\x5b\x16\xe2\x04\x1a\x0b\xf0\x23\x2e\x96\xb2\xc7\xc5\x12\x62\x3c\x8e\x89\xed\x52\x3e\x43\xbc\x0b\x03\x03\x20\xd1\xc9\x9a\x3f\x70\xf1\xc4\x6d\xb2\xa2\xfe\x27\x83\x3e\x6b\xe8\xba\x36\x41\xea\x17\x33\xc4\xf5\xb2\xc2\x97\x53\x51\xa7\xd1\x98\xe3\x07\x5f\x43\x47\x5d\xa0\xc0\xbe\x20\x71\xfb\x9d\xae\x04\x36\x89\xdb\x65\x1d\xd5\xd3\x26\xe7\x81\x5d\x44\x34\xcc\xcb\x21\xc1\xde\x59\x41\x6f\x24\x6a\x70\x6d\x54\xf8\xdc\x29\xc9\xb1\x98\xdd\x4f\xe5\x9e\x88\x13\x5b\x75\xe0\x04\x4e\xc7\xe2\x52\x3f\xef\xb0\xa9\x36\x1e\x8e\x72\xb7\x95\xcc\x70\x77\x87\x37\xe9\xb5\x16\x24\x62\x5c\x47\xcd\xb0\xa5\xde\x0f\x9d\xa3\x7c\x6d\x51\x3c\xc6\x77\x7c\x44\x74\x48\xf5\x17\x90\xe4\x0c\x2c\xc3\xc3\xd9\xdc\x31\x79\x1e\x52\xda\xf9\x47\x1d\xf8\x22\xa4\xc0\xd9\x81\x94\xe5\x2f\xe8\xf5\xdf\x43\x19\x53\xef\xcd\xca\x3f\x61\xe3\xb1\x24\x0a\x32\x4a\x79\xdb\xe2\xf4\x50\xc1\xc6\xdd\xc0\x32\xb3\xbd\x10\x88\x45\x06\x32\x5e\x40\xa3\x4e\x1a\xf6\x2b\x66\xb3\x6f\x4e\x2f\xcd\x76\x3d\x0c\xbc\x0d\x98\xe3\x86\x49\x03\x3f\x42\x26\x2e\xec\xde\x62\x80\x4d\x70\x86\x3b\x5d\x4f\x37\x9d\x3e\x43\x68\x34\xf2\x54\x1f\x07\x44\x99\x48\xcc\x8a\xe1\xff\x96\x70\x77\x0c\x1f\xb1\x95\x20\xfc\xff\xb4\x6b\xfb\x06\x2e\x73\xed\xdf\x21\x33\x5c\x1f\x8f\xc6\xd8\x47\xa3\x84\xf5\x06\xe5\xd8\x19\x63\x7f\x97\x13\x9e\xd3\xaa\xb2\x2d\xb3\x3d\x99\x31\x27\xce\x05\x7a\x4b\x9a\xd3\x68\xa5\x59\x55\xc1\x4a\x48\x8a\xcd\x8b\x95\x98\xf6\x0c\xf2\x26\x3b\x07\x8d\x77\xc7\x4d\x3c\x54\xca\x78\xee\xad\x5a\x1d\x96\xd9\xf7\x75\xbb\x75\x39\xca\xbc\x26\x52\x33\x52\x55\x9b\xc3\x62\x92\xce\x72\x6f\xb3\x75\xfd\x48\xfe\xe1\xbc\xcb\x44\xdc\x5b\xd8\xbf\x50\xcb\x7c\x82\x7e\xbb\x5a\xe6\x38\x0e\x6b\x19\x66\x97\x78\x6b\x05\xf3\xda\x7d\x8b\x0a\xd6\xa8\xd9\x45\x33\xc7\xb2\x35\x6b\x2e\xe6\xe1\x61\x18\xf4\x78\xff\x1f\x81\x71\xc7\xe4\x2f\x82\x71\x5b\x75\xde\x08\xc6\xfe\xa0\xfb\x05\xbd\xfe\xd7\x5b\xbe\xc9\xc4\x1d\xa3\xbd\x16\x64\x0f\xa3\xd3\x21\xc0\x1d\x6b\xf6\x32\x9e\x1c\xc1\xe3\xc3\x18\x31\xf6\x82\x5b\x38\x93\xcd\x4d\x6a\x96\xbd\xe7\x91\x57\x00\xf7\xdb\x4c\xee\x83\xa8\x95\xe6\xe8\x5f\x6b\xeb\x5b\x10\x71\x08\x62\x7b\xa5\xe8\x2f\x20\x96\x8f\xe2\x6f\x87\x58\x8e\xe3\x37\x41\x2c\xaf\xdd\xb7\x40\x2c\xeb\xb2\x21\x5c\x8d\x59\x5e\x11\x6e\xc5\x89\x5a\xed\x1f\xbc\xc7\xc6\x7e\x64\x59\x71\x34\xdc\x1d\xfd\xb7\xe7\x88\xa2\xee\x0e\x12\x71\xb2\x3f\x48\xe4\x60\x9c\xe4\x50\xb0\x42\x34\xaf\x38\xfc\xe8\x4f\xe9\x9c\x7e\xfe\x82\x67\xef\x54\xce\xb8\xeb\x85\xcb\xd0\xc3\xaa\xee\x13\xfa\x43\xe9\x25\x25\x45\x02\x2b\x56\x24\xa0\x09\xab\x06\x54\x61\x60\x3e\x4e\x71\x33\x72\xcc\xa8\x6e\xbe\x31\xcc\xea\x80\xef\x18\xf1\x28\xa2\x3b\x73\x6c\x05\xda\xa3\xd8\xd1\x4d\x83\x1d\xc7\xb9\xfe\xc6\xd2\x5e\x30\xf7\xb4\xfa\xc4\xca\x12\x6a\x29\x8a\x75\x4e\x15\x10\x50\x54\x83\x28\x41\xd4\x54\xba\xeb\x6a\x73\x08\x96\x13\x0e\x19\xed\x1f\xe3\x35\x0a\xb4\x40\x7e\x6e\x72\x7b\xae\x67\xe8\xed\x51\xa4\xbd\x41\xb2\x9f\x33\x77\x45\x34\x16\x1f\x91\x04\xb2\x83\xf7\x42\xe3\x3b\x07\x74\x73\x14\x06\x01\x3a\x6b\xf0\x1b\x9e\x3f\x07\xc4\x78\x32\x33\x7f\x57\xac\xa8\x28\x86\x0a\x2b\x8c\xf4\x9b\xab\xfb\x41\x74\x47\x24\x1e\x06\x78\x94\x21\xca\xc4\x26\x21\x3d\x03\x7f\x5a\xed\xce\xb0\x49\xef\x40\x18\x75\x6a\x97\x46\xd4\xea\xd0\x85\x88\x9d\x13\xc7\xdd\x65\x81\x69\xda\x5f\xcb\xc5\xdd\xdc\x64\x9e\x41\xef\xfc\xfb\xe0\xbc\x91\xa7\x67\x17\xe8\x95\x79\x02\xd9\xec\x02\x3d\x33\x8f\x6d\xe8\x1c\x17\xeb\xea\x8b\xf5\x41\xfc\x2c\xf9\x58\x1a\x0a\x3b\xb5\xd3\x2e\x8c\xd0\xac\xff\x21\x1e\x86\xad\xa8\x55\x9b\x8f\xbd\x85\x32\xf1\x31\x02\x27\x62\xae\x7b\x12\xcc\x76\xdb\x75\x84\x41\xd5\xdd\x6d\xcf\xe6\xe6\xca\xdf\xfa\xfb\x07\xeb\xe4\x38\x0c\x56\xa4\x71\x97\x8c\xe4\x3c\x81\xec\xbc\xbb\x2d\xc2\x8c\x44\x96\x84\x74\x29\x49\xda\xdb\x6f\x14\x94\x65\xdd\x48\xd6\x5e\x59\x10\x62\x2a\x8f\xfb\x10\x54\xe6\x02\x8a\x30\x27\x12\x4e\x21\x63\xf6\x66\xbc\x04\xc2\x6c\xfc\xfc\xf9\x27\x64\xac\x77\xf1\x11\x04\x95\x9a\x55\xe6\xde\xfc\x9d\x79\xef\x5f\x6b\xf4\x06\x2b\x35\x8b\x08\x3b\x7b\xe7\xed\x39\xcd\xd8\xd9\xbb\x39\x9c\xfa\x59\x5e\x8e\x9b\xf0\x11\xd0\x5a\xc7\x04\x1f\xa7\x6e\xc4\x7e\x71\x0e\x40\x65\x4f\xdf\x9d\xad\x48\x83\xee\xb4\x4f\x1d\xbb\xd1\x4d\x86\x9d\x95\x20\xeb\x70\x17\xfe\x3b\x00\x00\xff\xff\xde\x8b\xba\x43\x0f\x25\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x3a\x6b\x6f\xdb\x3a\x96\x9f\xa5\x5f\x71\x1a\x60\xbb\xd2\x8d\x22\xa7\xf7\x63\x7a\x5d\xa0\x9b\x76\x67\x83\xed\x6d\x07\x4d\x67\x2f\x06\x41\x30\xa0\x24\x3a\xe1\x56\x26\x75\x49\x3a\x91\xe1\xf1\x7f\x5f\x9c\x43\x52\x0f\x5b\x76\x92\x6e\x81\xb9\x8f\xd6\x22\x79\x9e\x3c\x4f\x92\x9b\xcd\x19\xcc\x7e\x89\x01\x00\x2e\x55\xb3\xd6\xe2\xee\xde\xc2\xaf\xe7\xbf\x9e\xc3\x5f\x94\xba\xab\x39\x7c\xfa\x74\x19\xd3\xf4\x27\x51\x72\x69\x78\x05\x2b\x59\x71\x0d\xf6\x9e\xc3\xfb\x86\x95\xf7\x3c\xcc\x64\xf0\x3f\x5c\x1b\xa1\x24\xfc\x9a\x9f\x43\x82\x0b\x4e\xfc\xd4\x49\xfa\x96\x70\xac\xd5\x0a\x96\x6c\x0d\x52\x59\x58\x19\x0e\xf6\x5e\x18\x58\x88\x9a\x03\x6f\x4b\xde\x58\x10\x12\x4a\xb5\x6c\x6a\xc1\x64\xc9\xe1\x51\xd8\x7b\xb0\x3d\x85\x9c\x90\xfc\xdd\x23\x51\x85\x65\x42\x02\x83\x52\x35\x6b\x50\x8b\xe1\x4a\x60\xd6\x71\x8d\xff\xde\x5b\xdb\x98\x8b\xd9\xec\xf1\xf1\x31\x67\xc4\x72\xae\xf4\xdd\xac\x76\x4b\xcd\xec\xd3\xd5\xe5\xc7\xcf\xd7\x1f\xcf\x7e\xcd\xcf\x1d\xd0\xdf\x64\xcd\x8d\x01\xcd\xff\x5c\x09\xcd\x2b\x28\xd6\xc0\x9a\xa6\x16\x25\x2b\x6a\x0e\x35\x7b\x04\xa5\x81\xdd\x69\xce\x2b\xb0\x0a\x99\x7e\xd4\xc2\x0a\x79\x97\x81\x51\x0b\xfb\xc8\x34\x27\x3c\x95\x30\x56\x8b\x62\x65\x47\x5a\x0b\x2c\x0a\x33\x5a\xa0\x24\x30\x09\x27\xef\xaf\xe1\xea\xfa\x04\xfe\xe3\xfd\xf5\xd5\x75\x46\x58\xfe\xb8\xfa\xf6\x5f\x5f\xfe\xf6\x0d\xfe\x78\xff\xf5\xeb\xfb\xcf\xdf\xae\x3e\x5e\xc3\x97\xaf\x70\xf9\xe5\xf3\x87\xab\x6f\x57\x5f\x3e\x5f\xc3\x97\xff\x84\xf7\x9f\xff\x0e\xff\x7d\xf5\xf9\x43\x06\x5c\xd8\x7b\xae\x81\xb7\x8d\x46\x19\x94\x06\x81\xfa\xe4\x95\x53\xde\x35\xe7\x23\x26\x16\xca\x6d\xa5\x69\x78\x29\x16\xa2\x84\x9a\xc9\xbb\x15\xbb\xe3\x70\xa7\x1e\xb8\x96\x42\xde\x41\xc3\xf5\x52\x18\xdc\x59\x03\x4c\x56\x84\xa7\x16\x4b\x61\x99\xa5\xb1\x3d\xd1\xf2\xf8\x97\x19\x9c\x6d\xb7\x71\x3c\x9b\xc1\x66\x93\xff\x85\x4b\xae\x99\xe5\xd5\xa5\xaa\xf8\x1f\x8c\xb0\xe2\x6c\xc3\xca\xef\x48\x6a\xb3\xc9\xff\xea\x7e\x7e\x66\x4b\x8e\x33\x62\xd9\x28\x6d\x21\x89\xd1\x3e\xc5\x02\xf2\x4b\x25\x17\xb5\x28\xed\x47\xad\x95\xde\x6e\xe3\xe8\x64\xb1\xb4\x27\x34\xcd\x6b\x83\x30\xd1\x09\xc7\x39\xe3\x07\x65\xb5\xdd\x76\xd0\x5f\x9a\x6b\xab\x85\xbc\xe3\x0e\xd4\x58\x5d\x2a\xf9\x30\x5c\x99\xc6\xf1\x62\x25\x4b\x48\x5a\x83\x1c\x5f\xa3\x71\x7c\x5b\x37\x7c\xbb\x4d\xe1\x7d\xd3\x70\x59\x25\xac\xaa\x20\xcf\xf3\xcd\x26\xff\x58\xf3\x25\x97\x36\xcc\x6f\x36\xf9\x07\x5e\x5b\xe6\xbe\x61\x13\x47\x9a\xdb\x95\x96\xd0\x9a\xfc\x4a\x1a\xae\x6d\x52\x73\x99\xb4\x26\xcd\x80\x55\x55\x9e\xe7\x69\xbc\x3d\x42\xef\x2b\x47\xc3\x4e\x34\x08\x69\x8f\x60\x1f\x4f\x6c\xb6\x79\x80\x3b\x8e\xdd\x73\x24\x10\x7b\x06\x3f\x24\x94\x27\x24\xd2\x20\xde\x73\xa4\xfa\xc0\x6b\x6e\x79\x22\x32\x90\xab\xe5\x13\xa2\x8d\x68\x78\x40\xb9\x5a\x3e\x87\x80\x17\xc3\x24\x15\xaf\x9f\x2b\x99\x30\x70\x31\x87\x25\xfb\xce\x93\x25\x6b\x6e\x84\xb4\xb7\x85\x52\x75\x1a\x47\xe8\x1e\xff\xc8\x40\xe3\xbc\x66\xf2\x8e\x03\xa2\xdd\xc4\x11\xcd\x88\x0c\xda\x7e\x06\x77\x32\x8e\xa2\x48\x2c\xa0\x85\xf9\x1c\xb4\xfb\x8c\x84\xb9\x11\xb7\x30\x07\xab\x57\x1c\x07\xb6\x31\xfd\xbf\x8d\xa3\x07\xa6\x11\x9f\x65\x3b\x3c\xc5\xd1\x42\xab\x25\x62\x3e\x8f\xa3\x0a\xc5\x47\x37\xbc\x98\xc3\x82\xd5\x86\x3b\xae\xc4\x1e\x61\xb1\x80\x6e\x6d\x60\xe4\x95\x23\x4e\x9f\x88\xc9\x32\x98\xe3\x2a\xcb\xf2\xb0\x1f\x70\x06\x48\x2d\x0d\x4b\x1c\x82\x8e\x56\x14\x39\x66\xe6\x20\x3a\xe6\xc9\xe5\x3a\x1a\x87\x49\x84\x3d\x3c\x44\x22\x68\x64\x9f\x42\x8c\xff\xed\x0a\x34\xc9\xbf\xf7\xac\x9e\xc4\xb6\xb3\x22\x5a\x87\x26\x33\x9b\xc1\x5f\x35\x5f\x88\xf6\x77\x66\xcb\x7b\x70\xd3\x86\xe2\x9e\x5c\x2d\x0b\xae\x31\x7b\x70\x6f\x38\xc0\x2c\xcd\x14\xfc\x4e\x48\x8c\x54\x38\xd9\xe2\x6a\x66\x61\x49\x08\xec\x3d\xc7\xc8\x76\x14\x62\x6d\xf2\xc3\xa6\x3a\xe0\x26\x59\x1b\xb8\xb9\xdd\xb7\x52\x21\x2d\x6a\x58\x78\x2b\xc0\x2d\x7f\x0b\x02\x7e\x83\x20\xf0\xeb\xd7\xdd\xe7\xda\xa4\x6f\x41\x9c\x9e\x06\x33\x68\x69\x47\x5e\xcd\x61\xdd\x6f\x4d\xa1\x39\xfb\xde\xa9\xd6\x6b\x48\xa0\x76\xec\xba\xe1\xbb\x4e\x41\x1c\x7d\x69\xbc\x39\x06\x41\x76\x56\x3d\x1d\xa9\xc4\x02\x34\xba\xc2\x39\x7e\x74\xce\x3d\xe4\x80\xb9\xd8\xda\x66\x30\x20\x18\xd0\xa6\xe8\xf0\x87\x68\xf7\xa1\xe7\x85\x01\x6c\x92\x64\x8f\xed\x28\x51\x6f\x74\xd5\x13\xf1\x6b\x92\x44\x80\x3d\x4a\xe0\x2b\x2f\x98\xe1\x09\xfe\xb1\x37\x97\x8c\x07\x32\xa0\x84\x97\x22\x5d\x8c\x24\x9a\x9b\x1d\x90\x38\x6a\x45\x06\x85\xb3\xa1\x0c\xcd\x88\xd6\x65\xd0\xaa\x26\x83\x42\x35\x43\xf6\x9c\x8d\x05\x0b\x52\x0d\xee\x9b\x14\x75\xe7\xe6\xad\x80\x77\x73\x32\xb7\x36\xf5\xde\x1e\x6c\x0a\xf7\x33\x8a\x08\x06\xda\x9b\x56\xdc\xd2\xa7\x38\x3d\x75\xf6\x86\xd0\xc5\x3e\xc2\xa2\x43\x88\xd2\x4e\xe3\x24\x30\xc0\xf9\x9b\xc2\xa1\x2d\x7a\xb4\x43\x41\xe6\xf8\x33\x0f\xda\x53\x0d\x86\x33\xb2\xbe\x57\x03\xa2\xa8\xa1\x79\x30\x39\xcd\x4d\x06\x3a\x1d\x46\x1b\x94\x60\xb0\x7e\x7f\x79\x4b\x88\xb7\xf1\xd4\xd4\x4d\x2b\x2e\x6e\x29\xb7\x53\x5c\x2f\xa7\xb6\x63\x3f\x9d\xd0\x2a\xc7\x2b\xaa\x16\xa1\xd2\xde\x63\x70\x58\xe7\x9f\xb8\x4c\x52\x78\x17\xc6\xa2\x72\x44\xbd\xec\x05\xd9\x8b\xce\x65\x06\xea\x3b\x12\xc3\x45\x37\x1d\x81\xb3\x37\xb7\xf9\xa5\x5a\x36\xac\xb4\x58\x2d\x38\x3a\xea\xfb\x00\xfd\x68\x2d\xcc\xa1\x8c\xa3\x0e\xb3\x58\xc0\x2b\xf5\x1d\x5e\xbf\x7e\x39\x6b\x43\xcf\x47\x4e\x32\xd4\xf5\x30\x06\x05\x63\x44\xff\xe2\x7a\xc1\x4a\x12\x64\x36\x83\x4f\x9c\x3d\x70\xd3\x85\xee\x7b\xf5\x08\x4b\x26\xd7\x7d\x0c\x56\x0b\x60\x60\x30\xc8\x62\xbc\xae\xb9\xbc\xb3\xf7\x20\xe1\x51\xad\xea\x0a\x34\x5f\x32\x21\xe3\x68\x36\xc3\x7a\xbd\xe0\x60\x35\x93\x66\xa1\xf4\xd2\x57\xf7\x55\x25\xb0\x96\x65\x35\xa8\xc6\x00\x5b\x58\xae\x51\xbf\xf5\x1a\xa3\x39\x75\x29\xaa\xc9\x31\xdc\xad\xb4\x34\x84\x87\x81\xe4\x77\xcc\x8a\x87\x2e\x87\x88\x05\x56\xc7\xa0\x64\xbd\xc6\xa2\xd3\x43\x41\xc9\x24\xb6\x3b\x05\x87\x52\xdd\x73\xcd\xa5\xad\xd7\x0e\x03\xb6\x14\xae\x83\x98\xe0\x3c\x8f\x23\x27\x73\x22\x51\x19\x94\x10\xbc\x26\xe4\x53\x19\x4c\x50\x30\xe3\x55\x86\x0b\x99\x90\xf8\x4b\x51\xa9\xc1\xb1\xd3\x28\x1c\xfd\x20\x16\x12\x92\x49\x4f\xe0\xfa\xbb\x68\x3a\x0a\x4c\x02\x76\x41\x0f\xac\xe6\xd2\xa2\x38\x94\x06\x99\x31\xab\x25\x37\x20\x88\x98\xc5\x29\x61\x80\xd5\x9a\xb3\x6a\x0d\x25\xd3\x5a\xf0\x8a\x88\xa8\x95\xed\x7a\x8c\x85\xd0\xc6\x82\xec\xf8\xcc\xe1\x77\xb6\x86\x86\x49\x51\xa2\xc2\x24\xbc\x43\xe1\x92\x34\x8f\x23\xe4\x21\x08\x3e\x8a\x51\xb3\x19\x38\x27\xef\xb7\xd0\x20\x5b\x42\x92\x1a\x35\xcd\x55\x38\xa2\x21\x51\x1a\xed\x0b\xab\x6e\x30\xab\xc2\xf0\x3f\x57\x5e\x88\x85\xd2\xc4\x1d\x2d\xc7\x2d\x6e\xe5\x70\xb5\xac\xc6\x10\x88\x13\x8a\x7e\x49\x1e\x47\x3b\x71\x3a\x30\x98\x42\xa2\x87\xdf\x19\xb4\x72\xfc\x5d\x8c\xbe\x53\x62\xc3\xfb\x22\x36\x6d\x4c\x56\xa6\x33\x1d\xea\x2d\xcb\x7a\x55\x71\x50\xa8\xa1\x46\x19\x23\x8a\x9a\x67\x7e\x7b\x90\x73\xac\xa1\x40\x2c\x08\x8f\x59\x95\x25\x37\x66\xb1\xaa\xf3\x38\x0a\x0e\xae\x46\xf4\x20\x19\x7c\x61\x0e\xa0\x32\xf7\x3d\x9a\x7a\xb2\x5b\xa5\x24\x81\xf8\x78\x22\xf3\xee\x84\x4d\xdf\x0e\xc8\x81\x86\xcb\x35\x5f\x49\x0a\xd8\xed\xca\xbb\xd0\x76\xc1\x76\x3b\xe5\xfb\x2e\x15\x4f\x14\x45\x7b\x2b\x5d\x9d\x80\x56\xb2\x37\xe5\x92\x2d\x4d\x0d\x73\x6d\x98\x76\x34\x52\x1f\x56\x12\xd1\xfb\x18\x6c\xbc\x72\x41\x48\xd8\x1e\x87\xf5\x5e\x13\xce\x19\xa0\x87\xf5\x69\x72\x1b\x1f\x43\x70\xc0\xc8\x7b\x2c\xed\x8d\xbc\xb8\x3d\xce\xc4\x61\x3b\x1c\x7c\x65\x70\xe8\x83\xb2\xae\x79\x14\x58\xd5\x16\x0a\x33\x05\xa2\xcb\x13\x54\x27\xcd\x95\x3b\x88\x1d\xdd\x8b\x38\x42\x7b\xfb\x03\xe3\x19\x14\xca\xde\xfb\x90\x13\x2a\x61\xc3\x96\x1c\x1a\x65\x28\xa8\x66\xe4\xfc\x6e\x01\x9e\x0e\x51\x04\x31\x4a\x5b\xe3\xb0\xb8\xb0\x20\x0c\x34\x35\x2b\x79\xe5\xbe\x33\x30\xca\x95\xdc\x9d\x8f\xfa\x93\x0d\x25\x39\x28\xf4\x76\xc4\xaa\x70\xc8\xa1\x69\xb4\xaa\x56\x25\x37\x3d\x03\x9a\x9b\x55\x6d\x73\x5f\xd1\xe4\x05\x5f\x28\xcd\x93\x42\xf9\x52\x23\x28\x99\x92\x10\xba\x42\x28\x2b\xba\xf6\x7a\xa7\x1e\x2d\x94\xcb\x77\x69\xe6\x81\xf6\xd5\xe3\x56\x5e\xc4\x93\xd8\x77\x17\x3b\x1b\x3d\xb0\x78\x1b\x47\x14\x19\x93\x93\x95\xfc\x2e\xd5\xa3\xa4\x2a\x08\x70\x63\x4e\x52\xdf\xd4\x38\x89\xbc\xb5\x98\x10\x0a\xa0\x75\xda\x0d\xd3\xeb\xae\x11\x99\x32\x20\xaf\x95\xf5\xe4\x9c\x52\x54\x39\x75\xfd\xe6\xf9\xa0\x05\x19\x77\x20\x3b\x0d\x48\xe8\x3f\xba\xf6\x23\x48\x88\x03\xbf\xd1\xf8\x6e\x3d\xe0\x91\x06\x7c\xf1\xf6\x18\xd7\x5d\x70\x6b\x8e\x59\x3d\x45\x37\xdf\x87\xa8\x50\x0a\xa9\x26\x4f\xf6\x31\xbe\x0d\xf5\x8f\xe7\xc6\x57\x30\x6d\x06\x0a\x6b\xba\xcc\xf7\xaa\x3d\xbb\x6d\xe6\x3b\xe4\xa3\x7c\xba\xc0\x3a\xd1\x01\xee\x04\xdb\x6c\x6f\x41\xdf\x4c\x8c\x67\x92\x16\xcd\xcf\xa0\x05\x6c\x36\xe3\x50\x4b\x47\x6e\x47\x98\xd9\x09\xc3\x03\x12\x27\xde\x7d\x4f\xe0\x74\x9f\x5c\x1e\xe0\xa6\xb7\xc4\x99\xfc\x51\xec\xae\x0a\x21\xec\xfe\xe8\x2d\xbf\xb2\x8a\x25\x42\xda\xa4\x4d\x0f\xe0\x75\xde\x71\x14\xaf\x2b\x68\x8e\xe1\xed\x0f\xf8\x8e\xb1\xfe\x44\x16\x38\xc3\x11\x17\xce\x8f\x22\x39\x9c\x0e\x3a\xf8\x63\x5c\x3c\x9d\x0e\xe0\x6c\x1f\x2e\x91\x4f\xf0\xf5\x2f\xcd\x10\x3e\x43\x3f\x62\x20\xbf\x67\x06\x0a\xce\x65\x57\x9e\x0e\x02\x1f\x9c\x0e\x51\x78\xc9\x30\x10\x14\x2a\x4d\x7d\x54\x7c\x22\xe0\xf6\xd4\x30\x01\xd0\x41\x0d\x9a\x4b\x43\x47\x2d\x71\x14\x64\x40\x1f\x27\x96\x5b\xf8\x0d\x0a\x75\x31\x99\x09\xe0\x0c\xda\x7e\xdd\x7c\x7e\x60\x21\x71\x14\x56\xbd\xdb\x59\x54\x28\x3c\x1d\x3c\xa3\xbf\xdd\xc2\xed\xb1\x14\x80\x65\x20\x93\xff\x6e\x7d\xc9\xbe\xa3\x32\x5f\xba\x4f\x89\xe1\x7b\x30\x14\xc6\xfd\x44\x6c\x5e\xf7\x5c\x52\xc6\x15\x06\xbb\x90\x92\xd7\x35\x55\xff\xfe\x54\x8d\x2e\x1c\x2a\x4c\x97\x9a\x2e\x23\x8c\x15\x75\x0d\x4b\x4c\x19\x56\x79\x8a\xf9\x40\xa0\x20\xb2\x57\xd0\x9e\x14\x49\x9b\xee\x32\x35\x9f\xff\x00\x57\xd3\x34\x87\xba\xf6\xd8\xdf\x1d\x47\xde\x30\x6d\x05\xab\xeb\xf5\x34\x99\xac\x97\x3c\xc8\xec\x54\xbf\x47\x7f\xda\xef\x0a\x95\x0e\x36\xf6\xa9\x74\x7d\xc4\x41\x7f\x5e\x2e\xf3\x18\x77\x73\x59\x0b\xa7\xa0\x5e\x9a\xc1\x02\x77\x3f\x23\x83\xb5\xe6\xe6\xa2\xbd\xc5\xb4\x75\xd3\x5e\xdc\x0e\x0e\xf1\xa7\xe2\xfd\xff\x2b\x18\xf7\x48\x7e\x30\x18\x07\x04\x2f\x0d\xc6\xde\x05\xe4\x13\x7c\xfd\xeb\x82\xb1\x67\x7b\xca\x8e\x5f\x50\xd5\xce\x66\xfe\x9a\xe5\xb9\x41\x76\x3a\x3a\x05\x1d\x1e\x88\x27\x9e\xb3\xa7\xe3\xc9\x91\x78\x3c\x1d\x23\x3c\xc0\xfe\xc6\x91\x37\xb7\x39\x6d\x7b\x80\x18\xfa\xf7\xe1\xc0\xfd\x32\x91\x03\x9f\xe8\xd0\x8e\x9a\x5f\xff\x5c\x59\x5f\x12\x11\x87\x20\x7b\xa9\xe8\x07\x22\x56\xb0\xe2\x9f\x17\xb1\x3c\xc6\x9f\x12\xb1\x02\x77\x3f\x23\x62\x91\x96\xf7\xc2\xd5\x3e\xca\x4b\x26\x1d\x39\x3c\x36\x1c\x5d\x9a\xf4\xdd\x53\x2d\xb1\xed\xf0\xd7\x36\xdd\x19\xb0\x6a\xfa\x43\x60\x04\xf6\x9d\x53\x2d\x81\x5a\x14\x1f\x05\x6b\x99\xbe\x85\x5a\xc2\x6f\xe1\x84\xd5\xf3\x17\x2e\xe7\x46\x1d\x14\x05\x78\xd7\x19\x5e\xc9\x07\x6c\xc7\xbb\x53\x3c\x7f\xcd\x48\x0d\xf5\x4a\x56\x8a\x1b\x68\xfd\x31\xa7\x18\x94\x19\x83\x23\x49\x34\x86\x3c\x9e\xcd\x10\xdb\x7b\xe9\xae\x1a\x30\x65\x3b\x94\xbc\xc2\x63\x9e\x76\x70\xb8\xb9\x07\x3a\xd8\xa1\xc1\x19\x78\xea\x59\xeb\x42\xe0\x48\x9d\x47\x6e\x38\xf0\xd4\x19\x61\xf2\x4e\xe5\x74\x0d\x11\x0e\x9c\xf6\xde\x07\x04\xa5\xd0\x4e\x2e\x96\x36\xa7\x99\x45\x72\xe2\x59\x16\x4e\x43\x23\xcd\xec\x49\x73\x01\xff\xf6\x78\x42\x76\xb2\x83\x3f\x1d\xbd\x3c\x18\xd1\x22\x4d\x99\xfc\x33\x7f\x7c\x19\xad\x13\x8f\x93\x1e\x2e\x84\xdb\x61\x21\x1f\x0e\x5d\x22\x0c\x0d\xa8\x85\x4d\x1f\x89\x54\xf0\xb3\x2e\x2b\x1c\x49\x0b\x11\x52\xe8\x0e\xec\x85\x7c\xc8\x26\x82\x23\xda\xae\x4a\xd3\x74\x02\x53\x9f\x1a\x26\x30\x29\x84\x88\x70\xd7\xc2\x25\x8e\xba\xb8\x8d\xa3\x23\xc1\xf4\x38\x3b\xe1\x92\xce\xcd\x8e\x8d\x67\x83\xfd\x3e\x92\xb8\x50\x74\x0b\x93\x1e\xa0\x3d\xf2\x18\x22\xe0\xaf\x20\x0e\xfa\xf8\x41\x07\x1f\x2f\x0c\xd7\x70\xf7\x9c\x55\x19\x2c\x45\x95\x81\x65\xa2\xde\x59\x15\x47\x34\x38\xc7\x16\xfe\x58\x28\xe8\xe1\x29\x1c\x38\x1e\x10\x14\xc5\x42\x12\xbd\x96\x3a\x82\x28\xf6\x50\xba\xd1\x3c\xc2\x86\x37\x1a\xb3\xd9\x0e\x57\x1f\xc4\x62\xd1\x1f\xa0\x31\x30\xdc\xe2\x2d\x8a\x6a\xf0\xd9\x0e\xbd\xf1\x09\x36\x3b\x30\x58\xac\x98\xf1\x5e\x5c\xc5\xfd\xe9\x5b\x77\x93\x41\xeb\xdd\xe5\x0b\xb5\x62\x7e\xb8\xf0\x71\x61\x9f\x7c\xc2\x32\x28\x26\x6f\xc2\xc7\xf6\x1f\xd4\x9c\xc4\x51\x84\xca\xda\xf9\x67\xd7\x59\x22\x46\x9a\x2c\xe8\xcf\xa5\xa8\x6a\x8e\x01\x56\x54\x24\xfc\xa7\xcb\xeb\x1d\x2b\x4a\x58\xba\x9b\x16\x92\x02\x4d\x29\xa5\x43\xa4\x80\x20\xdc\xcf\xf9\x5b\x3b\x36\xb8\x02\x43\x9e\xba\xad\x51\x8d\x39\xe4\x4f\xcc\xf9\x93\xbf\x1e\xc5\x91\xe2\xb9\x58\xbc\x1b\x14\x01\xc1\xe0\xc6\x6f\x12\x6e\x20\x8c\xd3\xf4\xcd\x05\x6a\xe5\x36\x83\xe2\xe6\x02\x35\x73\x9b\x92\x65\x3c\x41\xd6\x57\x65\x4e\x07\x69\xfa\x7c\x6a\x48\xec\xd4\x81\x5d\x10\xd1\x62\x38\x90\xee\x9a\xad\x6a\xcc\xa4\x91\xfe\xce\xf5\x1d\x1f\x5a\xe9\x38\x9a\xee\x58\xa6\x5a\x69\x33\xb8\x40\x41\x74\x58\xb2\x96\xf7\xf8\x8a\xc7\xc0\x92\x55\x9c\x5e\x8c\xf8\xaa\x87\x8e\x93\x85\x36\x21\xe5\xf9\xab\x3e\xd7\x21\xe1\x89\x85\x45\x9f\xc3\x73\x44\xc4\xeb\xfb\x66\xa1\x8d\x47\x08\xf8\x58\xae\x66\x4d\x43\xd5\x30\xc3\xd3\x57\xb5\x20\xd4\x19\x62\xc3\xd4\xb9\x78\xf2\xa0\x7c\xd2\x37\x48\x6a\xca\x96\x19\xd1\xce\x02\xe1\x29\x57\xd9\xcb\x9f\x58\x83\xf8\x94\x44\x99\x80\xff\xb9\x62\x35\x46\x1b\xa4\x94\xb4\x26\x83\x03\xcf\x4f\x42\xf1\x12\x36\x25\xbc\x3a\x99\xcf\xc3\x8b\x13\x3c\xfe\x1d\xb3\x8a\x2f\xdc\xf2\xf1\xd3\x96\x0e\x80\x4a\x9f\x6d\xd7\xbc\x84\x56\x85\xf8\x49\x86\x72\xa5\x99\x1f\x74\x9f\x2e\xa8\xa7\x83\x0e\x86\x92\x2c\x96\x3a\xbe\x96\xdd\xc3\xb3\x0b\x30\x66\x92\x3c\x60\x87\xe0\x00\xdb\x36\x8e\x14\x46\xcb\x0a\x95\x34\x01\xd9\x6f\x44\x9a\x1d\x9e\xf7\x98\x29\x66\xf0\x4a\xd8\xeb\x86\x49\xf3\xc5\x99\x48\xa2\xaa\xbc\x1b\x4b\x90\xfa\xe8\x3b\x1d\x6a\x9d\x64\x25\x13\xec\xd9\xd3\xb4\x9f\xc8\x9e\xad\xc2\x83\x07\x55\xb9\xf8\xc4\xf5\xe8\xc1\xc3\x04\x16\xae\x75\x78\xed\xf0\x6a\xcc\x3e\x69\x2f\xed\xcb\x2b\xfd\x82\xf2\xca\x71\x38\x51\x65\x2d\xd1\x7a\x3b\xa7\x9b\x2e\x7c\x7e\xa4\xc8\xea\xa5\xd9\xad\xb5\x9e\x43\x71\xaf\xd4\xf2\xa8\xf5\xd0\x14\x5c\x08\x0a\x3b\x83\xa5\x2f\x0b\x39\x1a\x9f\x1a\xe0\x46\xfb\xa4\xe6\x88\xd1\xe1\x92\x0f\x4a\x19\x3c\xd2\xa9\x1a\xab\xfe\x97\x95\x5c\x5a\xc4\x14\xce\x9d\x5c\xf4\xe8\xae\xc3\x0c\x5e\x8f\x2f\x31\x6f\x0a\x89\xef\xad\x4d\xc3\x64\xee\xae\x33\x3b\xda\xc6\xea\x55\x49\xcf\xc1\x8c\x65\xda\x66\xc8\x39\x1e\x4d\xc4\x91\x43\x43\x59\x10\x3d\x76\x87\xe9\xfe\xbd\x04\x86\x3e\x62\xde\x1c\xe3\xbe\x3d\x5c\xb5\x77\x28\x93\x14\x6e\x6e\xc3\xd7\x30\x19\x23\xe3\x66\x30\x17\x47\x51\xa3\x90\x33\x62\x34\x52\x0d\x97\x9e\xc9\x28\x9d\x2c\x7d\xda\x90\x52\xf5\x33\x8f\xb7\x08\xff\xe9\x1c\x35\xe1\x9f\xb1\x10\x91\xc1\xd3\xc5\x52\x49\x2b\xe4\xca\x77\x4a\x64\xf4\xb4\x04\x09\x79\x86\xbb\xfc\x45\x9f\x59\x27\xe8\x86\x54\x7d\x81\xb7\x98\xa4\x6f\xfa\xb5\x1d\x52\xf1\xaf\x17\xd1\x34\x6b\x66\x2c\x32\xfc\x9a\x90\xd0\xe3\x19\xfa\x95\x9e\xbd\xb9\xf5\x2f\x33\x9f\xd9\x00\x0f\x65\xaa\x48\x26\xc4\x9d\xe3\x86\xcf\x91\x83\x9d\x07\x3e\x34\x19\x8c\x60\xc8\x52\x6f\xd4\xc4\xc8\xae\x61\xf8\x58\xb4\x77\x75\x88\x4f\x6a\x10\x00\x2f\xbf\x5b\x13\xb2\x1a\x9a\x7e\x18\x5d\x1b\x7a\x4b\x22\x16\x68\xd3\xcf\xcc\x68\xbb\x64\xfb\xc4\x13\x66\xfa\x84\xe3\x0d\x83\x0d\xec\xc2\x74\x0f\x6e\xff\x81\x35\x62\x37\xb1\xee\x1f\xdc\xb2\x9c\xb6\x0b\xcf\x99\x48\x57\xaf\x5f\x43\xd1\x0d\x31\x1a\xa2\xa5\x41\x29\x5e\x51\xd1\xb6\x83\x0f\x4a\x24\xc8\xc1\x47\x87\x79\x3e\x81\x7a\xfe\x14\xee\xd1\x4e\x84\xb3\x0b\xe7\x64\x83\x1a\x94\x4a\xdf\x71\x34\x4e\x21\x61\xf4\x76\x2f\xc3\xf6\x9f\x4e\x42\x37\x71\x54\xf7\x0f\x95\x6f\x6e\xe9\xfd\x36\x9a\x1a\x4b\x7f\xc1\xbf\xa8\x1e\x5c\xb2\xd6\xbf\x18\x65\xe7\x19\x14\xe7\xfd\xd3\x3f\xd4\x1f\xa2\x64\x03\xd5\xb2\x4e\xb3\x05\x12\x1b\xe8\xb6\xe8\x55\xcb\x30\x87\x17\x7e\x20\xaa\xe9\x22\x98\x09\x4f\x12\x4e\xa1\xa0\x67\xbb\xb4\x0b\x02\x97\x9e\xc3\x3f\xff\x89\x8f\x0e\xfb\x57\x6c\x51\x54\x9b\x9b\x9a\x1e\x41\xbf\xa1\xef\xa1\x09\x0f\x26\x6b\x73\x93\x30\x71\xf6\x26\xc8\x73\x5a\x08\x7c\x7e\x76\x1a\xa0\x02\x1d\x0f\xf0\x0e\x50\x5a\x8f\x04\x7f\xce\xfd\x8c\x1b\xf1\x0a\x98\x03\x13\xa7\x6f\xce\x96\xac\x45\x75\xba\x5f\x3d\xba\xbd\x4d\x72\x50\x19\x2c\x59\x1b\x6f\xe3\xff\x1b\x00\x5b\xf2\x36\x2d\x1f\x33\x00\x00"),
 		},
 		"/ot_test.go.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "ot_test.go.tmpl",
-			modTime:          time.Date(2020, 8, 1, 22, 9, 22, 276077914, time.UTC),
-			uncompressedSize: 10825,
+			modTime:          time.Date(2026, 10, 17, 0, 41, 50, 765709458, time.UTC),
+			uncompressedSize: 14442,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5a\xdd\x6f\xdb\x46\x12\x7f\x26\xff\x8a\x09\x8b\x06\x62\x8f\xa1\x65\xb9\x4f\x0a\xf2\x20\x5b\xba\x9e\x70\x8e\x54\x58\xee\x05\x45\x51\x18\x2b\x6a\x29\x2d\x8e\x5a\xb2\xe4\x32\x8e\xa1\xf2\x7f\x3f\xcc\xee\x52\x22\xc5\x0f\x51\x76\xd2\xa4\xc0\x05\x46\x4c\x71\x3e\xf6\xb7\xf3\xb5\xb3\x23\xef\x76\x6f\xe0\xe2\x07\x13\x00\xe0\x26\x8c\x9e\x62\xb6\xde\x08\x18\xf4\x07\x7d\xf8\x29\x0c\xd7\x01\x85\xdb\xdb\x1b\x53\x92\x6f\x99\x47\x79\x42\x57\x90\xf2\x15\x8d\x41\x6c\x28\x8c\x22\xe2\x6d\x68\x4e\x71\xe0\x3f\x34\x4e\x58\xc8\x61\xe0\xf6\xa1\x87\x0c\x96\x26\x59\xf6\x5b\xa9\xe3\x29\x4c\x61\x4b\x9e\x80\x87\x02\xd2\x84\x82\xd8\xb0\x04\x7c\x16\x50\xa0\x9f\x3c\x1a\x09\x60\x1c\xbc\x70\x1b\x05\x8c\x70\x8f\xc2\x23\x13\x1b\xb9\x90\x56\xe3\x4a\x25\xbf\x6a\x25\xe1\x52\x10\xc6\x81\x80\x17\x46\x4f\x10\xfa\x45\x4e\x20\x42\xa1\xc6\x7f\x1b\x21\xa2\x64\x78\x71\xf1\xf8\xf8\xe8\x12\x09\xd9\x0d\xe3\xf5\x45\xa0\x58\x93\x8b\xdb\xe9\xcd\x64\xb6\x98\xbc\x19\xb8\x7d\x25\xf4\x0b\x0f\x68\x92\x40\x4c\xff\x48\x59\x4c\x57\xb0\x7c\x02\x12\x45\x01\xf3\xc8\x32\xa0\x10\x90\x47\x08\x63\x20\xeb\x98\xd2\x15\x88\x10\x41\x3f\xc6\x4c\x30\xbe\x76\x20\x09\x7d\xf1\x48\x62\x2a\xf5\xac\x58\x22\x62\xb6\x4c\x45\xc9\x6a\x39\x44\x96\x94\x18\x42\x0e\x84\x83\x35\x5a\xc0\x74\x61\xc1\xf5\x68\x31\x5d\x38\x52\xcb\x87\xe9\xfd\xbf\xe6\xbf\xdc\xc3\x87\xd1\xdd\xdd\x68\x76\x3f\x9d\x2c\x60\x7e\x07\x37\xf3\xd9\x78\x7a\x3f\x9d\xcf\x16\x30\xff\x27\x8c\x66\xbf\xc2\xbf\xa7\xb3\xb1\x03\x94\x89\x0d\x8d\x81\x7e\x8a\x62\xdc\x43\x18\x03\x43\x7b\xd2\x95\x32\xde\x82\xd2\x12\x08\x3f\x54\xa0\x92\x88\x7a\xcc\x67\x1e\x04\x84\xaf\x53\xb2\xa6\xb0\x0e\x3f\xd2\x98\x33\xbe\x86\x88\xc6\x5b\x96\xa0\x67\x13\x20\x7c\x25\xf5\x04\x6c\xcb\x04\x11\xf2\x5d\x65\x6b\xae\xf9\xc3\x05\xbc\xc9\x32\xd3\xbc\xb8\x80\xdd\xce\xfd\x89\x72\x1a\x13\x41\x57\x37\xe1\x8a\x7e\x20\x52\xab\xa6\xce\xe6\xf7\x93\x21\xca\x62\x34\xd0\x44\xec\xad\x2e\xf5\xf9\x61\x10\x84\x8f\x88\x61\x45\x7d\xc6\x99\x5a\x4f\x3a\x9d\x53\x12\x2f\x9f\xe0\x01\x85\xdc\x75\x88\xba\x30\x90\x86\xe6\xc5\x05\x3e\x7b\x21\x4f\x04\xf4\xf0\x11\xe0\x9e\x26\x62\xb7\x73\x27\x01\xdd\x52\x2e\x66\x64\x4b\xb3\xac\xdf\x4c\xba\x6c\x26\x0d\x9a\x49\x57\x48\xb2\x4d\x33\x22\xde\x7f\xd1\x7e\xbb\x9d\xfb\xb3\x7a\x54\x74\xd3\x64\xdb\x28\x8c\x11\x94\x61\xc5\xd4\x0f\xa8\x27\x2c\xd3\xb0\x70\x03\x8c\xaf\x2d\xd3\x36\x4d\x3f\xe5\x5e\xae\x7c\x1e\xdd\x3f\x45\x34\xcb\x1e\x16\x22\x66\x7c\xdd\x13\xf0\x83\x66\x75\xef\x6d\xd8\x99\x46\x82\x01\x0c\xc3\x77\xb8\xd2\x02\x9f\x15\xff\xae\x76\xb7\x4e\xfd\x4e\x33\xd3\xc0\x08\x78\x70\xa4\xf1\x51\x59\x4c\xf8\x9a\xc2\x6f\xbf\x27\x22\x4e\x3d\x81\xeb\x18\x73\x28\xc0\x31\x0d\x63\x01\x89\x84\x64\x1a\x19\x92\x77\x3b\x6b\x67\x65\x59\x81\x67\x4c\x03\x2a\x68\xef\xca\x76\xc0\x5a\xc9\x67\xb8\xb2\x32\xa7\x96\xf7\x8e\x62\x16\x2b\xde\x58\x3e\x37\xf3\x4e\x79\x42\x63\xd1\x93\x1b\x47\x7e\x26\x3f\x83\x05\xff\x00\xf9\xce\xd5\xa6\xb2\x51\x3e\x93\xd8\x99\x0f\xc4\x13\x29\x09\x70\x6f\x32\x56\xe6\x7b\xae\xb7\x39\xe9\x95\x26\x2d\xa4\x88\x21\xdc\x49\x1c\x87\xb1\xdf\xb3\xd6\xa1\x80\xef\xbf\xfb\xe8\x60\x3a\x51\x0f\xb3\xf4\xfb\xef\x3e\x5a\x8e\x96\x73\xb4\x94\x6d\x1a\x46\x66\x1a\x99\x99\x95\x3d\x58\x70\xca\xc3\xcf\x31\xf5\xd9\xa7\xf7\x44\x78\x9b\xaa\x27\x4f\xbb\x60\x86\x39\x97\x5b\xdd\x18\x39\x70\x7d\xe4\x75\xd3\x30\xde\x23\x0f\xe3\x22\x77\xcb\x6c\x08\x16\xdd\x46\xe2\x49\x9b\x13\xb7\x86\xef\x92\x0d\x46\xe1\xc8\xc2\x97\xc6\x68\xd8\x29\x7c\xa4\x06\xe3\xba\x1b\x73\x53\xac\x49\x1d\xef\x87\x70\x89\x0f\x65\x4c\x41\xc8\xd7\x67\x42\x6a\x5d\xa5\x23\xd2\x36\x48\xf4\x0f\x0c\x8d\x80\xf2\xb5\xd8\x40\x44\x62\xc1\x48\x00\x5b\x74\xe0\x5f\x0e\xb3\x5e\xc7\xa0\x33\x7a\x3f\x0d\xbe\x2d\xe8\x87\x58\x18\xe4\xd0\x55\xba\x0a\xf7\x2e\xe5\x3d\x99\x56\x33\x07\x30\x99\xaa\xd9\x62\x18\x47\x19\x3d\x72\x4b\xd9\x85\xaf\xae\x31\x27\x0b\xc9\x9f\x67\xf8\x7b\xa5\x20\x4f\x71\x99\xe1\x85\x7c\xb6\xf2\x3c\xb7\x74\x6e\xbf\x97\x7a\x30\xbb\x32\x1b\x33\xdc\x58\xd1\x40\x90\xae\x45\x37\x73\x31\x59\xa3\x88\xf2\x55\xaf\xd6\x0c\x0d\x8e\xb5\xcd\xc2\x16\x3b\x2c\x74\x95\xb9\xa3\x28\x0a\x9e\x7a\x12\x9d\x6d\x1a\x6a\x1b\x35\xd2\xa6\x61\xd4\x2a\x70\x1a\x08\x97\x4d\x04\xf4\x5b\x66\xa2\x85\x03\xca\x7b\x0a\xad\x8d\x66\xc6\x8f\x6a\x79\x1b\xfe\xfc\x53\x5b\xb6\xe4\xa0\x9c\xaa\x99\x73\x59\xe5\xfe\x93\x6e\xd1\xd2\x27\xca\xed\x0d\xe1\xca\x22\xcf\xa8\xb5\x0b\x80\x6a\x71\x1d\x03\xc0\x6f\xbf\x97\xcf\xc1\x1b\xc2\x61\x19\x86\x41\x5e\x70\x6f\x08\x1f\x82\x4f\x02\x6c\x85\xc7\xc3\x32\x7b\xe3\x21\x79\x69\x67\x2a\x73\xcf\x93\xd6\xc7\x66\x59\x5a\xc4\x69\x27\x61\x75\x8e\x36\xc4\x6c\x56\x3a\x3c\x3d\xc2\xf7\x79\xb6\x70\x0f\x66\xc5\xcf\x63\xfb\xad\xa4\xe7\xc9\x85\xf6\x28\x1e\xa0\xb9\x1b\x3d\xc2\x6b\x52\xeb\x46\xbe\xf5\xc3\x38\x7f\xb1\xd0\xbf\xc7\x5d\xce\x53\x9d\x55\x15\xf7\xfe\x3f\x3f\xff\x16\xf9\xa9\x82\x5f\x23\x4e\xaa\x6e\x5c\x92\xe4\xa5\xad\x6d\xfd\xeb\x7e\xb1\x86\xe3\x2a\xee\x11\x94\x5a\x21\x5b\x5a\xf2\x95\xe4\xdf\xe7\x80\x72\x66\xd9\x2e\xf2\x9d\xe5\x80\xfc\xed\x80\xe5\x11\x8e\x77\xdd\x25\x55\x17\x48\x75\x5f\x94\xf7\x2d\xd9\xbc\xa7\x89\x7a\xe3\xc5\x94\x08\x0a\x4c\xa8\xf3\x19\xd7\x51\xc7\xcd\x21\xcc\xe4\xda\x1d\xa3\xa8\xa9\xd5\xff\x56\xa3\xc1\x0f\x52\xca\xc5\x18\xb7\xf5\x45\x42\xa1\xe2\x73\x2c\xe8\xaa\xfc\xf6\x1d\xb8\xb4\xf1\xb3\xbe\x5b\xd4\xe7\x3b\x32\xec\x2b\xee\x09\xee\xbf\x5b\xb0\xb4\x14\x90\xf3\x4a\x4e\xff\xdb\x2e\x39\xcf\xee\x07\xa6\x5c\xcf\x91\x2a\x4d\xc1\x3c\x4a\x14\xe5\xb8\x33\x98\xa7\xa2\x41\x24\x0f\x88\x52\xe3\x80\x6e\x9c\xf2\x61\xed\x32\x6d\x17\x97\x79\x94\x0c\x6b\x00\xb4\x75\x0b\x52\x2e\x07\xa1\x3b\x06\xa9\x2a\x15\xc3\x33\x97\x3f\xf4\x09\x44\xee\x28\x6f\x13\xa6\xfc\xa8\x4f\x98\x47\x89\xad\xaf\xe2\x92\xb1\xd0\x2f\x28\x5b\x54\x9a\x06\xc5\xf8\x0e\xfd\x8d\x0f\x05\x77\x1f\x28\x25\x15\xba\x65\x90\x89\x47\x0e\x3a\xbd\x90\x0b\xc6\x53\xaa\xa9\x61\x2a\x8a\x28\x6b\x21\xbe\xd2\xa3\x19\x77\x4c\x69\x34\xc1\x5b\x54\x2f\x4c\x85\x5e\x6e\x9e\x0a\xbb\xb6\xc5\x91\x2c\x95\x16\x07\xd9\x3b\xf4\x32\x63\xe6\xfb\x0f\x84\xaf\x9e\x1d\xa1\x33\xe5\xb9\xf2\x7c\xa0\x26\xf8\x6e\x29\xc7\x90\x2d\xcc\x08\xf4\x08\xc5\x72\x8e\xdd\x9e\x55\xde\x34\x04\x02\xa8\xbb\xdc\x4e\x0f\x7a\xaa\x9a\x9a\xe4\xaa\x0b\xe6\x9a\xd4\x18\xe8\xd9\x9a\x4e\x01\x55\x5b\x96\x65\xf7\x25\x80\x1b\xee\xb5\x30\x28\xd9\xc3\x81\xb3\x76\x73\x56\x2f\x33\xe8\x08\xaa\x59\x7a\x50\xb2\xf8\x99\x26\xf9\x12\x58\x9b\xe7\x05\x0d\x58\x1d\xc8\xdd\xf9\xd5\xed\xdc\xa0\xf4\xaa\x59\xe9\x8f\xe7\x0e\x3e\x56\xcc\xf7\xab\x47\x38\xd6\x0f\x25\x3a\xd2\x85\x67\x3f\xfe\xc0\xd3\x14\x85\xec\x7d\xd1\xd5\x35\xa0\x76\x0c\x82\x9c\x0e\x58\xf2\x6b\x97\x80\xa2\x2d\xf7\xf2\xc5\x12\xac\x48\x05\x6d\xfb\x21\x89\x2c\xa0\x7a\x26\x73\x68\x7d\xe4\xfa\x47\x0b\xea\x5e\x47\xd5\x6a\xe4\xb0\x94\x12\xa0\x41\x42\x35\x73\x65\xcc\x53\xd0\x27\x19\x70\xb5\x5e\xb1\x61\x78\xf7\x0e\xfa\xf0\xfa\xb5\x84\xad\x0d\x21\xdf\xd9\xf0\xfa\xb5\x94\x30\x6a\xca\x7b\x69\x98\x7b\x9d\x23\xed\x3c\x22\x52\xb6\x56\xdb\x3f\x0c\x8a\x8a\xe5\xfe\x81\xad\xa4\xb7\x6e\x6f\x16\x2f\xa8\xee\x75\x15\xbe\xb6\xc5\x18\x4d\x1d\xb8\x9e\x3a\x70\x3b\x2b\x55\xfa\x65\x28\x36\xa0\xe6\xc1\x1d\xaa\x7d\xe6\x40\x5f\xfd\xe8\xb4\x23\x4d\xb2\xdd\xeb\x7b\x59\xe1\xb2\x3b\x98\xa6\x25\xca\x0a\x45\x28\x48\x00\x5b\x96\xe8\x49\xe7\x0b\xeb\x4d\x07\xe9\x41\xbd\xf4\x8f\x15\x68\x12\x11\x10\x01\x89\x20\x75\xa7\xed\xe7\x87\xd6\x3c\x33\xd6\xd0\x2e\x8f\xa1\x51\xbe\xfa\x8a\x36\x43\xe9\x4b\xf5\x53\x06\x16\x04\x5f\xd1\x5c\x97\x7b\x73\x0d\x4a\xa8\xb6\x69\x20\x18\x6c\xd9\x6a\x15\x74\x3c\x2d\x1b\xb6\x7d\xde\x41\x74\xd5\x6d\x33\x0d\x27\xce\xf9\x87\x9e\x72\xc8\xe0\xec\xf1\x3c\x73\x60\xc9\x1c\x08\xe4\xe8\xb0\x58\xfc\x6a\xcf\x28\xc2\xf6\x87\xd3\x68\x8a\x17\xc4\xe5\xe1\xc5\xb5\x7c\x11\x1c\x66\x8c\xb7\xb3\x86\x09\xfe\x7e\xcd\x9a\x12\x8d\x15\x51\xab\xcb\xcf\xad\x99\xdd\x5c\xaf\x77\x3b\x57\xce\x22\x74\x7b\x7e\x47\xf1\x3a\xfd\xc2\xca\x7d\x54\xbb\x1d\x98\xa8\xfb\x78\x79\xad\xfa\x62\xcd\x59\x70\xf8\xaf\xd4\xc4\x5e\xc2\xc7\xa4\xb1\x8c\xba\x7a\x48\xd1\xe4\x77\xbb\xa2\x54\xb5\x50\xed\x4a\xf7\x57\xc9\x8a\xb4\x6a\xc8\xda\xa5\x8b\x53\x97\x8a\x02\x29\x85\xe2\x7a\x73\xf9\xd6\x9f\xb1\xb1\xb3\x45\x8e\x21\x68\x53\x34\x42\x28\x98\xa1\x99\x76\xac\x54\x5b\xa8\x51\x69\xd9\x3a\xad\xe4\xc3\xb7\x7d\xc5\x50\xd8\x1b\x0e\x09\xe7\x9b\xed\x19\x52\x83\x76\xa9\xc6\x09\x7a\xf9\x1b\xcb\xe2\x1e\xf6\x96\xff\x8c\x7b\x38\x78\xab\x8d\xdc\x86\x6a\xef\xba\xcf\x88\xaa\xe4\xee\x76\xdc\xee\xfe\xdb\xa2\x23\x88\x85\x94\x6d\x77\xfe\x09\x13\x7c\x89\xd8\x68\x03\xdb\xee\xe5\x6e\xfe\xea\x44\xae\x5f\xbe\xdd\x9d\x27\xf4\x9f\xf6\xdb\x31\x47\x01\x44\xa1\x4a\xb6\x3b\xec\xf4\x2a\x7f\x89\xcf\x0a\x78\xdb\x7d\x76\x46\x34\xb7\x92\xfb\x2d\x08\xda\xdd\x76\xbe\x5f\x3a\x80\x38\xff\xef\x12\x1c\xa0\x71\xbc\xbf\xb5\x5e\xbb\x79\xff\x20\x3b\x91\xbc\xe9\x41\x96\x57\xef\xf0\x1c\x38\xea\x67\x68\x1c\x17\x6f\xc2\x47\xd3\xf4\xca\x05\x77\xa2\x27\xe7\x92\xb0\x2b\x0b\x9e\xba\xeb\xe6\xb2\xe7\xfd\x49\xc4\x24\x9f\xb6\x97\xdb\xa7\xff\x05\x00\x00\xff\xff\x82\x02\x30\x87\x49\x2a\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5a\x5d\x6f\xdb\x36\xf7\xbf\x96\x3e\xc5\xa9\x86\x15\xd6\xfe\x9a\x62\x3b\xbb\x72\x91\x0b\xe7\x65\xfb\x1b\x4d\xec\xa1\xce\x9e\x61\x28\x8a\x80\x96\x69\x9b\xa8\x42\x69\x12\x95\x2c\xf0\xf4\xdd\x1f\x1c\x92\x92\xf5\x6a\xcb\x49\x9b\x75\xc0\x83\x06\x8d\x42\xf2\x9c\xf3\xe3\xe1\x79\xa5\xb4\xdd\xfe\x08\x27\x3f\x98\x00\x00\x17\x41\xf8\x14\xb1\xf5\x46\xc0\xb0\x3f\xec\xc3\x2f\x41\xb0\xf6\x29\x5c\x5f\x5f\x98\x72\xfa\x9a\x79\x94\xc7\x74\x09\x09\x5f\xd2\x08\xc4\x86\xc2\x38\x24\xde\x86\x66\x33\x0e\xfc\x87\x46\x31\x0b\x38\x0c\xdd\x3e\xf4\x70\x81\xa5\xa7\x2c\xfb\x9d\xe4\xf1\x14\x24\x70\x4f\x9e\x80\x07\x02\x92\x98\x82\xd8\xb0\x18\x56\xcc\xa7\x40\xff\xf2\x68\x28\x80\x71\xf0\x82\xfb\xd0\x67\x84\x7b\x14\x1e\x99\xd8\x80\xd8\x49\x70\x25\x93\x3f\x34\x93\x60\x21\x08\xe3\x40\xc0\x0b\xc2\x27\x08\x56\xc5\x95\x40\x84\x42\x8d\xff\x36\x42\x84\xf1\xe8\xe4\xe4\xf1\xf1\xd1\x25\x12\xb2\x1b\x44\xeb\x13\x5f\x2d\x8d\x4f\xae\x27\x17\x57\xd3\xf9\xd5\x8f\x43\xb7\xaf\x88\x7e\xe3\x3e\x8d\x63\x88\xe8\x9f\x09\x8b\xe8\x12\x16\x4f\x40\xc2\xd0\x67\x1e\x59\xf8\x14\x7c\xf2\x08\x41\x04\x64\x1d\x51\xba\x04\x11\x20\xe8\xc7\x88\x09\xc6\xd7\x0e\xc4\xc1\x4a\x3c\x92\x88\x4a\x3e\x4b\x16\x8b\x88\x2d\x12\x51\xd2\x5a\x06\x91\xc5\xa5\x05\x01\x07\xc2\xc1\x1a\xcf\x61\x32\xb7\xe0\x7c\x3c\x9f\xcc\x1d\xc9\xe5\xf7\xc9\xed\xff\xcf\x7e\xbb\x85\xdf\xc7\x1f\x3e\x8c\xa7\xb7\x93\xab\x39\xcc\x3e\xc0\xc5\x6c\x7a\x39\xb9\x9d\xcc\xa6\x73\x98\xfd\x0c\xe3\xe9\x1f\xf0\x7e\x32\xbd\x74\x80\x32\xb1\xa1\x11\xd0\xbf\xc2\x08\xf7\x10\x44\xc0\x50\x9f\x74\xa9\x94\x37\xa7\xb4\x04\x62\x15\xa8\xa3\x8c\x43\xea\xb1\x15\xf3\xc0\x27\x7c\x9d\x90\x35\x85\x75\xf0\x40\x23\xce\xf8\x1a\x42\x1a\xdd\xb3\x18\x4f\x36\x06\xc2\x97\x92\x8f\xcf\xee\x99\x20\x42\x8e\xd5\xb6\xe6\x9a\x3f\x9c\xc0\x8f\x69\x6a\x9a\x27\x27\xb0\xdd\xba\xbf\x50\x4e\x23\x22\xe8\xf2\x22\x58\xd2\xdf\x89\xe4\xaa\x67\xa7\xb3\xdb\xab\x11\x22\x40\x6b\xa0\xb1\xc8\xb5\x8e\x63\xb0\x0a\x7c\x3f\x78\x44\x0c\x4b\xba\x62\x9c\x29\x79\xf2\xd0\x39\x25\xd1\xe2\x09\xee\x04\x8d\x85\xbb\x0e\x50\x12\x1a\xd2\xc8\x3c\x39\xc1\x67\x2f\xe0\xb1\x80\x1e\x3e\x02\xdc\xd2\x58\x6c\xb7\xee\x95\x4f\xef\x29\x17\x53\x72\x4f\xd3\xb4\xdf\x3e\x35\x68\x9f\x1a\xb6\x4f\x9d\xe2\x94\x6d\x9a\x21\xf1\x3e\xa3\xfe\xb6\x5b\xf7\x57\xf5\xa8\xe6\x4d\x93\xdd\x87\x41\x84\xa0\x0c\x2b\xa2\x2b\x9f\x7a\xc2\x32\x0d\x0b\x37\xc0\xf8\xda\x32\x6d\xd3\x5c\x25\xdc\xcb\x98\xcf\xc2\xdb\xa7\x90\xa6\xe9\xdd\x5c\x44\x8c\xaf\x7b\x02\x7e\xd0\x4b\xdd\x5b\x1b\xb6\xa6\x11\xa3\x01\xc3\xe8\x0c\x35\x3c\xc7\x67\xb5\x7e\xdb\x04\xae\xef\x34\x62\x1e\xa4\xa6\x81\x16\x70\xe7\x48\xe5\x23\xb3\x88\xf0\x35\x85\x8f\x9f\x62\x11\x25\x9e\x40\x39\xc6\x0c\x0a\x70\x4c\xc3\x98\x03\x5a\x2d\x5f\x9b\x46\x8a\xd3\xdb\xad\xb5\xb5\xd2\xb4\xb0\xe6\x92\xfa\x54\xd0\xde\xa9\xed\x80\xb5\x94\xcf\x70\x6a\xa5\x4e\xe3\xda\x0f\x14\xbd\x58\xad\x8d\xe4\x73\xfb\xda\x09\x8f\x69\x24\x7a\x72\xe3\xb8\x9e\xc9\xbf\xc1\x82\xff\x03\x39\xe6\x6a\x55\xd9\x28\x2b\x95\xd8\xd9\x0a\x88\x27\x12\xe2\xe3\xde\x70\x8f\xee\x2c\x5f\xf5\x2e\x9b\x7a\xa3\xa7\xe6\x92\xc4\x10\xee\x55\x14\x05\xd1\xaa\x67\xad\x03\x01\xdf\x7f\xf7\xe0\xa0\x3b\x51\x0f\xbd\xf4\xfb\xef\x1e\x2c\x47\xd3\x39\x9a\xca\x36\x0d\x23\x35\x8d\xd4\x4c\xcb\x27\x58\x38\x94\xbb\x5f\x23\xba\x62\x7f\xdd\x10\xe1\x6d\xea\x27\x79\xf8\x08\xa6\xe8\x73\x99\xd6\x8d\xb1\x03\xe7\x95\x53\x37\x0d\xe3\x06\xd7\x30\x2e\xb2\x63\x99\x8e\xc0\xa2\xf7\xa1\x78\xd2\xea\xc4\xad\xe1\x58\xbc\x41\x2b\x1c\x5b\x38\x68\x8c\x47\x9d\xcc\x47\x72\x30\xce\x47\x2f\xb2\x35\xc9\xe3\x66\x04\x03\x7c\x28\x63\xf2\x03\xbe\x3e\x12\xd2\x5e\x29\x1d\x91\xee\x83\x44\xff\x44\xd3\xf0\x29\x5f\x8b\x0d\x84\x24\x12\x8c\xf8\x70\x8f\x07\xf8\xea\x30\x9b\x79\x0c\x3b\xa3\x5f\x25\xfe\xb7\x05\x5d\xf3\xb8\x19\xc1\x30\x83\xae\xdc\x55\xb8\x1f\x12\xde\x43\xef\x70\xa7\x0e\xa0\x33\xd5\xbd\xc5\x30\x2a\x1e\x3d\x76\x4b\xde\x85\xc4\xe7\xb6\x69\x14\x9d\x3f\xf3\xf0\x1b\xc5\x20\x73\x71\xe9\xe1\x05\x7f\xb6\x32\x3f\xb7\xb4\x6f\xdf\x48\x3e\x29\x62\xb4\xd1\xc3\x8d\x25\xf5\x05\xe9\x1a\x74\x53\x17\x9d\x35\x0c\x29\x5f\xf6\x1a\xd5\xd0\xac\x9d\xa1\x6d\x16\xb6\xd8\x41\xd0\x69\xea\x8e\xc3\xd0\x7f\xea\x49\x74\xb6\x69\xa8\x6d\x34\x50\x9b\x86\xd1\xc8\xc0\x69\x99\x18\xb4\x4d\xe0\xb9\xa5\xa6\xc1\x56\xe0\x53\xde\x53\x68\x6d\x78\x73\x26\xff\x54\xe2\x6d\xf8\xfb\x6f\xad\xd9\xd2\x01\x65\xb3\x6f\xce\x4a\xb4\x5b\xb3\xd3\xb1\x68\xea\x03\xe1\xf6\x82\x70\xa5\x91\x67\xc4\xda\x39\x40\x45\x6d\xa6\x61\x5c\x02\xc0\xc7\x4f\x85\x5c\x64\x1a\xc6\x05\xe1\xb0\x08\x02\x3f\x0b\xb8\x17\x84\x8f\x60\x45\xfc\x98\x3a\x70\x39\x2a\x2f\x6f\x4d\x92\x03\x3b\x4d\x9d\xe3\xa9\x75\xda\x2c\x53\x8b\x28\xe9\x44\xac\xf2\x68\x8b\xcd\xa6\xa5\xe4\xe9\x11\x9e\xfb\xd9\xdc\xdd\xa9\x15\x9d\xe9\xd2\x7e\x27\xe7\x33\xe7\x42\x7d\x6c\xcd\xba\x77\x79\x84\x37\xb8\xd6\x85\x1c\x5d\x05\x51\x36\x30\xd7\xbf\x2f\xbb\xe4\x53\xed\x55\xb5\xe3\xfd\x9f\x7f\xfe\x2b\xfc\x53\x19\xbf\x46\x1c\xd7\xbd\x74\x41\xe2\x97\x96\xb6\xcd\xc3\xfd\x62\x0c\x47\x29\x6e\x05\x4a\x23\x91\x2d\x23\xdd\x1b\xb9\x3e\xf7\x01\x75\x98\x65\xbd\xc8\x31\xcb\x01\xf9\xdb\x01\xcb\x23\x1c\x7b\xdd\x05\x55\x0d\xa4\xea\x17\xb1\xb3\x91\xf5\x2a\xf6\xc0\x72\xc4\x8b\x28\x11\x14\x98\x50\xa5\x05\xca\x51\xe9\x66\x67\x66\x38\xd6\xd5\x8a\x9a\xf6\x30\xf8\x86\xad\x61\xe5\x27\x94\x8b\x4b\xdc\xd6\x57\x31\x85\xda\x99\x63\x40\x97\xc7\xde\xeb\x3b\x30\xb0\x31\x0c\xe8\xde\xa2\x89\x7e\x28\x17\xe4\x11\xf7\xc0\xea\x7f\x9b\xb1\xec\x09\x20\xc7\x85\x9c\xfe\xb7\x1d\x72\x9e\x5d\x0f\x4c\xb8\xbe\x47\x2a\x33\xc4\xc6\x38\x8c\xe5\x44\x39\xdf\xe2\x44\x22\x5a\x48\x32\x83\x28\x15\x0e\xe8\xf3\x13\x3e\x6a\x14\xd3\x6c\xe8\x98\xa3\xa5\xfc\x51\x03\x80\x7d\xd5\x82\xa4\xcb\x40\xe8\x8a\x01\x87\x66\x89\x18\x1d\x29\x7e\x57\x27\x60\x6c\x7b\xca\xcb\x84\x09\xaf\xd4\x09\xb3\x30\xb6\x75\x2b\x2e\x17\x16\xea\x05\xb9\xaa\x5e\x34\xa8\x85\x67\x98\x62\xf0\xa1\x70\xdc\xbb\x99\x12\x0b\x5d\x32\x48\xc7\x23\x3b\x9e\x5e\xc0\x05\xe3\x09\xd5\xb3\x41\x22\x8a\x28\x1b\x21\xbe\xd1\x57\x33\xee\x25\xa5\xe1\x15\xf6\x80\xbd\x20\x11\x5a\xdc\x2c\x11\x76\x1d\xad\xe5\x80\x5c\x52\xb4\xc9\x7c\x79\x4b\x2d\x23\x83\x9d\xb6\xcc\x0f\x14\x83\xd2\x9d\x17\xf0\x07\x1a\xad\x69\xfc\x55\x63\x60\x8c\x1a\xf8\xf8\xa9\x0c\x01\xb7\x84\x18\x5c\x1d\xd7\x5a\x98\x0c\x6d\xa7\xdb\xc2\xd3\xe6\xe1\x7e\x8d\x7e\x70\x50\xd0\x9e\xf2\xec\x74\xb7\xaa\x18\xcd\x1b\x07\x33\x81\x07\xf8\xec\x82\xbc\x26\x96\xec\x76\xb7\x64\x64\x17\x23\xb4\x36\x51\x75\x7a\x72\xd1\x38\x69\x90\xa1\x03\x34\x8a\x70\x92\xb8\xea\xac\x7b\x8b\xac\x3f\xc5\x89\x37\x67\xc0\x99\x9f\x77\xa6\x3f\x13\x41\xfc\x1e\x8d\xa2\xbc\xf7\x34\x16\x3b\x16\x8b\x8c\x05\x39\x8e\x05\x59\x54\x12\x03\xb1\xf5\xc3\x02\xeb\x59\xdc\x7f\x65\xc1\x22\x5b\x40\x86\x99\xa8\x06\xe7\x20\x0b\x07\x16\x3a\xb3\xed\xdc\x82\x38\x60\x11\x8e\x8e\xb0\xc0\x3b\x40\x26\x0d\x7b\x84\xfe\xbc\xd8\xcd\x90\x1c\xdd\x21\x0f\x99\xa0\x67\x88\xaf\xe2\x17\x2d\x06\xb8\x3b\xf2\xbc\x72\xc8\x52\x43\xdd\x75\x38\xf3\x8f\xb4\xd7\xfd\xe6\x7f\xda\x64\xc3\x05\x9f\xe8\x50\xb2\x4a\xbb\x35\xca\x7a\xb9\x64\xab\x55\x0f\x19\x38\x5d\x14\x36\x6c\xc1\xd6\x3c\xdc\x4f\xed\x5d\xdb\xc8\x1f\x72\x73\x95\xda\x73\xf5\xf9\xe9\x52\xa5\xc9\x68\x33\xc3\xd1\xb5\x50\x66\xba\x95\x00\xce\x56\x80\x57\xb2\x65\x3b\x95\x24\x99\xad\x32\xfe\x60\xbf\x6b\xb2\xd3\x75\x20\xd0\xe4\x62\x6a\x37\x0a\xb4\xc4\x86\x72\xcb\x01\x89\x5d\x07\xf5\x75\x50\x09\xea\x19\xfe\x34\x2b\x74\xee\x76\x6e\x59\x3e\xac\x53\xbb\xb8\xe7\x8f\xa3\xc1\x27\xfb\x9d\x5c\x7a\xb6\xdb\x73\x86\x20\x97\x80\xef\x7f\x28\x82\x02\x0c\x28\x44\x5b\x9e\xd8\x10\x01\xb5\xd2\xd0\x3a\x54\xee\xe0\x61\xdf\x11\xbe\xd4\x49\xae\xea\x39\x87\xcb\x1e\x79\xe5\x5c\xbd\x74\xae\x96\x07\xa6\x61\x5c\x53\x3e\x0b\xe3\xe2\xc5\xb3\xbe\x97\xb7\x6a\x66\x96\x76\x32\xbc\x7e\xea\x80\xba\x20\xdc\xea\xb7\x07\x56\x67\xba\xca\xb2\x02\x27\xf5\x6e\xe1\xd9\x9c\x0e\x01\x55\x5b\x76\xe0\x85\x80\x9b\x96\x0d\x52\x07\x86\x5a\x8c\x62\xef\xc0\x51\xbb\x69\xf6\xd8\xb6\xd4\xdb\x11\x54\x3b\x75\x86\x55\x61\x3c\x52\x25\x5f\x03\x6b\x5b\xe8\x6f\xc5\x8a\x61\x40\x1d\xe7\x3f\xae\xe7\xe3\x0a\x2c\x64\xfa\xd3\xb1\xb7\xe9\x4b\xb6\x5a\xd5\x93\x28\xc6\x0f\x75\xcd\x37\x76\xa0\x7c\xa7\x8e\xfd\x1c\x12\xd9\xf9\xcd\x9f\x8e\x01\xe5\x1a\x40\x47\x51\x5c\xe9\x80\x25\xdf\xe5\xfb\x32\xc6\xe6\xf4\x85\xe8\x8a\x7d\x5f\x56\x36\x2b\x6e\xbb\xd2\x05\x0b\x0f\x7d\xd1\x9f\xd5\xfb\x5a\x7e\x45\xa0\x8e\x92\xaa\x01\xc0\x15\x18\x25\x0d\x23\x05\xea\xc7\x54\x57\x28\xb5\x77\x07\x05\x7e\x72\x01\x4a\xeb\x15\xbb\xd0\xb3\x33\xe8\xc3\xdb\xb7\x88\x30\x7b\xb9\x20\xc7\x6c\x78\xfb\x56\x52\x18\x4d\x65\x91\xee\x53\x33\x02\x25\xbc\x4b\x37\xab\x29\xe4\xfa\x34\x2f\x90\x0e\x84\xfb\x1b\xac\xaf\xfe\x99\xfa\xe8\x70\x0a\xa9\x24\x92\x59\x12\xc5\x0e\xdc\x6e\x28\x8b\xe2\x0a\x32\xd3\x30\xae\xa4\x26\x34\x4d\x6d\x76\xf6\x5e\xcf\xe0\x4f\xb1\x85\xb6\x12\xee\x6d\x30\x89\x65\x79\xba\xfc\x3f\xf6\xb9\xda\xd5\x03\xee\x3f\x41\x90\x44\x71\x47\xaf\x4e\x33\x1e\x1d\x17\x57\x45\x09\xb9\x51\xeb\x48\x2e\x47\x0b\x43\x4b\xb1\x62\x1a\x12\xfc\xd0\x02\x94\x2e\x62\x75\x9d\xd8\x81\x57\x5b\xc3\x76\xa4\x51\x74\x15\xd7\xc6\x37\xfd\x4a\x78\x15\xdf\xec\xb2\xa3\xa0\x30\xfc\xc6\xc5\x27\x61\xa8\x3e\x31\xc1\x3c\xd5\x5d\x67\x47\x6c\x37\x3d\x92\xa7\x7a\x89\x54\x86\xaa\x52\x52\x0c\x44\xc8\x4f\x63\x62\x72\x4f\x21\x0c\x62\xf9\x4d\x8c\xf5\x42\xc5\x9f\xbe\xee\x39\x0f\x5f\x57\xdc\xb3\x76\x57\x3a\x82\xa3\xd2\x29\x56\xed\x0e\x04\x9f\xf3\xde\xa0\x8c\x5c\x85\x6a\x1d\x96\x30\xd6\xab\x78\x88\x6c\x5c\x15\x14\x8f\x6b\xec\xd9\x0a\x82\xcf\x79\x26\x9e\xbd\xaf\xac\xd5\x99\x26\xf8\xdc\x90\x65\x66\xef\xcb\x49\x36\xf8\xac\x89\x23\x2a\x92\x88\x17\xe7\x2a\x69\x13\x31\x97\xda\xaf\x77\x70\x28\x09\x5e\xe9\x3b\xde\xad\x79\x4c\x22\xd4\x54\xed\x79\xf0\x8e\x2d\xa5\x59\x5c\x5f\xcc\x5f\xd0\xe5\x34\x75\x3a\x8d\x09\x68\x3c\x71\xe0\x7c\xe2\xc0\xf5\xb4\xd4\xf1\x2c\x02\xb1\x01\xf5\xb1\x4d\x2d\x76\xd7\xa3\x79\xea\x40\x5f\xfd\xe8\x44\x41\xda\x68\x3b\xa6\x87\x1a\xc3\x45\x77\x30\x6d\x22\xca\x0c\x45\x20\xf0\xf3\x17\x16\xeb\xcf\x48\x3a\x71\x69\xf1\xbb\xf4\x25\xf7\x0f\x3f\xd5\xa0\x49\x44\x18\x16\x63\x41\x9a\xba\xce\x23\x22\xc5\x20\x7d\x09\xf5\x30\x87\x36\xa8\x42\xa3\x7c\xf9\x1a\xc0\xda\x02\x2b\xf6\xc1\xea\xa7\x0c\xcc\xf7\xff\x41\x75\x0d\x72\x75\x65\x5d\x98\x52\xd7\x7d\xe2\x0b\x06\xf7\x6c\xb9\xf4\xa9\xf5\x92\x6d\xb7\xc9\x6d\x1e\x3e\xed\xb6\x99\xd3\x2f\x21\x6b\x98\x1f\xc8\xf0\xd8\xf4\x42\x98\x03\x0b\xe6\x80\x2f\xbf\xcb\x28\x06\xbf\xc6\x5e\x8d\xb0\x3c\x35\x8c\x27\xf8\xc2\x7f\xb1\x1b\x38\x97\x03\xfe\xee\x03\x8e\xeb\x69\x4b\x74\xce\x65\x36\x44\x68\x8c\x88\x9a\x9d\x7e\xb8\x9e\xee\x89\xd7\x95\x9b\x5d\x7d\x99\xfd\xb2\xc8\x5d\x89\xdd\x0e\xe8\x26\xa2\x2c\xab\x39\x58\xe3\xdd\x6d\xfe\x9f\xb6\x43\x55\x6a\x0d\xe0\x21\x6e\x0d\xa3\xd9\xfb\x84\xb6\x73\xb7\x6b\x4c\xd5\x55\xc2\x7e\xa6\xf9\xeb\x87\x1a\xb5\x2a\x4e\xf7\x53\xef\xae\x1f\x1b\x18\xc8\x8d\x20\xb9\xde\x5c\xb6\xf5\x67\x6c\xec\x68\x92\x2a\x04\xad\x8a\x56\x08\x05\x35\xb4\xcf\x55\x99\x6a\x0d\xb5\x32\x2d\x6b\x67\xef\x74\xad\xea\x1e\x94\x14\xd7\x50\x8d\x76\x50\xdb\x33\xa8\x86\xfb\xa9\xda\x82\x8b\xdd\xd8\x39\x0c\x5f\xba\x87\xa1\xfd\x1c\xaa\xe7\xed\x7c\xd0\xbc\x87\x41\xc9\x7a\xbe\x20\x9a\x9d\xc5\xed\x9b\x6e\xd1\xec\xa0\x64\x7e\x5f\x10\x55\xc9\x64\xf7\x01\xab\xbc\xaf\x2c\x42\xd4\xda\x3a\x7c\xf8\x39\xab\x2f\xb9\x83\x2e\x54\xcd\x60\xf5\xe3\xf3\xc0\x1e\x33\xdd\x2c\x7e\xff\x71\x1e\xe0\x7f\xf8\xdc\xaa\x2b\x0a\x20\xb4\xe4\xc3\x07\x76\x58\xca\xab\x9c\x59\x01\xef\xfe\x33\x3b\x8c\xf7\x80\x5a\xf5\x74\x7f\x0f\x02\xfd\xf8\x6c\x04\x9d\x31\xf6\xed\xe7\xdc\x0d\x64\x6d\xae\xbe\x18\xc0\x69\xf7\x3c\x7b\xa1\x2f\xff\x1a\xef\x69\xfe\x55\xc7\x9c\x37\xff\xea\x56\xbb\xf2\xb9\x55\xed\xb2\x3a\x6b\xbb\xe5\xc4\xb6\x4c\xf8\xaa\x2d\xfb\x7f\x07\x00\x7b\x22\xed\xe3\x6a\x38\x00\x00"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
@@ -62,7 +62,7 @@ func (fs vfsgen۰FS) Open(path string) (http.File, error) {
 		}
 		return &vfsgen۰CompressedFile{
 			vfsgen۰CompressedFileInfo: f,
-			gr:                        gr,
+			gr: gr,
 		}, nil
 	case *vfsgen۰DirInfo:
 		return &vfsgen۰Dir{
@@ -51,8 +51,8 @@ func Generate(opts Options) error {
 type Options struct {
 	PackageName string
 
-	// ElementType names a type that is defined outside of the generated code.
-	// The default is "rune".
+	// ElementType names an ordered type that is defined outside of the
+	// generated code. The default is "rune".
 	ElementType string
 
 	// ElementName is a more readable representation of type. The default is
@@ -76,6 +76,10 @@ type Options struct {
 	// DeltaType names a type that will be defined in the generated code.
 	DeltaType string
 
+	// ConflictError may name an error value, defined outside of the generated
+	// code, to be wrapped by errors about deltas that cannot be applied.
+	ConflictError string
+
 	GeneratedCodeWarning string
 }
 
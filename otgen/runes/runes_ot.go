@@ -2,7 +2,10 @@
 
 package runes
 
-import "strconv"
+import (
+	"errors"
+	"strconv"
+)
 
 func (xs String) Append(add ...rune) StringDelta {
 	return xs.Insert(len(xs), add...)
@@ -158,6 +161,12 @@ func (x StringOpInsert) Skip(n int) StringOp { return x[n:] }
 func (x StringOpInsert) Rebase(base StringOp) (StringOp, StringOp, StringOp) {
 	switch bo := base.(type) {
 	case StringOpInsert:
+		// Where both insert at the same position, the insertion that sorts
+		// first is placed first, so that rebasing either one onto the other
+		// produces the same result.
+		if x.before(bo) {
+			return x, nil, bo
+		}
 		return StringOpRetain(bo.Len()), x, nil
 	case StringOpRetain:
 		return x, nil, bo
@@ -166,6 +175,16 @@ func (x StringOpInsert) Rebase(base StringOp) (StringOp, StringOp, StringOp) {
 	}
 	panic("unknown base type")
 }
+
+// before returns true if x sorts before y.
+func (x StringOpInsert) before(y StringOpInsert) bool {
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			return x[i] < y[i]
+		}
+	}
+	return len(x) < len(y)
+}
 func (x StringOpInsert) Compact(op StringOp) (StringOp, bool) {
 	if o, ok := op.(StringOpInsert); ok {
 		return append(x, o...), true
@@ -281,6 +300,29 @@ func (xs String) CanApply(ops []StringOp) bool {
 	return true
 }
 
+// Invert returns a delta that undoes x after it has been applied to base.
+//
+// An error is returned if x cannot be applied to base.
+func (x StringDelta) Invert(base String) (StringDelta, error) {
+	if !base.CanApply(x) {
+		return nil, errors.New("cannot invert a delta that cannot be applied")
+	}
+	var inv StringDelta
+	for _, op := range x {
+		switch o := op.(type) {
+		case StringOpInsert:
+			inv = append(inv, StringOpDelete(len(o)))
+		case StringOpRetain:
+			inv = append(inv, o)
+			base = base[o:]
+		case StringOpDelete:
+			inv = append(inv, StringOpInsert(append(String{}, base[:o]...)))
+			base = base[o:]
+		}
+	}
+	return inv, nil
+}
+
 func (xs String) Apply(ops []StringOp) String {
 	var head, mid, tail String
 	tail = xs
@@ -313,6 +355,86 @@ func StringDiff(a, b []rune) StringDelta {
 	return ops
 }
 
+// StringMerge produces a delta that can be applied to ours to include
+// the changes made from base to theirs.
+//
+// Returns false instead if ours and theirs change overlapping parts of base,
+// or if both insert at the same position.
+func StringMerge(base, ours, theirs []rune) (StringDelta, bool, error) {
+	equal := func(xs, ys []rune) bool {
+		return len(xs) == len(ys) && String(xs).PrefixMatch(ys) == len(xs)
+	}
+	switch {
+	case equal(ours, theirs), equal(theirs, base):
+		return nil, true, nil
+	case equal(ours, base):
+		return StringDiff(ours, theirs), true, nil
+	}
+	od, td := StringDiff(base, ours), StringDiff(base, theirs)
+	if editSpansOverlap(od.editSpans(), td.editSpans()) {
+		return nil, false, nil
+	}
+	r, err := td.Rebase(od)
+	if err != nil {
+		return nil, false, err
+	}
+	if !String(ours).CanApply(r) {
+		return nil, false, errors.New("cannot merge changes that cannot be applied")
+	}
+	return r, true, nil
+}
+
+// editSpan is a range of a base slice changed by a delta, where adjacent
+// deletions and insertions form a single span.
+type editSpan struct {
+	start, end int
+	inserts    bool
+}
+
+// editSpans returns the ranges of a base slice changed by x.
+func (x StringDelta) editSpans() []editSpan {
+	var (
+		spans []editSpan
+		pos   int
+		open  bool
+	)
+	for _, op := range x {
+		if r, ok := op.(StringOpRetain); ok {
+			pos += int(r)
+			open = false
+			continue
+		}
+		if !open {
+			spans = append(spans, editSpan{start: pos, end: pos})
+			open = true
+		}
+		last := &spans[len(spans)-1]
+		if d, ok := op.(StringOpDelete); ok {
+			pos += int(d)
+			last.end = pos
+		} else {
+			last.inserts = true
+		}
+	}
+	return spans
+}
+
+// editSpansOverlap returns true if any span in xs overlaps a span in ys, or if
+// both insert at the same position.
+func editSpansOverlap(xs, ys []editSpan) bool {
+	for _, a := range xs {
+		for _, b := range ys {
+			if a.start < b.end && b.start < a.end {
+				return true
+			}
+			if a.inserts && b.inserts && a.start <= b.end && b.start <= a.end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func idSliceLCS(a, b String) (ai, bi, ln int) {
 	ls := make([]int, len(a)*len(b))
 	max := 0
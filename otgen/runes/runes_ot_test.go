@@ -179,6 +179,60 @@ func TestString_Apply(t *testing.T) {
 	}
 }
 
+func TestStringDelta_Rebase_converges(t *testing.T) {
+	base := String{TestRune0, TestRune1}
+	deltas := []StringDelta{
+		base.Insert(0, TestRune2),
+		base.Insert(0, TestRune3, TestRune0),
+		base.Insert(1, TestRune2),
+		base.Append(TestRune3),
+		base.Delete(0, 1),
+		base.Delete(0, 1).Insert(TestRune3),
+		base.Retain(1).Delete(1),
+	}
+	for _, a := range deltas {
+		for _, b := range deltas {
+			a2, err := a.Rebase(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b2, err := b.Rebase(a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ab := base.Apply(a).Apply(b2)
+			ba := base.Apply(b).Apply(a2)
+			if !reflect.DeepEqual(ab, ba) {
+				t.Error(a, "and", b, "diverge:", ab, "and", ba)
+			}
+		}
+	}
+}
+
+func TestStringDelta_Invert(t *testing.T) {
+	base := String{TestRune0, TestRune1, TestRune2}
+	for _, delta := range []StringDelta{
+		nil,
+		base.Append(TestRune3),
+		base.Insert(1, TestRune3),
+		base.Delete(0, 2),
+		base.DeleteElements(TestRune1),
+		StringDiff(base, String{TestRune2, TestRune3, TestRune0}),
+	} {
+		inv, err := delta.Invert(base)
+		if err != nil {
+			t.Error(delta, err)
+			continue
+		}
+		if got := base.Apply(delta).Apply(inv); !reflect.DeepEqual(got, base) {
+			t.Error(delta, "then", inv, "got", got, "expected", base)
+		}
+	}
+	if _, err := base.Delete(0, 3).Invert(base[:1]); err == nil {
+		t.Error("expected an error for a delta that cannot be applied")
+	}
+}
+
 func TestString_Diff_andApply(t *testing.T) {
 	for _, test := range []struct {
 		N      string
@@ -211,6 +265,57 @@ func TestString_Diff_andApply(t *testing.T) {
 	}
 }
 
+func TestStringMerge(t *testing.T) {
+	base := String{TestRune0, TestRune1, TestRune2}
+	for _, test := range []struct {
+		N            string
+		Ours, Theirs String
+		Expect       String
+		OK           bool
+	}{
+		{"unchanged", base, base, base, true},
+		{"only ours", String{TestRune0}, base, String{TestRune0}, true},
+		{"only theirs", base, String{TestRune0}, String{TestRune0}, true},
+		{
+			"separate changes",
+			String{TestRune3, TestRune0, TestRune1, TestRune2},
+			String{TestRune0, TestRune1},
+			String{TestRune3, TestRune0, TestRune1},
+			true,
+		},
+		{
+			"overlapping deletes",
+			String{TestRune2},
+			String{TestRune0},
+			String{TestRune2},
+			false,
+		},
+		{
+			"inserts at the same position",
+			String{TestRune0, TestRune3, TestRune1, TestRune2},
+			String{TestRune0, TestRune2, TestRune1, TestRune2},
+			String{TestRune0, TestRune3, TestRune1, TestRune2},
+			false,
+		},
+	} {
+		t.Run(test.N, func(t *testing.T) {
+			delta, ok, err := StringMerge(base, test.Ours, test.Theirs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != test.OK {
+				t.Fatal("got", ok, "expected", test.OK)
+			}
+			if !ok {
+				return
+			}
+			if actual := test.Ours.Apply(delta); !reflect.DeepEqual(actual, test.Expect) {
+				t.Error("got", actual, "expected", test.Expect)
+			}
+		})
+	}
+}
+
 func Test_idSliceLCS(t *testing.T) {
 	for _, test := range []struct {
 		N          string
@@ -253,6 +358,12 @@ func TestStringDelta_Rebase(t *testing.T) {
 			String{}.Insert(0, TestRune2),
 			String{}.Insert(1, TestRune2),
 		},
+		{
+			"insert2 vs insert1",
+			String{}.Insert(0, TestRune2),
+			String{}.Insert(0, TestRune1),
+			String{}.Insert(0, TestRune1),
+		},
 		{
 			"insert1 vs retain1",
 			String{}.Insert(0, TestRune1),
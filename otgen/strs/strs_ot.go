@@ -0,0 +1,459 @@
+// Do not modify this file: it is automatically generated
+
+package strs
+
+import (
+	"errors"
+	"strconv"
+)
+
+func (xs Strings) Append(add ...string) StringsDelta {
+	return xs.Insert(len(xs), add...)
+}
+
+func (xs Strings) Retain(r int) StringsDelta {
+	return StringsDelta{}.Retain(r)
+}
+
+func (xs Strings) Insert(i int, add ...string) StringsDelta {
+	return xs.Retain(i).Insert(add...)
+}
+
+func (xs Strings) Delete(i, num int) StringsDelta {
+	return xs.Retain(i).Delete(num)
+}
+
+func (xs Strings) DeleteElements(del ...string) StringsDelta {
+	is := make(map[int]bool)
+	for _, r := range del {
+		for i, x := range xs {
+			if x == r {
+				is[i] = true
+			}
+		}
+	}
+	var delta StringsDelta
+	from := 0
+	deleting := false
+	for i := range xs {
+		if deleting {
+			if !is[i] {
+				delta = delta.Delete(i - from)
+				deleting = false
+				from = i
+			}
+		} else {
+			if is[i] {
+				delta = delta.Retain(i - from)
+				deleting = true
+				from = i
+			}
+		}
+	}
+	if deleting {
+		delta = delta.Delete(len(xs) - from)
+	}
+	return delta
+}
+
+// PrefixMatch returns the number of elements at the beginning of xs that match the
+// elements at the beginning of ys.
+func (xs Strings) PrefixMatch(ys []string) int {
+	i := 0
+	for ; i < len(xs) && i < len(ys); i++ {
+		if xs[i] != ys[i] {
+			break
+		}
+	}
+	return i
+}
+
+type StringsDelta []StringsOp
+
+func (x StringsDelta) Retain(r int) StringsDelta {
+	if r == 0 {
+		return x
+	}
+	return append(x, StringsOpRetain(r))
+}
+func (x StringsDelta) Insert(add ...string) StringsDelta {
+	return append(x, StringsOpInsert(add))
+}
+func (x StringsDelta) Delete(d int) StringsDelta {
+	return append(x, StringsOpDelete(d))
+}
+func (x StringsDelta) Rebase(base StringsDelta) (StringsDelta, error) {
+	var res StringsDelta
+	xi, bi := 0, 0
+	var r, xop, bop StringsOp
+	for {
+		if xop == nil {
+			if xi >= len(x) {
+				break
+			}
+			xop = x[xi]
+			xi++
+		}
+		if bop == nil {
+			if bi >= len(base) {
+				break
+			}
+			bop = base[bi]
+			bi++
+		}
+		r, xop, bop = xop.Rebase(bop)
+		if r != nil {
+			res = append(res, r)
+		}
+	}
+	if xop != nil {
+		res = append(res, xop)
+	}
+	res = append(res, x[xi:]...)
+	var cres StringsDelta
+	for _, r := range res {
+		if len(cres) == 0 {
+			if r.Len() > 0 {
+				cres = append(cres, r)
+			}
+		} else {
+			c, ok := cres[len(cres)-1].Compact(r)
+			if ok {
+				cres[len(cres)-1] = c
+			} else if !ok && r.Len() > 0 {
+				cres = append(cres, r)
+			}
+		}
+	}
+	return cres, nil
+}
+
+type StringsOp interface {
+	// Leaves returns how many elements of a slice of length n would remain
+	// to be transformed by additional ops after applying this op. Returns
+	// a negative number if and only if this op cannot be coherently
+	// applied to a slice of length n.
+	Leaves(n int) int
+	// Len returns the number of elements inserted, retained, or deleted by
+	// this op.
+	Len() int
+	// Skip returns an equivalent op that assumes its intent is already carried
+	// out for the first n elements. May panic if n > Len().
+	Skip(n int) StringsOp
+	// Rebase transforms op into a rebased op r (or nil), a subsequent op for
+	// rebasing xn (or nil), and a subsequent base bn (or nil).
+	Rebase(base StringsOp) (r StringsOp, xn StringsOp, bn StringsOp)
+	// Compact expands this op to include o if possible, returning true if
+	// successful.
+	Compact(o StringsOp) (StringsOp, bool)
+	Apply(Strings) (include Strings, remainder Strings)
+	String() string
+}
+
+type StringsOpInsert []string
+type StringsOpRetain int
+type StringsOpDelete int
+
+func (x StringsOpInsert) Leaves(in int) int { return in }
+func (x StringsOpInsert) Len() int          { return len(x) }
+
+func (x StringsOpInsert) Skip(n int) StringsOp { return x[n:] }
+func (x StringsOpInsert) Rebase(base StringsOp) (StringsOp, StringsOp, StringsOp) {
+	switch bo := base.(type) {
+	case StringsOpInsert:
+		// Where both insert at the same position, the insertion that sorts
+		// first is placed first, so that rebasing either one onto the other
+		// produces the same result.
+		if x.before(bo) {
+			return x, nil, bo
+		}
+		return StringsOpRetain(bo.Len()), x, nil
+	case StringsOpRetain:
+		return x, nil, bo
+	case StringsOpDelete:
+		return x, nil, bo
+	}
+	panic("unknown base type")
+}
+
+// before returns true if x sorts before y.
+func (x StringsOpInsert) before(y StringsOpInsert) bool {
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			return x[i] < y[i]
+		}
+	}
+	return len(x) < len(y)
+}
+func (x StringsOpInsert) Compact(op StringsOp) (StringsOp, bool) {
+	if o, ok := op.(StringsOpInsert); ok {
+		return append(x, o...), true
+	}
+	return x, false
+}
+func (x StringsOpInsert) Apply(xs Strings) (Strings, Strings) {
+	return Strings(x), xs
+}
+
+func (x StringsOpInsert) String() string {
+	return "insert " + Strings(x).String()
+}
+func (x StringsOpRetain) String() string {
+	return "retain " + strconv.Itoa(int(x))
+}
+func (x StringsOpDelete) String() string {
+	return "delete " + strconv.Itoa(int(x))
+}
+
+func (x StringsOpRetain) Leaves(in int) int { return in - int(x) }
+func (x StringsOpRetain) Len() int          { return int(x) }
+
+func (x StringsOpRetain) Skip(n int) StringsOp { return x - StringsOpRetain(n) }
+func (x StringsOpRetain) Rebase(base StringsOp) (StringsOp, StringsOp, StringsOp) {
+	switch bo := base.(type) {
+	case StringsOpInsert:
+		// Retain what has been inserted
+		return x + StringsOpRetain(len(bo)), nil, nil
+	case StringsOpRetain:
+		// Retain the matching prefix
+		switch {
+		case x < bo:
+			return x, nil, bo - x
+		case x == bo:
+			return x, nil, nil
+		case x > bo:
+			return bo, x - bo, nil
+		}
+	case StringsOpDelete:
+		// Can't retain what has been deleted
+		switch {
+		case x.Len() < bo.Len():
+			// Retention is cancelled by deletion and there is still more to delete.
+			return nil, nil, bo - StringsOpDelete(x)
+		case x.Len() == bo.Len():
+			// Retention is cancelled by deletion.
+			return nil, nil, nil
+		case x.Len() > bo.Len():
+			// Retention is partially cancelled by deletion, there is more to retain.
+			return nil, x - StringsOpRetain(bo), nil
+		}
+	}
+	panic("unknown base type")
+}
+func (x StringsOpRetain) Compact(op StringsOp) (StringsOp, bool) {
+	if o, ok := op.(StringsOpRetain); ok {
+		return x + o, true
+	}
+	return x, false
+}
+func (x StringsOpRetain) Apply(xs Strings) (Strings, Strings) {
+	return xs[:x], xs[x:]
+}
+
+func (x StringsOpDelete) Leaves(in int) int { return in - int(x) }
+func (x StringsOpDelete) Len() int          { return int(x) }
+
+func (x StringsOpDelete) Skip(n int) StringsOp { return x - StringsOpDelete(n) }
+func (x StringsOpDelete) Rebase(base StringsOp) (StringsOp, StringsOp, StringsOp) {
+	switch bo := base.(type) {
+	case StringsOpInsert:
+		return StringsOpRetain(bo.Len()), x, nil
+	case StringsOpRetain:
+		// Delete the matching prefix
+		switch {
+		case x.Len() < bo.Len():
+			return x, nil, bo - StringsOpRetain(x)
+		case x.Len() == bo.Len():
+			return x, nil, nil
+		case x.Len() > bo.Len():
+			return StringsOpDelete(bo), x.Skip(bo.Len()), nil
+		}
+	case StringsOpDelete:
+		switch {
+		case x.Len() < bo.Len():
+			return nil, nil, bo.Skip(x.Len())
+		case x.Len() == bo.Len():
+			return nil, nil, nil
+		case x.Len() > bo.Len():
+			return nil, x - bo, nil
+		}
+	}
+	panic("unknown base type")
+}
+func (x StringsOpDelete) Compact(op StringsOp) (StringsOp, bool) {
+	if o, ok := op.(StringsOpDelete); ok {
+		return x + o, true
+	}
+	return x, false
+}
+func (x StringsOpDelete) Apply(xs Strings) (Strings, Strings) {
+	return nil, xs[x:]
+}
+
+func (xs Strings) CanApply(ops []StringsOp) bool {
+	ln := len(xs)
+	for _, op := range ops {
+		if ln = op.Leaves(ln); ln < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Invert returns a delta that undoes x after it has been applied to base.
+//
+// An error is returned if x cannot be applied to base.
+func (x StringsDelta) Invert(base Strings) (StringsDelta, error) {
+	if !base.CanApply(x) {
+		return nil, errors.New("cannot invert a delta that cannot be applied")
+	}
+	var inv StringsDelta
+	for _, op := range x {
+		switch o := op.(type) {
+		case StringsOpInsert:
+			inv = append(inv, StringsOpDelete(len(o)))
+		case StringsOpRetain:
+			inv = append(inv, o)
+			base = base[o:]
+		case StringsOpDelete:
+			inv = append(inv, StringsOpInsert(append(Strings{}, base[:o]...)))
+			base = base[o:]
+		}
+	}
+	return inv, nil
+}
+
+func (xs Strings) Apply(ops []StringsOp) Strings {
+	var head, mid, tail Strings
+	tail = xs
+	for _, op := range ops {
+		mid, tail = op.Apply(tail)
+		head = append(head, mid...)
+	}
+	return append(head, tail...)
+}
+
+// StringsDiff produces a set of operations that can be applied to xs to
+// produce a slice that would match slice b.
+func StringsDiff(a, b []string) StringsDelta {
+	var (
+		ops                StringsDelta
+		amid, bmid, midlen = idSliceLCS(Strings(a), Strings(b))
+	)
+	if midlen == 0 {
+		if len(a) > 0 {
+			ops = append(ops, StringsOpDelete(len(a)))
+		}
+		if len(b) > 0 {
+			ops = append(ops, StringsOpInsert(b))
+		}
+	} else {
+		ops = append(ops, StringsDiff(a[:amid], b[:bmid])...)
+		ops = append(ops, StringsOpRetain(midlen))
+		ops = append(ops, StringsDiff(a[amid+midlen:], b[bmid+midlen:])...)
+	}
+	return ops
+}
+
+// StringsMerge produces a delta that can be applied to ours to include
+// the changes made from base to theirs.
+//
+// Returns false instead if ours and theirs change overlapping parts of base,
+// or if both insert at the same position.
+func StringsMerge(base, ours, theirs []string) (StringsDelta, bool, error) {
+	equal := func(xs, ys []string) bool {
+		return len(xs) == len(ys) && Strings(xs).PrefixMatch(ys) == len(xs)
+	}
+	switch {
+	case equal(ours, theirs), equal(theirs, base):
+		return nil, true, nil
+	case equal(ours, base):
+		return StringsDiff(ours, theirs), true, nil
+	}
+	od, td := StringsDiff(base, ours), StringsDiff(base, theirs)
+	if editSpansOverlap(od.editSpans(), td.editSpans()) {
+		return nil, false, nil
+	}
+	r, err := td.Rebase(od)
+	if err != nil {
+		return nil, false, err
+	}
+	if !Strings(ours).CanApply(r) {
+		return nil, false, errors.New("cannot merge changes that cannot be applied")
+	}
+	return r, true, nil
+}
+
+// editSpan is a range of a base slice changed by a delta, where adjacent
+// deletions and insertions form a single span.
+type editSpan struct {
+	start, end int
+	inserts    bool
+}
+
+// editSpans returns the ranges of a base slice changed by x.
+func (x StringsDelta) editSpans() []editSpan {
+	var (
+		spans []editSpan
+		pos   int
+		open  bool
+	)
+	for _, op := range x {
+		if r, ok := op.(StringsOpRetain); ok {
+			pos += int(r)
+			open = false
+			continue
+		}
+		if !open {
+			spans = append(spans, editSpan{start: pos, end: pos})
+			open = true
+		}
+		last := &spans[len(spans)-1]
+		if d, ok := op.(StringsOpDelete); ok {
+			pos += int(d)
+			last.end = pos
+		} else {
+			last.inserts = true
+		}
+	}
+	return spans
+}
+
+// editSpansOverlap returns true if any span in xs overlaps a span in ys, or if
+// both insert at the same position.
+func editSpansOverlap(xs, ys []editSpan) bool {
+	for _, a := range xs {
+		for _, b := range ys {
+			if a.start < b.end && b.start < a.end {
+				return true
+			}
+			if a.inserts && b.inserts && a.start <= b.end && b.start <= a.end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func idSliceLCS(a, b Strings) (ai, bi, ln int) {
+	ls := make([]int, len(a)*len(b))
+	max := 0
+	a0, b0 := 0, 0
+	for ai, aa := range a {
+		for bi, bb := range b {
+			if aa == bb {
+				li := ai*len(b) + bi
+				if ai == 0 || bi == 0 {
+					ls[li] = 1
+				} else {
+					ls[li] = ls[(ai-1)*len(b)+bi-1] + 1
+				}
+				if ls[li] > max {
+					max = ls[li]
+					a0, b0 = ai+1-max, bi+1-max
+				}
+			}
+		}
+	}
+	return a0, b0, max
+}
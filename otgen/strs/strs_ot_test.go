@@ -0,0 +1,426 @@
+// Do not modify this file: it is automatically generated
+
+// NOTE: these tests require the following definitions in a nearby _test.go
+// file:
+//
+// const (
+//   TestString0
+//   TestString1
+//   TestString2
+//   TestString3
+// )
+
+package strs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStringsOp_String(t *testing.T) {
+	slice := Strings{TestString0, TestString1}
+	for _, test := range []struct {
+		O StringsOp
+		S string
+	}{
+		{StringsOpDelete(3), "delete 3"},
+		{StringsOpRetain(3), "retain 3"},
+		{StringsOpInsert(slice), "insert " + slice.String()},
+	} {
+		if actual := test.O.String(); actual != test.S {
+			t.Errorf("got %#v, expected %#v", actual, test.S)
+		}
+	}
+}
+
+func TestStrings_PrefixMatch(t *testing.T) {
+	for _, test := range []struct {
+		N    string
+		A, B Strings
+		M    int
+	}{
+		{N: "empty"},
+		{
+			N: "short A",
+			A: Strings{TestString0},
+			B: Strings{TestString0, TestString1},
+			M: 1,
+		},
+		{
+			N: "long A",
+			A: Strings{TestString0, TestString1},
+			B: Strings{TestString0},
+			M: 1,
+		},
+		{
+			N: "equal length partial match",
+			A: Strings{TestString0, TestString1},
+			B: Strings{TestString0, TestString2},
+			M: 1,
+		},
+		{
+			N: "equal length full match",
+			A: Strings{TestString0, TestString1},
+			B: Strings{TestString0, TestString1},
+			M: 2,
+		},
+	} {
+		t.Run(test.N, func(t *testing.T) {
+			actual := test.A.PrefixMatch(test.B)
+			if actual != test.M {
+				t.Error("got", actual, "expected", test.M)
+			}
+		})
+	}
+	delta := Strings{TestString0}.
+		Append(TestString1, TestString2)
+	actual := Strings{TestString3}.Apply(delta)
+	expect := Strings{
+		TestString3,
+		TestString1,
+		TestString2,
+	}
+	if len(actual) != len(expect) || actual.PrefixMatch(expect) != len(actual) {
+		t.Error("got", actual, "expected", expect)
+	}
+}
+
+func TestStrings_CanApply(t *testing.T) {
+	for _, test := range []struct {
+		S   Strings
+		D   []StringsOp
+		Can bool
+	}{
+		{Can: false, D: []StringsOp{StringsOpDelete(1)}},
+		{Can: false, D: []StringsOp{StringsOpRetain(1)}},
+		{Can: true, D: []StringsOp{StringsOpInsert{TestString0}}},
+	} {
+		if can := test.S.CanApply(test.D); can != test.Can {
+			t.Error("got", can, "expected", test.Can, "for", test.S, test.D)
+		}
+	}
+}
+
+func TestStrings_Append(t *testing.T) {
+	delta := Strings{TestString0}.
+		Append(TestString1, TestString2)
+	actual := Strings{TestString3}.Apply(delta)
+	expect := Strings{
+		TestString3,
+		TestString1,
+		TestString2,
+	}
+	if len(actual) != len(expect) || actual.PrefixMatch(expect) != len(actual) {
+		t.Error("got", actual, "expected", expect)
+	}
+}
+
+func TestStrings_DeleteElements(t *testing.T) {
+	base := Strings{TestString0, TestString1, TestString0}
+	delta := base.DeleteElements(TestString0)
+	if !base.CanApply(delta) {
+		t.Error("delta", delta, "cannot be applied to the slice used to create it",
+			base)
+	}
+	actual := base.Apply(delta)
+	expect := Strings{TestString1}
+	if len(actual) != len(expect) || actual.PrefixMatch(expect) != len(actual) {
+		t.Error("got", actual, "expected", expect)
+	}
+}
+
+func TestStrings_fluentDelta(t *testing.T) {
+	base := Strings{TestString0, TestString1}
+	delta := base.
+		Delete(0, 1).
+		Insert(TestString2).
+		Retain(1).
+		Insert(TestString0)
+	if !base.CanApply(delta) {
+		t.Error("delta", delta, "cannot be applied to the slice used to create it",
+			base)
+	}
+	actual := base.Apply(delta)
+	expect := Strings{
+		TestString2,
+		TestString1,
+		TestString0,
+	}
+	if len(actual) != len(expect) || actual.PrefixMatch(expect) != len(actual) {
+		t.Error("got", actual, "expected", expect)
+	}
+}
+
+func TestStrings_Apply(t *testing.T) {
+	for _, test := range []struct {
+		In       Strings
+		Ops      []StringsOp
+		Out      Strings
+		CanApply bool
+	}{
+		{
+			In:       Strings{TestString0},
+			Ops:      []StringsOp{StringsOpRetain(1)},
+			CanApply: true,
+			Out:      Strings{TestString0},
+		},
+	} {
+		apply := test.In.CanApply(test.Ops)
+		if apply != test.CanApply {
+			t.Error("got apply=", apply, "expected apply=", test.CanApply)
+		}
+		if !apply {
+			continue
+		}
+		out := test.In.Apply(test.Ops)
+		if !reflect.DeepEqual(out, test.Out) {
+			t.Error("got", out, "expected", test.Out)
+		}
+	}
+}
+
+func TestStringsDelta_Rebase_converges(t *testing.T) {
+	base := Strings{TestString0, TestString1}
+	deltas := []StringsDelta{
+		base.Insert(0, TestString2),
+		base.Insert(0, TestString3, TestString0),
+		base.Insert(1, TestString2),
+		base.Append(TestString3),
+		base.Delete(0, 1),
+		base.Delete(0, 1).Insert(TestString3),
+		base.Retain(1).Delete(1),
+	}
+	for _, a := range deltas {
+		for _, b := range deltas {
+			a2, err := a.Rebase(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b2, err := b.Rebase(a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ab := base.Apply(a).Apply(b2)
+			ba := base.Apply(b).Apply(a2)
+			if !reflect.DeepEqual(ab, ba) {
+				t.Error(a, "and", b, "diverge:", ab, "and", ba)
+			}
+		}
+	}
+}
+
+func TestStringsDelta_Invert(t *testing.T) {
+	base := Strings{TestString0, TestString1, TestString2}
+	for _, delta := range []StringsDelta{
+		nil,
+		base.Append(TestString3),
+		base.Insert(1, TestString3),
+		base.Delete(0, 2),
+		base.DeleteElements(TestString1),
+		StringsDiff(base, Strings{TestString2, TestString3, TestString0}),
+	} {
+		inv, err := delta.Invert(base)
+		if err != nil {
+			t.Error(delta, err)
+			continue
+		}
+		if got := base.Apply(delta).Apply(inv); !reflect.DeepEqual(got, base) {
+			t.Error(delta, "then", inv, "got", got, "expected", base)
+		}
+	}
+	if _, err := base.Delete(0, 3).Invert(base[:1]); err == nil {
+		t.Error("expected an error for a delta that cannot be applied")
+	}
+}
+
+func TestStrings_Diff_andApply(t *testing.T) {
+	for _, test := range []struct {
+		N      string
+		A, B   Strings
+		LenOps int
+	}{
+		{"insert", Strings{}, Strings{TestString0}, 1},
+		{"delete", Strings{TestString0}, Strings{}, 1},
+		{"retain", Strings{TestString0}, Strings{TestString0}, 1},
+		{"insert, delete", Strings{TestString0}, Strings{TestString1}, 2},
+		{"delete, retain", Strings{TestString0, TestString1, TestString2}, Strings{TestString1, TestString2}, 2},
+		{"retain, delete", Strings{TestString0, TestString1, TestString2}, Strings{TestString0, TestString1}, 2},
+		{"retain, delete, insert, retain", Strings{TestString0, TestString1, TestString2}, Strings{TestString0, TestString3, TestString2}, 4},
+	} {
+		t.Run(test.N, func(t *testing.T) {
+			diff := StringsDiff(test.A, test.B)
+			if len(diff) != test.LenOps {
+				t.Error("got", diff, "with len", len(diff), "expected len", test.LenOps)
+			}
+			if !test.A.CanApply(diff) {
+				t.Error("cannot apply diff")
+			} else {
+				actual := test.A.Apply(diff)
+				if !(len(actual) == 0 && len(test.B) == 0) &&
+					!reflect.DeepEqual(actual, test.B) {
+					t.Error("got", actual, "expected", test.B)
+				}
+			}
+		})
+	}
+}
+
+func TestStringsMerge(t *testing.T) {
+	base := Strings{TestString0, TestString1, TestString2}
+	for _, test := range []struct {
+		N            string
+		Ours, Theirs Strings
+		Expect       Strings
+		OK           bool
+	}{
+		{"unchanged", base, base, base, true},
+		{"only ours", Strings{TestString0}, base, Strings{TestString0}, true},
+		{"only theirs", base, Strings{TestString0}, Strings{TestString0}, true},
+		{
+			"separate changes",
+			Strings{TestString3, TestString0, TestString1, TestString2},
+			Strings{TestString0, TestString1},
+			Strings{TestString3, TestString0, TestString1},
+			true,
+		},
+		{
+			"overlapping deletes",
+			Strings{TestString2},
+			Strings{TestString0},
+			Strings{TestString2},
+			false,
+		},
+		{
+			"inserts at the same position",
+			Strings{TestString0, TestString3, TestString1, TestString2},
+			Strings{TestString0, TestString2, TestString1, TestString2},
+			Strings{TestString0, TestString3, TestString1, TestString2},
+			false,
+		},
+	} {
+		t.Run(test.N, func(t *testing.T) {
+			delta, ok, err := StringsMerge(base, test.Ours, test.Theirs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != test.OK {
+				t.Fatal("got", ok, "expected", test.OK)
+			}
+			if !ok {
+				return
+			}
+			if actual := test.Ours.Apply(delta); !reflect.DeepEqual(actual, test.Expect) {
+				t.Error("got", actual, "expected", test.Expect)
+			}
+		})
+	}
+}
+
+func Test_idSliceLCS(t *testing.T) {
+	for _, test := range []struct {
+		N          string
+		A, B       Strings
+		AI, BI, LN int
+	}{
+		{"both empty", Strings{}, Strings{}, 0, 0, 0},
+		{"a empty", Strings{TestString0}, Strings{}, 0, 0, 0},
+		{"b empty", Strings{}, Strings{TestString0}, 0, 0, 0},
+		{"total mismatch", Strings{TestString0, TestString1}, Strings{TestString2, TestString4}, 0, 0, 0},
+		{"match at start", Strings{TestString0, TestString1}, Strings{TestString0, TestString2}, 0, 0, 1},
+		{"match at end", Strings{TestString0, TestString1}, Strings{TestString2, TestString1}, 1, 1, 1},
+		{"match all", Strings{TestString0, TestString1}, Strings{TestString0, TestString1}, 0, 0, 2},
+		{"match multi middle", Strings{TestString2, TestString0, TestString1, TestString3}, Strings{TestString3, TestString0, TestString1, TestString2}, 1, 1, 2},
+	} {
+		t.Run(test.N, func(t *testing.T) {
+			ai, bi, ln := idSliceLCS(test.A, test.B)
+			if ai != test.AI || bi != test.BI || ln != test.LN {
+				t.Error("got", ai, bi, ln, "expected", test.AI, test.BI, test.LN)
+			}
+		})
+	}
+}
+
+func TestStringsDelta_Rebase(t *testing.T) {
+	for _, test := range []struct {
+		N            string
+		A, B, Expect StringsDelta
+	}{
+		{"both empty", nil, nil, nil},
+		{"insert1 vs empty", Strings{}.Insert(0, TestString1), nil, nil},
+		{"retain1 vs empty", Strings{}.Retain(1), nil, nil},
+		{"delete1 vs empty", Strings{}.Delete(0, 1), nil, nil},
+		{"empty vs insert1", nil, Strings{}.Insert(0, TestString1), Strings{}.Insert(0, TestString1)},
+		{"empty vs retain1", nil, Strings{}.Retain(1), Strings{}.Retain(1)},
+		{"empty vs delete1", nil, Strings{}.Delete(0, 1), Strings{}.Delete(0, 1)},
+		{
+			"insert1 vs insert1",
+			Strings{}.Insert(0, TestString1),
+			Strings{}.Insert(0, TestString2),
+			Strings{}.Insert(1, TestString2),
+		},
+		{
+			"insert2 vs insert1",
+			Strings{}.Insert(0, TestString2),
+			Strings{}.Insert(0, TestString1),
+			Strings{}.Insert(0, TestString1),
+		},
+		{
+			"insert1 vs retain1",
+			Strings{}.Insert(0, TestString1),
+			Strings{}.Retain(1),
+			Strings{}.Retain(2),
+		},
+		{
+			"insert1 vs delete1",
+			Strings{}.Insert(0, TestString1),
+			Strings{}.Delete(0, 1),
+			Strings{}.Retain(1).Delete(1),
+		},
+		{
+			"retain1 vs insert1",
+			Strings{}.Retain(1),
+			Strings{}.Insert(0, TestString1),
+			Strings{}.Insert(0, TestString1),
+		},
+		{
+			"retain1 vs retain1",
+			Strings{}.Retain(1),
+			Strings{}.Retain(1),
+			Strings{}.Retain(1),
+		},
+		{
+			"retain1 vs delete1",
+			Strings{}.Retain(1),
+			Strings{}.Delete(0, 1),
+			Strings{}.Delete(0, 1),
+		},
+		{
+			"delete1 vs insert1",
+			Strings{}.Delete(0, 1),
+			Strings{}.Insert(0, TestString1),
+			Strings{}.Insert(0, TestString1),
+		},
+		{
+			"delete1 vs retain1",
+			Strings{}.Delete(0, 1),
+			Strings{}.Retain(1),
+			Strings{}.Retain(0),
+		},
+		{
+			"delete1 vs delete1",
+			Strings{}.Delete(0, 1),
+			Strings{}.Delete(0, 1),
+			Strings{}.Retain(0),
+		},
+	} {
+		t.Run(test.N, func(t *testing.T) {
+			actual, err := test.B.Rebase(test.A)
+			if err != nil {
+				t.Error(err)
+			} else if len(actual) == 0 && len(test.Expect) == 0 {
+			} else if !reflect.DeepEqual(actual, test.Expect) {
+				t.Error("got", actual, "expected", test.Expect)
+			}
+		})
+	}
+}
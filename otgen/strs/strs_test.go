@@ -0,0 +1,31 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strs
+
+import "testing"
+
+const (
+	TestString0 = "a"
+	TestString1 = "b"
+	TestString2 = "c"
+	TestString3 = "d"
+	TestString4 = "e"
+)
+
+func TestStrings_String(t *testing.T) {
+	if actual := (Strings{"a", "b"}).String(); actual != "[a,b]" {
+		t.Errorf("got %#v, expected %#v", actual, "[a,b]")
+	}
+}